@@ -3,15 +3,23 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/api/spec"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_server"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/selfcheck"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -22,8 +30,29 @@ var prettyPrint bool
 var autoPaginate int = 0
 var port int
 var verbose bool
+var outputFormat string
+var outputFields []string
+var outputFilter string
+var batchParam string
+var batchFile string
+var batchSize int
+var batchConcurrency int
+var watchInterval time.Duration
+var watchKey string
+var watchFormat string
+var watchUntil string
+var watchExec string
+var watchWebhook string
+var target string
+var asUser string
+var collectionEnv string
+var collectionVars []string
+var collectionJUnit string
+var collectionFailFast bool
 
 var generateCount int
+var selfcheckSpec string
+var selfcheckOutput string
 
 var apiCmd = &cobra.Command{
 	Use:   "api",
@@ -66,6 +95,41 @@ var putCmd = &cobra.Command{
 	RunE:      cmdRun,
 }
 
+var batchCmd = &cobra.Command{
+	Use:   "batch <file>",
+	Short: "Runs a list of requests from a JSON Lines file, writing one result line per request. Use - to read from stdin.",
+	Example: `  twitch api batch requests.jsonl
+  twitch api batch requests.jsonl --concurrency 8 > results.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: batchCmdRun,
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch get <path>",
+	Short: "Polls a GET endpoint, printing only the items that were added, removed or changed since the last poll.",
+	Long: `Polls a GET endpoint, printing only the items that were added, removed or changed since the last poll.
+Items are matched between polls by --key. The first poll is printed in full, and later polls print the changes as a colored diff or an RFC 6902 JSON Patch.`,
+	Example: `  twitch api watch get streams -q user_login=foo --interval 15s
+  twitch api watch get polls -q broadcaster_id=1234 --format patch
+  twitch api watch get streams -q user_login=foo --until 'length > 0' --exec 'notify-send "foo is live"'`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: watchCmdRun,
+}
+
+var collectionCmd = &cobra.Command{
+	Use:   "collection",
+	Short: "Used to run saved collections of API requests with variables and assertions.",
+}
+
+var collectionRunCmd = &cobra.Command{
+	Use:   "run <file>",
+	Short: "Runs the requests of a collection in order, checking their assertions. Exits with an error if any request fails.",
+	Example: `  twitch api collection run flow.yaml --env mock.yaml
+  twitch api collection run flow.yaml --target mock --as-user foo --var broadcaster_id=1234 --junit report.xml`,
+	Args: cobra.ExactArgs(1),
+	RunE: collectionRunCmdRun,
+}
+
 var mockCmd = &cobra.Command{
 	Use:   "mock-api",
 	Short: "Used to interface with the mock Twitch API.",
@@ -77,6 +141,17 @@ var startCmd = &cobra.Command{
 	RunE:  mockStartRun,
 }
 
+var selfcheckCmd = &cobra.Command{
+	Use:   "selfcheck",
+	Short: "Checks the mock API's endpoints against the Helix API specification, listing those that diverge from it.",
+	Long: `Checks the mock API's endpoints against the Helix API specification, listing those that diverge from it.
+Each endpoint is called on a temporary server with generated data, with a valid request and with requests missing their authorization, required parameters or body. The status and body of each response are compared with the specification.`,
+	Example: `  twitch mock-api selfcheck
+  twitch mock-api selfcheck --spec helix-openapi.json -o json`,
+	Args: cobra.NoArgs,
+	RunE: selfcheckRun,
+}
+
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Used to randomly generate data for use with the mock API. By default, this is run on the first invocation of the start command, however this allows you to generate further primitives.",
@@ -86,11 +161,20 @@ var generateCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(apiCmd, mockCmd)
 
-	apiCmd.AddCommand(getCmd, postCmd, patchCmd, deleteCmd, putCmd)
+	apiCmd.AddCommand(getCmd, postCmd, patchCmd, deleteCmd, putCmd, batchCmd, watchCmd, collectionCmd)
 
 	apiCmd.PersistentFlags().StringArrayVarP(&queryParameters, "query-params", "q", nil, "Available multiple times. Passes in query parameters to endpoints using the format of `key=value`.")
 	apiCmd.PersistentFlags().StringVarP(&body, "body", "b", "", "Passes a body to the request. Alteratively supports CURL-like references to files using the format of `@data,json`.")
 	apiCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Whether to display HTTP request and header information above the response of the API call.")
+	apiCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", api.OutputJSON, fmt.Sprintf("Format to print the response in. Supported values: %v", strings.Join(api.OutputFormats, ", ")))
+	apiCmd.PersistentFlags().StringSliceVar(&outputFields, "fields", nil, "Comma separated fields to keep from each item of data, such as id,login,display_name. Nested fields are separated with a dot.")
+	apiCmd.PersistentFlags().StringVar(&outputFilter, "filter", "", "jq-style expression applied to data, such as '.[] | select(.viewer_count > 100)'.")
+	apiCmd.PersistentFlags().StringVar(&batchParam, "batch-param", "", "Query parameter to batch the values from --from-file into, such as id or login. Makes one request per batch and merges the results.")
+	apiCmd.PersistentFlags().StringVar(&batchFile, "from-file", "", "File with one value per line for --batch-param. Use - to read from stdin.")
+	apiCmd.PersistentFlags().IntVar(&batchSize, "batch-size", api.MaxBatchSize, "Number of values to send in each batched request.")
+	apiCmd.PersistentFlags().IntVar(&batchConcurrency, "concurrency", 4, "Number of batched requests to run at once.")
+	apiCmd.PersistentFlags().StringVar(&target, "target", "", "API to send requests to: production, mock, or mock:<port>. The mock is authorized automatically. Default is the configured BASE_URL, or production.")
+	apiCmd.PersistentFlags().StringVar(&asUser, "as-user", "", "Login of the mock user to authorize as, with the scopes the endpoint requires. Requires --target mock. Default is an app access token.")
 
 	// default here is false to enable -p commands to toggle off without explicitly defining -p=false as -p false will not work. The below commands invert the bool to pass the true default. Deprecated, so marking as hidden in favor of the unformatted flag.
 	apiCmd.PersistentFlags().BoolVarP(&prettyPrint, "pretty-print", "p", false, "Whether to pretty-print API requests. Default is true.")
@@ -101,11 +185,32 @@ func init() {
 	getCmd.PersistentFlags().IntVarP(&autoPaginate, "autopaginate", "P", 0, "Whether to have API requests automatically paginate. Default is to not paginate.")
 	getCmd.PersistentFlags().Lookup("autopaginate").NoOptDefVal = "0"
 
-	mockCmd.AddCommand(startCmd, generateCmd)
+	for _, c := range []*cobra.Command{getCmd, postCmd, patchCmd, deleteCmd, putCmd} {
+		addEndpointCommands(c)
+	}
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "How often to poll the endpoint, such as 15s or 1m.")
+	watchCmd.Flags().StringVar(&watchKey, "key", "id", "Field that identifies each item between polls.")
+	watchCmd.Flags().StringVar(&watchFormat, "format", api.WatchDiff, fmt.Sprintf("Format to print changes in. Supported values: %v", strings.Join(api.WatchFormats, ", ")))
+	watchCmd.Flags().StringVar(&watchUntil, "until", "", "jq-style expression evaluated against the data of each poll. Stops watching once it's true, such as 'length > 0'.")
+	watchCmd.Flags().StringVar(&watchExec, "exec", "", "Shell command to run after each change. The JSON Patch of the change is passed on stdin and in TWITCH_WATCH_PATCH.")
+	watchCmd.Flags().StringVar(&watchWebhook, "webhook", "", "URL to POST the JSON Patch of each change to.")
+
+	collectionCmd.AddCommand(collectionRunCmd)
+
+	collectionRunCmd.Flags().StringVar(&collectionEnv, "env", "", "Environment file with the target and variables to run the collection with, such as mock.yaml or production.yaml.")
+	collectionRunCmd.Flags().StringArrayVar(&collectionVars, "var", nil, "Available multiple times. Sets a variable of the collection using the format of `key=value`, overriding the collection and environment.")
+	collectionRunCmd.Flags().StringVar(&collectionJUnit, "junit", "", "File to write a JUnit XML report of the run to.")
+	collectionRunCmd.Flags().BoolVar(&collectionFailFast, "fail-fast", false, "Skip the remaining requests after the first that fails.")
+
+	mockCmd.AddCommand(startCmd, generateCmd, selfcheckCmd)
 
 	startCmd.Flags().IntVarP(&port, "port", "p", 8080, "Defines the port that the mock API will run on.")
 
 	generateCmd.Flags().IntVarP(&generateCount, "count", "c", 25, "Defines the number of fake users to generate.")
+
+	selfcheckCmd.Flags().StringVar(&selfcheckSpec, "spec", "", "OpenAPI specification to check against, in the format of the embedded one. Default is the specification embedded in the CLI.")
+	selfcheckCmd.Flags().StringVarP(&selfcheckOutput, "output", "o", "text", "Format to print the results in. Supported values: text, json")
 }
 
 func cmdRun(cmd *cobra.Command, args []string) error {
@@ -120,6 +225,11 @@ func cmdRun(cmd *cobra.Command, args []string) error {
 		path = "/" + strings.Join(args[:], "/")
 	}
 
+	return runRequest(cmd, cmd.Name(), path, queryParameters, nil)
+}
+
+// runRequest makes the request for an api command. When endpoint is set, the query parameters and body are first validated against it.
+func runRequest(cmd *cobra.Command, method string, path string, queryParameters []string, endpoint *spec.Endpoint) error {
 	if body != "" && body[:1] == "@" {
 		var err error
 		body, err = getBodyFromFile(body[1:])
@@ -128,11 +238,190 @@ func cmdRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if cmd.Name() == "get" && cmd.PersistentFlags().Lookup("autopaginate").Changed {
-		return api.NewRequest(cmd.Name(), path, queryParameters, []byte(body), !prettyPrint, &autoPaginate, verbose)
+	if endpoint != nil {
+		if err := validateEndpointRequest(*endpoint, queryParameters, []byte(body)); err != nil {
+			return err
+		}
+	}
+
+	if err := useTarget(method, path); err != nil {
+		return err
+	}
+
+	output := api.OutputOptions{
+		Format: outputFormat,
+		Fields: outputFields,
+		Filter: outputFilter,
+	}
+
+	if batchParam != "" || batchFile != "" {
+		if batchParam == "" || batchFile == "" {
+			return fmt.Errorf("--batch-param and --from-file must be used together")
+		}
+		if cmd.Flags().Changed("autopaginate") {
+			return fmt.Errorf("--autopaginate can't be used with batched requests")
+		}
+
+		values, err := readBatchFile(batchFile)
+		if err != nil {
+			return err
+		}
+		return api.NewBatchRequest(method, path, queryParameters, []byte(body), !prettyPrint, verbose, output, api.BatchParameters{
+			Param:       batchParam,
+			Values:      values,
+			Size:        batchSize,
+			Concurrency: batchConcurrency,
+		})
+	}
+
+	if method == "get" && cmd.Flags().Changed("autopaginate") {
+		return api.NewRequest(method, path, queryParameters, []byte(body), !prettyPrint, &autoPaginate, verbose, output)
 	} else {
-		return api.NewRequest(cmd.Name(), path, queryParameters, []byte(body), !prettyPrint, nil, verbose) // only set on when the user changed the flag
+		return api.NewRequest(method, path, queryParameters, []byte(body), !prettyPrint, nil, verbose, output) // only set on when the user changed the flag
+	}
+}
+
+func readBatchFile(filename string) ([]string, error) {
+	if filename == "-" {
+		return api.ReadBatchValues(os.Stdin)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return api.ReadBatchValues(f)
+}
+
+// useTarget applies --target and --as-user to a request. Without a method, the mock user is given every scope, for
+// request lists whose requests aren't known in advance.
+func useTarget(method string, path string) error {
+	var scopes []string
+	if asUser != "" {
+		scopes = api.MockScopes(method, path)
+	}
+	return api.UseTarget(api.TargetParameters{Target: target, AsUser: asUser, Scopes: scopes})
+}
+
+func batchCmdRun(cmd *cobra.Command, args []string) error {
+	if err := useTarget("", ""); err != nil {
+		return err
+	}
+
+	if args[0] == "-" {
+		return api.RunRequestList(os.Stdin, os.Stdout, batchConcurrency)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return api.RunRequestList(f, os.Stdout, batchConcurrency)
+}
+
+func watchCmdRun(cmd *cobra.Command, args []string) error {
+	if !strings.EqualFold(args[0], "get") {
+		return fmt.Errorf("Only GET requests can be watched")
+	}
+
+	path := "/" + strings.Join(args[1:], "/")
+	if strings.HasPrefix(args[1], "/") {
+		path = strings.Join(args[1:], "/")
+	}
+
+	if err := useTarget(http.MethodGet, path); err != nil {
+		return err
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return api.Watch(ctx, os.Stdout, api.WatchParameters{
+		Path:            path,
+		QueryParameters: queryParameters,
+		Interval:        watchInterval,
+		Key:             watchKey,
+		Format:          watchFormat,
+		Output: api.OutputOptions{
+			Fields: outputFields,
+			Filter: outputFilter,
+		},
+		Until:   watchUntil,
+		Exec:    watchExec,
+		Webhook: watchWebhook,
+	})
+}
+
+func collectionRunCmdRun(cmd *cobra.Command, args []string) error {
+	c, err := api.LoadCollection(args[0])
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]any{}
+	var env api.CollectionEnvironment
+	if collectionEnv != "" {
+		env, err = api.LoadCollectionEnvironment(collectionEnv)
+		if err != nil {
+			return err
+		}
+		for k, v := range env.Variables {
+			vars[k] = v
+		}
+	}
+	for _, v := range collectionVars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return fmt.Errorf("Invalid variable %v. Use the format of key=value", v)
+		}
+		vars[key] = value
+	}
+
+	// the flags take precedence over the environment
+	p := api.TargetParameters{Target: env.Target, AsUser: env.AsUser}
+	if cmd.Flags().Changed("target") {
+		p.Target = target
+	}
+	if cmd.Flags().Changed("as-user") {
+		p.AsUser = asUser
+	}
+	if p.AsUser != "" {
+		p.Scopes = api.MockScopes("", "")
+	}
+	if err := api.UseTarget(p); err != nil {
+		return err
+	}
+
+	started := time.Now()
+	results, err := api.RunCollection(c, api.CollectionRunParameters{Variables: vars, FailFast: collectionFailFast}, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	if collectionJUnit != "" {
+		f, err := os.Create(collectionJUnit)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := api.WriteJUnitReport(f, c.Name, started, results); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.Passed() {
+			failed++
+		}
+	}
+	fmt.Printf("\n%v of %v requests passed\n", len(results)-failed, len(results))
+	if failed != 0 {
+		return fmt.Errorf("%v of %v requests failed", failed, len(results))
+	}
+	return nil
 }
 
 func getBodyFromFile(filename string) (string, error) {
@@ -153,3 +442,54 @@ func generateMockRun(cmd *cobra.Command, args []string) error {
 	generate.Generate(generateCount)
 	return nil
 }
+
+func selfcheckRun(cmd *cobra.Command, args []string) error {
+	if selfcheckOutput != "text" && selfcheckOutput != "json" {
+		return fmt.Errorf("Invalid output format %v. Supported values: text, json", selfcheckOutput)
+	}
+
+	var opts selfcheck.Options
+	if selfcheckSpec != "" {
+		b, err := os.ReadFile(selfcheckSpec)
+		if err != nil {
+			return err
+		}
+		opts.Spec, err = spec.Parse(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	results, err := selfcheck.Run(opts)
+	if err != nil {
+		return err
+	}
+
+	if selfcheckOutput == "json" {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		c := color.New(color.FgGreen)
+		switch r.Status {
+		case selfcheck.StatusDiverges:
+			c = color.New(color.FgRed)
+		case selfcheck.StatusNoSpec, selfcheck.StatusSkipped, selfcheck.StatusNotMocked:
+			c = color.New(color.FgYellow)
+		}
+		c.Printf("%-10v %-6v %v\n", strings.ToUpper(r.Status), r.Method, r.Path)
+		for _, p := range r.Problems {
+			fmt.Printf("    %v\n", p)
+		}
+	}
+	fmt.Printf("\n%v match, %v diverge, %v have no spec, %v not mocked, %v skipped\n", counts[selfcheck.StatusMatch],
+		counts[selfcheck.StatusDiverges], counts[selfcheck.StatusNoSpec], counts[selfcheck.StatusNotMocked], counts[selfcheck.StatusSkipped])
+	return nil
+}
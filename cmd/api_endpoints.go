@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/twitchdev/twitch-cli/internal/api/spec"
+)
+
+// addEndpointCommands adds a subcommand to methodCmd for each endpoint in the API specification that supports its method,
+// with a flag for each of the endpoint's query parameters. Nested paths, such as /channels/followers, become nested subcommands.
+func addEndpointCommands(methodCmd *cobra.Command) {
+	endpoints, err := spec.Endpoints()
+	if err != nil {
+		// the generic commands still work without the specification
+		return
+	}
+
+	for _, e := range endpoints {
+		if e.Method != strings.ToUpper(methodCmd.Name()) {
+			continue
+		}
+
+		parent := methodCmd
+		segments := strings.Split(strings.TrimPrefix(e.Path, "/"), "/")
+		for i, segment := range segments {
+			child := findSubcommand(parent, segment)
+			if child == nil {
+				child = pathCommand(methodCmd.Name(), segments[:i+1])
+				parent.AddCommand(child)
+			}
+			parent = child
+		}
+		configureEndpointCommand(parent, methodCmd.Name(), e)
+	}
+}
+
+func findSubcommand(parent *cobra.Command, name string) *cobra.Command {
+	for _, c := range parent.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// pathCommand is a subcommand for a path that isn't in the specification. Like the method commands, any arguments are added to the path.
+func pathCommand(method string, segments []string) *cobra.Command {
+	path := "/" + strings.Join(segments, "/")
+	return &cobra.Command{
+		Use:   segments[len(segments)-1],
+		Short: fmt.Sprintf("Performs a %v request on endpoints under %v.", strings.ToUpper(method), path),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRequest(cmd, method, joinPath(path, args), queryParameters, nil)
+		},
+	}
+}
+
+func joinPath(path string, args []string) string {
+	if len(args) == 0 {
+		return path
+	}
+	return path + "/" + strings.Join(args, "/")
+}
+
+func configureEndpointCommand(c *cobra.Command, method string, e spec.Endpoint) {
+	c.Short = e.Summary
+	c.Long = endpointHelp(e)
+
+	for _, p := range e.Parameters {
+		name := flagName(p.Name)
+		if c.InheritedFlags().Lookup(name) != nil || c.Flags().Lookup(name) != nil {
+			// still usable with --query-params
+			continue
+		}
+
+		usage := p.Description
+		values := p.Schema.EnumStrings()
+		if p.Schema.Items != nil {
+			values = p.Schema.Items.EnumStrings()
+		}
+		if len(values) != 0 {
+			usage += " Valid values: " + strings.Join(values, ", ") + "."
+		}
+		if p.Required {
+			usage += " Required."
+		}
+
+		switch p.Schema.Type {
+		case "array":
+			c.Flags().StringSlice(name, nil, usage+" Can be given multiple times, or comma separated.")
+		case "integer":
+			c.Flags().Int(name, 0, usage)
+		case "boolean":
+			c.Flags().Bool(name, false, usage)
+		default:
+			c.Flags().String(name, "", usage)
+		}
+
+		if len(values) != 0 {
+			c.RegisterFlagCompletionFunc(name, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+		}
+	}
+
+	if e.Body != nil {
+		c.Flags().Bool("scaffold", false, "Prints a template of the request body to pass to --body, and exits.")
+	}
+
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			// a path below this endpoint that isn't in the specification
+			return runRequest(cmd, method, joinPath(e.Path, args), queryParameters, nil)
+		}
+
+		if scaffold, _ := cmd.Flags().GetBool("scaffold"); scaffold {
+			b, err := e.ScaffoldBody()
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+			return nil
+		}
+
+		params := append([]string{}, queryParameters...)
+		for _, p := range e.Parameters {
+			f := cmd.Flags().Lookup(flagName(p.Name))
+			if f == nil || !f.Changed {
+				continue
+			}
+			if p.Schema.Type == "array" {
+				values, _ := cmd.Flags().GetStringSlice(f.Name)
+				for _, v := range values {
+					params = append(params, p.Name+"="+v)
+				}
+			} else {
+				params = append(params, p.Name+"="+f.Value.String())
+			}
+		}
+
+		return runRequest(cmd, method, e.Path, params, &e)
+	}
+}
+
+// validateEndpointRequest checks the request's query parameters and body against the specification
+func validateEndpointRequest(e spec.Endpoint, queryParameters []string, body []byte) error {
+	q := url.Values{}
+	for _, paramStr := range queryParameters {
+		var value string
+		param := strings.Split(paramStr, "=")
+		if len(param) == 2 {
+			value = param[1]
+		}
+		q.Add(param[0], value)
+	}
+	// batched values are added to each request later
+	if batchParam != "" && len(q[batchParam]) == 0 {
+		q.Set(batchParam, "")
+	}
+
+	if err := e.ValidateQuery(q); err != nil {
+		return err
+	}
+	return e.ValidateBody(body)
+}
+
+func endpointHelp(e spec.Endpoint) string {
+	var help strings.Builder
+	help.WriteString(e.Summary)
+	if e.Description != "" {
+		help.WriteString("\n\n" + e.Description)
+	}
+
+	scopes := "None"
+	if len(e.Scopes) != 0 {
+		scopes = strings.Join(e.Scopes, ", ")
+	}
+	help.WriteString("\n\nRequired scopes: " + scopes)
+
+	if e.Body != nil {
+		if b, err := e.ScaffoldBody(); err == nil {
+			help.WriteString("\n\nBody:\n" + string(b))
+		}
+	}
+
+	if e.OperationID != "" {
+		help.WriteString("\n\nDocs: https://dev.twitch.tv/docs/api/reference#" + e.OperationID)
+	}
+	return help.String()
+}
+
+func flagName(param string) string {
+	return strings.ReplaceAll(param, "_", "-")
+}
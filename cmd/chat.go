@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/twitchdev/twitch-cli/internal/chat"
+	"github.com/twitchdev/twitch-cli/internal/chat/mock_server"
+)
+
+var (
+	chatDebug      bool
+	chatServerIP   string
+	chatServerPort int
+	chatChannel    string
+	chatUser       string
+	chatMessage    string
+	chatTier       string
+	chatViewers    int
+)
+
+var chatCmd = &cobra.Command{
+	Use:   "chat [action]",
+	Short: `Executes actions regarding the mock IRC chat server. See "twitch chat --help" for usage info.`,
+	Long:  "Executes actions regarding the mock IRC chat server, which speaks the same protocol as irc.chat.twitch.tv.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  chatCmdRun,
+	Example: `  twitch chat start-server
+  twitch chat message --channel=dallas --user=ronni --message="Hello chat! Kappa"
+  twitch chat sub --channel=dallas --tier=2000
+  twitch chat raid --channel=dallas --viewers=150`,
+}
+
+func init() {
+	rootCmd.AddCommand(chatCmd)
+
+	// flags for start-server
+	chatCmd.Flags().StringVar(&chatServerIP, "ip", "127.0.0.1", "Defines the ip that the mock chat server will bind to.")
+	chatCmd.Flags().IntVarP(&chatServerPort, "port", "p", 6667, "Defines the port that the mock chat server will run on.")
+	chatCmd.Flags().BoolVar(&chatDebug, "debug", false, "Set on/off for debug messages for the chat server, including every IRC message sent and received.")
+
+	// flags for everything else
+	chatCmd.Flags().StringVarP(&chatChannel, "channel", "c", "", "Login of the channel to inject into. Used in all injection commands.")
+	chatCmd.Flags().StringVarP(&chatUser, "user", "u", "", "Login of the user the injected message, sub, or raid comes from. Defaults to a random user from the mock database.")
+	chatCmd.Flags().StringVarP(&chatMessage, "message", "m", "", `Text of the injected message. Used with "chat message" and "chat sub".`)
+	chatCmd.Flags().StringVar(&chatTier, "tier", "", `Tier of the injected sub; 1000, 2000 or 3000. Used with "chat sub".`)
+	chatCmd.Flags().IntVar(&chatViewers, "viewers", 0, `Number of viewers in the injected raid. Used with "chat raid".`)
+}
+
+func chatCmdRun(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		cmd.Help()
+		return fmt.Errorf("")
+	}
+
+	if args[0] == "start-server" || args[0] == "start" {
+		log.Printf("Attempting to start chat server on %v:%v", chatServerIP, chatServerPort)
+		log.Printf("`Ctrl + C` to exit mock chat server.")
+		return mock_server.StartChatServer(chatDebug, chatServerIP, chatServerPort)
+	}
+
+	// Forward all other commands via RPC
+	return chat.ForwardChatCommand(args[0], chat.ChatCommandParameters{
+		Channel: chatChannel,
+		User:    chatUser,
+		Message: chatMessage,
+		Tier:    chatTier,
+		Viewers: chatViewers,
+	})
+}
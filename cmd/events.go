@@ -20,6 +20,10 @@ func init() {
 	eventCmd.AddCommand(
 		events.TriggerCommand(),
 		events.RetriggerCommand(),
+		events.SimulateCommand(),
+		events.HistoryCommand(),
+		events.ListenCommand(),
+		events.ValidateCommand(),
 		events.VerifySubscriptionCommand(),
 		events.WebsocketCommand(),
 		events.StartWebsocketServerCommand(),
@@ -0,0 +1,238 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TylerBrock/colorjson"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/history"
+)
+
+var (
+	historyEvent       string
+	historyTransport   string
+	historyUser        string
+	historySince       string
+	historyUntil       string
+	historyListLimit   int
+	historyExportLimit int
+	historyFormat      string
+	historyOutput      string
+	historyOlderThan   string
+	historyForwardURL  string
+	historySecret      string
+)
+
+func HistoryCommand() (command *cobra.Command) {
+	command = &cobra.Command{
+		Use:   "history",
+		Short: "Lists, shows, exports, and prunes previously triggered events.",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists previously triggered events, newest first.",
+		Args:  cobra.NoArgs,
+		RunE:  historyListCmdRun,
+		Example: `  twitch event history list
+  twitch event history list --event cheer --since 2h
+  twitch event history list --transport websocket --user 1234 --limit 5`,
+	}
+	addHistoryFilterFlags(listCmd)
+	listCmd.Flags().IntVarP(&historyListLimit, "limit", "l", 50, "Maximum number of events listed. 0 lists every matching event.")
+
+	showCmd := &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Shows the full payload of a previously triggered event.",
+		Args:    cobra.ExactArgs(1),
+		RunE:    historyShowCmdRun,
+		Example: `twitch event history show 5d3aed06-d019-f790-7a21-9b7d53f1a1ba`,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports previously triggered events as JSON Lines or as an HTTP Archive (HAR) of the webhook requests.",
+		Args:  cobra.NoArgs,
+		RunE:  historyExportCmdRun,
+		Example: `  twitch event history export --format jsonl --output events.jsonl
+  twitch event history export --format har --event subscribe -F http://localhost:8080/eventsub -s testsecret`,
+	}
+	addHistoryFilterFlags(exportCmd)
+	exportCmd.Flags().IntVarP(&historyExportLimit, "limit", "l", 0, "Maximum number of events exported, starting with the newest. 0 exports every matching event.")
+	exportCmd.Flags().StringVar(&historyFormat, "format", history.ExportFormatJSONL, "Export format. Valid values: jsonl, har")
+	exportCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "File to write the export to. Defaults to stdout.")
+	exportCmd.Flags().StringVarP(&historyForwardURL, "forward-address", "F", "", "Request URL used in HAR exports. Defaults to the configured forward address.")
+	exportCmd.Flags().StringVarP(&historySecret, "secret", "s", "", "Webhook secret used to sign HAR requests. Defaults to the configured secret.")
+	exportCmd.Flags().BoolVarP(&noConfig, "no-config", "D", false, "Disables the use of the configuration, if it exists.")
+
+	pruneCmd := &cobra.Command{
+		Use:     "prune",
+		Short:   "Deletes previously triggered events older than the given time.",
+		Args:    cobra.NoArgs,
+		RunE:    historyPruneCmdRun,
+		Example: `twitch event history prune --older-than 7d`,
+	}
+	pruneCmd.Flags().StringVar(&historyOlderThan, "older-than", "", "Delete events triggered before this time. Either an RFC3339 timestamp, or a duration such as 30m, 12h, or 7d.")
+	pruneCmd.MarkFlagRequired("older-than")
+
+	command.AddCommand(listCmd, showCmd, exportCmd, pruneCmd)
+
+	return
+}
+
+func addHistoryFilterFlags(command *cobra.Command) {
+	command.Flags().StringVarP(&historyEvent, "event", "e", "", "Only include events of this type, by trigger or topic (e.g. cheer, or channel.cheer).")
+	command.Flags().StringVarP(&historyTransport, "transport", "T", "", "Only include events sent with this transport.")
+	command.Flags().StringVarP(&historyUser, "user", "u", "", "Only include events sent from or to this user ID.")
+	command.Flags().StringVar(&historySince, "since", "", "Only include events triggered at or after this time. Either an RFC3339 timestamp, or a duration such as 30m, 12h, or 7d.")
+	command.Flags().StringVar(&historyUntil, "until", "", "Only include events triggered at or before this time. Either an RFC3339 timestamp, or a duration such as 30m, 12h, or 7d.")
+}
+
+func historyFilter(limit int) (history.Filter, error) {
+	since, err := history.ParseTime(historySince)
+	if err != nil {
+		return history.Filter{}, err
+	}
+	until, err := history.ParseTime(historyUntil)
+	if err != nil {
+		return history.Filter{}, err
+	}
+
+	return history.Filter{
+		Event:     historyEvent,
+		Transport: historyTransport,
+		User:      historyUser,
+		Since:     since,
+		Until:     until,
+		Limit:     limit,
+	}, nil
+}
+
+func historyListCmdRun(cmd *cobra.Command, args []string) error {
+	f, err := historyFilter(historyListLimit)
+	if err != nil {
+		return err
+	}
+
+	events, err := history.GetEvents(f)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No events found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIMESTAMP\tEVENT\tTRANSPORT\tFROM\tTO")
+	for _, e := range events {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", e.ID, e.Timestamp, e.Event, e.Transport, e.FromUser, e.ToUser)
+	}
+	return w.Flush()
+}
+
+func historyShowCmdRun(cmd *cobra.Command, args []string) error {
+	e, err := history.GetEvent(args[0])
+	if err != nil {
+		return err
+	}
+
+	var obj map[string]interface{}
+	err = json.Unmarshal([]byte(e.JSON), &obj)
+	if err != nil {
+		return fmt.Errorf("Unable to parse stored event JSON: %v", err)
+	}
+
+	fmt.Printf("Event: %v\nTransport: %v\nTimestamp: %v\n\n", e.Event, e.Transport, e.Timestamp)
+
+	if runtime.GOOS == "windows" {
+		s, _ := json.MarshalIndent(obj, "", "  ")
+		fmt.Println(string(s))
+		return nil
+	}
+
+	f := colorjson.NewFormatter()
+	f.Indent = 2
+	f.KeyColor = color.New(color.FgBlue).Add(color.Bold)
+	s, err := f.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(s))
+	return nil
+}
+
+func historyExportCmdRun(cmd *cobra.Command, args []string) error {
+	f, err := historyFilter(historyExportLimit)
+	if err != nil {
+		return err
+	}
+
+	events, err := history.GetEvents(f)
+	if err != nil {
+		return err
+	}
+
+	defaults := configure_event.GetEventConfiguration(noConfig)
+	if historyForwardURL == "" {
+		historyForwardURL = defaults.ForwardAddress
+	}
+	if historySecret == "" {
+		historySecret = defaults.Secret
+	}
+
+	var w io.Writer = os.Stdout
+	if historyOutput != "" {
+		file, err := os.Create(historyOutput)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	err = history.Export(w, events, history.ExportParameters{
+		Format:         historyFormat,
+		ForwardAddress: historyForwardURL,
+		Secret:         historySecret,
+	})
+	if err != nil {
+		return err
+	}
+
+	if historyOutput != "" {
+		fmt.Printf("Exported %v events to %v\n", len(events), historyOutput)
+	}
+	return nil
+}
+
+func historyPruneCmdRun(cmd *cobra.Command, args []string) error {
+	olderThan, err := history.ParseTime(historyOlderThan)
+	if err != nil {
+		return err
+	}
+	if olderThan.IsZero() {
+		return fmt.Errorf("--older-than must be provided")
+	}
+
+	deleted, unparsed, err := history.Prune(olderThan)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted %v events triggered before %v\n", deleted, olderThan.Format(time.RFC3339))
+	if len(unparsed) > 0 {
+		fmt.Printf("Kept %v events with timestamps that couldn't be parsed: %v\n", len(unparsed), strings.Join(unparsed, ", "))
+	}
+	return nil
+}
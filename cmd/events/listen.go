@@ -0,0 +1,57 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/listen"
+)
+
+var (
+	listenIP            string
+	listenPort          int
+	listenForward       string
+	listenMaxMessageAge time.Duration
+)
+
+func ListenCommand() (command *cobra.Command) {
+	command = &cobra.Command{
+		Use:   "listen",
+		Short: "Starts a local webhook receiver that verifies and prints EventSub messages.",
+		Long: `Starts a local webhook receiver that handles EventSub messages the way a production callback should.
+Verification challenges are answered, signatures are checked against the secret, and messages with stale timestamps or already seen message IDs are rejected.`,
+		Args: cobra.NoArgs,
+		RunE: listenCmdRun,
+		Example: `  twitch event listen --port 8080 --secret testsecret
+  twitch event listen -s testsecret --forward-address http://localhost:3000/eventsub`,
+	}
+
+	command.Flags().StringVar(&listenIP, "ip", "127.0.0.1", "Defines the ip that the webhook receiver will bind to.")
+	command.Flags().IntVarP(&listenPort, "port", "p", 8080, "Defines the port that the webhook receiver will run on.")
+	command.Flags().StringVarP(&secret, "secret", "s", "", "Webhook secret used to verify message signatures. Defaults to the configured secret.")
+	command.Flags().StringVarP(&listenForward, "forward-address", "F", "", "Downstream URL verified notifications are forwarded to.")
+	command.Flags().DurationVar(&listenMaxMessageAge, "max-age", listen.DefaultMaxMessageAge, "Messages with timestamps older than this are rejected. 0 disables the check.")
+	command.Flags().BoolVarP(&noConfig, "no-config", "D", false, "Disables the use of the configuration, if it exists.")
+
+	return
+}
+
+func listenCmdRun(cmd *cobra.Command, args []string) error {
+	if secret != "" {
+		if len(secret) < 10 || len(secret) > 100 {
+			return fmt.Errorf("Invalid secret provided. Secrets must be between 10-100 characters")
+		}
+	} else {
+		secret = configure_event.GetEventConfiguration(noConfig).Secret
+	}
+
+	return listen.StartListener(listen.ListenParameters{
+		IP:             listenIP,
+		Port:           listenPort,
+		Secret:         secret,
+		ForwardAddress: listenForward,
+		MaxMessageAge:  listenMaxMessageAge,
+	})
+}
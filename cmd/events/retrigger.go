@@ -6,23 +6,32 @@ import (
 
 	"github.com/spf13/cobra"
 	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/history"
 	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
+var retriggerLast int
+
 func RetriggerCommand() (command *cobra.Command) {
 	command = &cobra.Command{
-		Use:     "retrigger",
-		Short:   "Refires events based on the event ID. Can be forwarded to the local webserver for event testing.",
-		RunE:    retriggerCmdRun,
-		Example: `twitch event retrigger subscribe`,
+		Use:   "retrigger",
+		Short: "Refires events based on the event ID, or the most recent events matching a filter. Can be forwarded to the local webserver for event testing.",
+		RunE:  retriggerCmdRun,
+		Example: `  twitch event retrigger -i 5d3aed06-d019-f790-7a21-9b7d53f1a1ba
+  twitch event retrigger --event cheer --last 5`,
 	}
 
 	command.Flags().StringVarP(&forwardAddress, "forward-address", "F", "", "Forward address for mock event (webhook only).")
 	command.Flags().StringVarP(&eventMessageID, "id", "i", "", "ID of the event to be refired.")
 	command.Flags().StringVarP(&secret, "secret", "s", "", "Webhook secret. If defined, signs all forwarded events with the SHA256 HMAC and must be 10-100 characters in length.")
 	command.Flags().BoolVarP(&noConfig, "no-config", "D", false, "Disables the use of the configuration, if it exists.")
-	command.MarkFlagRequired("id")
+	command.Flags().IntVar(&retries, "retries", 0, "Refires the event as this many retries of its original delivery, with its original timestamp and the Twitch-Eventsub-Message-Retry header counting up from 1.")
+	command.Flags().DurationVar(&timestampSkew, "timestamp-skew", 0, "Moves the refired event's timestamp away from now, such as -11m to send an event older than the 10 minute freshness window. Not used with --retries.")
+
+	// flags for refiring by filter, instead of by ID
+	addHistoryFilterFlags(command)
+	command.Flags().IntVar(&retriggerLast, "last", 1, "Number of the most recent events matching the filter to refire. Used when --id isn't set.")
 
 	return
 }
@@ -32,6 +41,10 @@ func retriggerCmdRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(websubDeprecationNotice)
 	}
 
+	if retries < 0 {
+		return fmt.Errorf("--retries can't be negative")
+	}
+
 	defaults := configure_event.GetEventConfiguration(noConfig)
 
 	if secret != "" {
@@ -49,16 +62,58 @@ func retriggerCmdRun(cmd *cobra.Command, args []string) error {
 		forwardAddress = defaults.ForwardAddress
 	}
 
-	//color.New().Add(color.FgGreen).Println(fmt.Sprintf(`Refire %v`, eventMessageID));
-	res, err := trigger.RefireEvent(eventMessageID, trigger.TriggerParameters{
-		ForwardAddress: forwardAddress,
-		Secret:         secret,
-		Timestamp:      util.GetTimestamp().Format(time.RFC3339Nano),
-	})
-	if err != nil {
-		return fmt.Errorf("Error refiring event: %s", err)
+	ids := []string{eventMessageID}
+	if eventMessageID == "" {
+		var err error
+		ids, err = retriggerIDsByFilter()
+		if err != nil {
+			return err
+		}
 	}
 
-	fmt.Println(res)
+	for _, id := range ids {
+		//color.New().Add(color.FgGreen).Println(fmt.Sprintf(`Refire %v`, id));
+		res, err := trigger.RefireEvent(id, trigger.TriggerParameters{
+			ForwardAddress: forwardAddress,
+			Secret:         secret,
+			Timestamp:      util.GetTimestamp().Add(timestampSkew).Format(time.RFC3339Nano),
+			Retries:        retries,
+		})
+		if err != nil {
+			return fmt.Errorf("Error refiring event: %s", err)
+		}
+
+		fmt.Println(res)
+	}
 	return nil
 }
+
+// retriggerIDsByFilter returns the IDs of the most recent events matching the history filter flags, oldest first so
+// they're refired in their original order.
+func retriggerIDsByFilter() ([]string, error) {
+	if historyEvent == "" && historyTransport == "" && historyUser == "" && historySince == "" && historyUntil == "" {
+		return nil, fmt.Errorf("Either --id or at least one of --event, --transport, --user, --since, or --until must be provided")
+	}
+	if retriggerLast < 1 {
+		return nil, fmt.Errorf("--last must be at least 1")
+	}
+
+	f, err := historyFilter(retriggerLast)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := history.GetEvents(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("No events in the event history match the given filter")
+	}
+
+	ids := []string{}
+	for i := len(events) - 1; i >= 0; i-- {
+		ids = append(ids, events[i].ID)
+	}
+	return ids, nil
+}
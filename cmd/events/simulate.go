@@ -0,0 +1,99 @@
+package events
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/twitchdev/twitch-cli/internal/events"
+	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/simulate"
+)
+
+var (
+	simulateSteps    int
+	simulateDuration time.Duration
+	simulateInterval time.Duration
+	simulatePersist  bool
+)
+
+func SimulateCommand() (command *cobra.Command) {
+	command = &cobra.Command{
+		Use:   "simulate <kind>",
+		Short: "Sends every event in the lifecycle of a poll, prediction, hype train, goal or charity campaign, all describing the same object.",
+		Long: fmt.Sprintf(`Sends every event in the lifecycle of a poll, prediction, hype train, goal or charity campaign, from begin through each progress event to the end.
+Every event shares the same ID, votes/progress/levels only ever increase, timestamps follow the object's timeline, and the last event has the final outcome.
+Supported: %s`, strings.Join(simulate.Kinds, ", ")),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: simulate.Kinds,
+		RunE:      simulateCmdRun,
+		Example: `  twitch event simulate poll -F http://localhost:8080 -s testsecret
+  twitch event simulate prediction -t 12345 --persist
+  twitch event simulate hype-train --steps 10 --interval 2s -T websocket`,
+	}
+
+	command.Flags().StringVarP(&forwardAddress, "forward-address", "F", "", "Forward address for mock events (webhook only).")
+	command.Flags().StringVarP(&transport, "transport", "T", "webhook", fmt.Sprintf("Preferred transport method for events. Defaults to /EventSub.\nSupported values: %s", events.ValidTransports()))
+	command.Flags().StringVarP(&secret, "secret", "s", "", "Webhook secret. If defined, signs all forwarded events with the SHA256 HMAC and must be 10-100 characters in length.")
+	command.Flags().BoolVarP(&noConfig, "no-config", "D", false, "Disables the use of the configuration, if it exists.")
+	command.Flags().StringVar(&websocketClient, "session", "", "Defines a specific websocket client/session to forward events to. Used only with \"websocket\" transport.")
+	command.Flags().StringVarP(&toUser, "to-user", "t", "", "User ID of the broadcaster. Required with --persist, where it must be a mock API user.")
+	command.Flags().StringVarP(&toUserName, "to-user-name", "", "", "User Name of the broadcaster. Not used with --persist, where the mock API user's name is used.")
+	command.Flags().StringVarP(&description, "description", "d", "", "Title of the poll or prediction, or description of the goal.")
+	command.Flags().StringVar(&timestamp, "timestamp", "", "Sets when the simulated object starts. Must be in RFC3339Nano format. Defaults to now.")
+	command.Flags().IntVar(&simulateSteps, "steps", 3, "Number of progress events between the first and last event.")
+	command.Flags().DurationVar(&simulateDuration, "duration", 0, "How long the simulated object runs for, such as the poll duration or prediction window. Defaults to 5m for polls and hype trains, 2m for predictions, 24h for goals and 2h for charity campaigns.")
+	command.Flags().DurationVar(&simulateInterval, "interval", 0, "Time to wait between sending each event. By default, events are sent immediately, with timestamps following the simulated timeline.")
+	command.Flags().BoolVar(&simulatePersist, "persist", false, "Stores the poll or prediction in the mock API database, so its endpoints return the same object as the events.")
+
+	return
+}
+
+func simulateCmdRun(cmd *cobra.Command, args []string) error {
+	if transport == "websub" {
+		return fmt.Errorf(websubDeprecationNotice)
+	}
+
+	defaults := configure_event.GetEventConfiguration(noConfig)
+
+	if secret != "" {
+		if len(secret) < 10 || len(secret) > 100 {
+			return fmt.Errorf("Invalid secret provided. Secrets must be between 10-100 characters")
+		}
+	} else {
+		secret = defaults.Secret
+	}
+
+	// Validate that the forward address is actually a URL
+	if len(forwardAddress) > 0 {
+		_, err := url.ParseRequestURI(forwardAddress)
+		if err != nil {
+			return err
+		}
+	} else {
+		forwardAddress = defaults.ForwardAddress
+	}
+
+	payloads, err := simulate.Run(simulate.SimulateParameters{
+		Kind:            args[0],
+		Transport:       transport,
+		ForwardAddress:  forwardAddress,
+		Secret:          secret,
+		WebSocketClient: websocketClient,
+		ToUser:          toUser,
+		ToUserName:      toUserName,
+		Title:           description,
+		Steps:           simulateSteps,
+		Duration:        simulateDuration,
+		Interval:        simulateInterval,
+		Timestamp:       timestamp,
+		Persist:         simulatePersist,
+	})
+	for _, p := range payloads {
+		fmt.Println(p)
+	}
+
+	return err
+}
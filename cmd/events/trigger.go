@@ -3,12 +3,20 @@ package events
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/twitchdev/twitch-cli/internal/events"
 	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/load"
+	"github.com/twitchdev/twitch-cli/internal/events/payload"
 	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/events/types"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v1"
+	"github.com/twitchdev/twitch-cli/internal/events/types/bits_use"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/events/types/moderate_v2"
+	"github.com/twitchdev/twitch-cli/internal/models"
 )
 
 func TriggerCommand() (command *cobra.Command) {
@@ -41,7 +49,7 @@ func TriggerCommand() (command *cobra.Command) {
 	command.Flags().StringVarP(&giftUser, "gift-user", "g", "", "Used only for \"gift\" events. Denotes the User ID of the gifting user.")
 	command.Flags().BoolVarP(&isAnonymous, "anonymous", "a", false, "Denotes if the event is anonymous. Only applies to Gift and Sub events.")
 	command.Flags().IntVarP(&count, "count", "c", 1, "Number of times to run an event. This can be used to simulate rapid events, such as multiple sub gift, or large number of cheers.")
-	command.Flags().StringVarP(&eventStatus, "event-status", "S", "", "Status of the Event object (.event.status in JSON); currently applies to channel points redemptions.")
+	command.Flags().StringVarP(&eventStatus, "event-status", "S", "", "Status of the Event object (.event.status in JSON); currently applies to channel points redemptions, AutoMod message updates, and suspicious user events.")
 	command.Flags().StringVarP(&subscriptionStatus, "subscription-status", "r", "enabled", "Status of the Subscription object (.subscription.status in JSON). Defaults to \"enabled\".")
 	command.Flags().StringVarP(&itemID, "item-id", "i", "", "Manually set the ID of the event payload item (for example the reward ID in redemption events). For stream events, this is the game ID.")
 	command.Flags().StringVarP(&itemName, "item-name", "n", "", "Manually set the name of the event payload item (for example the reward ID in redemption events). For stream events, this is the game title.")
@@ -59,12 +67,28 @@ func TriggerCommand() (command *cobra.Command) {
 	command.Flags().StringVar(&websocketClient, "session", "", "Defines a specific websocket client/session to forward an event to. Used only with \"websocket\" transport.")
 	command.Flags().StringVar(&banStart, "ban-start", "", "Sets the timestamp a ban started at.")
 	command.Flags().StringVar(&banEnd, "ban-end", "", "Sets the timestamp a ban is intended to end at. If not set, the ban event will appear as permanent. This flag can take a timestamp or relative time (600, 600s, 10d4h12m55s)")
+	command.Flags().StringVar(&messageText, "message", "", "Sets the text of the chat message for chat events.")
+	command.Flags().StringVar(&noticeType, "notice-type", "", fmt.Sprintf("Only used for \"chat-notification\" events. Sets the type of notice. Defaults to \"announcement\".\nSupported values: %s", chat.NoticeTypes))
+	command.Flags().StringVar(&moderateAction, "moderate-action", "", fmt.Sprintf("Only used for \"moderate\" and \"automod-terms-update\" events. Sets the moderation action taken. Defaults to \"ban\", or \"add_blocked_term\" for term updates.\nSupported values: %s", moderate_v2.Actions))
+	command.Flags().StringVar(&rewardType, "reward-type", "", fmt.Sprintf("Only used for \"add-automatic-redemption\" and \"bits-use\" events. Sets the automatic reward redeemed, or how Bits were used. Defaults to \"send_highlighted_message\" or \"cheer\".\nSupported values for automatic rewards: %s\nSupported values for Bits: %s", automatic_reward_v1.RewardTypes, bits_use.Types))
+	command.Flags().StringSliceVar(&participantIDs, "participants", nil, "Only used for \"shared-chat-begin\" and \"shared-chat-update\" events. Sets the IDs of other broadcasters in the shared chat session, besides the host (from user) and the broadcaster (to user).")
+	command.Flags().StringArrayVar(&setOverrides, "set", nil, "Sets a field of the generated payload, such as --set event.reward.title=Hydrate. Fields that are strings stay strings; otherwise the value is used as JSON if valid, or as a string. Use path:=value to always use JSON. Can be used multiple times.")
+	command.Flags().StringArrayVar(&mergeOverrides, "merge", nil, "Applies the JSON merge patch (RFC 7386) in the given file to the generated payload. Can be used multiple times.")
+	command.Flags().StringArrayVar(&jqOverrides, "jq", nil, "Transforms the generated payload with a jq-style filter, such as '.event.bits += 100 | del(.event.message)'. Uses the same subset of jq as the --filter of api commands. Can be used multiple times.")
+	command.Flags().StringVar(&rate, "rate", "", "Runs a load test, sending events at this rate, such as 500/s or 1200/m. Without it, events are sent as fast as --concurrency allows.")
+	command.Flags().DurationVar(&duration, "duration", 0, "Runs a load test for this long, such as 30s or 5m. Defaults to 10s for load tests without --count.")
+	command.Flags().IntVar(&concurrency, "concurrency", 1, "Runs a load test with this many events in flight at once.")
+	command.Flags().StringSliceVar(&eventMix, "mix", nil, "Runs a load test with a weighted mix of events instead of a single event, such as follow=80,subscribe=15,cheer=5.")
+	command.Flags().IntVar(&retries, "retries", 0, "Webhook only. Delivers the event again this many times with the same message ID and timestamp, and the Twitch-Eventsub-Message-Retry header counting up, as Twitch does when a delivery fails.")
+	command.Flags().DurationVar(&timestampSkew, "timestamp-skew", 0, "Moves the event's timestamp away from now, such as -11m to send events older than the 10 minute freshness window. Can't be used with --timestamp.")
+	command.Flags().BoolVar(&outOfOrder, "out-of-order", false, "Used with --count. Generates every event first, then delivers them all at once in a random order.")
+	command.Flags().StringVar(&chatColor, "color", "", "Sets the chatter's name color for chat messages, or the color of announcements for \"chat-notification\" events.")
 
 	return
 }
 
 func triggerCmdRun(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
+	if len(args) == 0 && len(eventMix) == 0 {
 		cmd.Help()
 		return fmt.Errorf("")
 	}
@@ -93,37 +117,78 @@ func triggerCmdRun(cmd *cobra.Command, args []string) error {
 		forwardAddress = defaults.ForwardAddress
 	}
 
+	if timestamp != "" && timestampSkew != 0 {
+		return fmt.Errorf("Use either --timestamp or --timestamp-skew, not both")
+	}
+	if retries < 0 {
+		return fmt.Errorf("--retries can't be negative")
+	}
+	if retries > 0 && transport != models.TransportWebhook {
+		return fmt.Errorf("--retries is only supported with the webhook transport")
+	}
+
+	// Parsed once up front, so a mistake in an override is reported before any events are sent
+	overrides, err := payload.NewOverrides(setOverrides, mergeOverrides, jqOverrides)
+	if err != nil {
+		return err
+	}
+
+	params := trigger.TriggerParameters{
+		SubscriptionID:      subscriptionID,
+		EventMessageID:      eventMessageID,
+		Transport:           transport,
+		ForwardAddress:      forwardAddress,
+		FromUser:            fromUser,
+		FromUserName:        fromUserName,
+		ToUser:              toUser,
+		ToUserName:          toUserName,
+		GiftUser:            giftUser,
+		Secret:              secret,
+		IsAnonymous:         isAnonymous,
+		EventStatus:         eventStatus,
+		ItemID:              itemID,
+		Cost:                cost,
+		Description:         description,
+		ItemName:            itemName,
+		GameID:              gameID,
+		Tier:                tier,
+		SubscriptionStatus:  subscriptionStatus,
+		Timestamp:           timestamp,
+		CharityCurrentValue: charityCurrentValue,
+		CharityTargetValue:  charityTargetValue,
+		ClientID:            clientId,
+		Version:             version,
+		WebSocketClient:     websocketClient,
+		BanStartTimestamp:   banStart,
+		BanEndTimestamp:     banEnd,
+		MessageText:         messageText,
+		NoticeType:          noticeType,
+		Color:               chatColor,
+		ModerateAction:      moderateAction,
+		RewardType:          rewardType,
+		ParticipantIDs:      participantIDs,
+		Overrides:           overrides,
+		Retries:             retries,
+		TimestampSkew:       timestampSkew,
+	}
+
+	if isLoadTest(cmd) {
+		return runLoadTest(cmd, args, params)
+	}
+
+	params.Event = args[0]
+	if outOfOrder {
+		payloads, err := trigger.FireOutOfOrder(params, count)
+		for _, res := range payloads {
+			if res != "" {
+				fmt.Println(res)
+			}
+		}
+		return err
+	}
+
 	for i := 0; i < count; i++ {
-		res, err := trigger.Fire(trigger.TriggerParameters{
-			Event:               args[0],
-			SubscriptionID:      subscriptionID,
-			EventMessageID:      eventMessageID,
-			Transport:           transport,
-			ForwardAddress:      forwardAddress,
-			FromUser:            fromUser,
-			FromUserName:        fromUserName,
-			ToUser:              toUser,
-			ToUserName:          toUserName,
-			GiftUser:            giftUser,
-			Secret:              secret,
-			IsAnonymous:         isAnonymous,
-			EventStatus:         eventStatus,
-			ItemID:              itemID,
-			Cost:                cost,
-			Description:         description,
-			ItemName:            itemName,
-			GameID:              gameID,
-			Tier:                tier,
-			SubscriptionStatus:  subscriptionStatus,
-			Timestamp:           timestamp,
-			CharityCurrentValue: charityCurrentValue,
-			CharityTargetValue:  charityTargetValue,
-			ClientID:            clientId,
-			Version:             version,
-			WebSocketClient:     websocketClient,
-			BanStartTimestamp:   banStart,
-			BanEndTimestamp:     banEnd,
-		})
+		res, err := trigger.Fire(params)
 
 		if err != nil {
 			return err
@@ -134,3 +199,59 @@ func triggerCmdRun(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// isLoadTest returns whether any of the load test flags were used.
+func isLoadTest(cmd *cobra.Command) bool {
+	for _, flag := range []string{"rate", "duration", "concurrency", "mix"} {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+func runLoadTest(cmd *cobra.Command, args []string, params trigger.TriggerParameters) error {
+	p := load.LoadParameters{
+		Duration:    duration,
+		Concurrency: concurrency,
+		Trigger:     params,
+	}
+
+	if len(eventMix) != 0 && len(args) != 0 {
+		return fmt.Errorf("Use either an event or --mix for load tests, not both")
+	}
+	if retries > 0 || outOfOrder {
+		return fmt.Errorf("--retries and --out-of-order can't be used in load tests")
+	}
+	if len(eventMix) != 0 {
+		mix, err := load.ParseMix(eventMix)
+		if err != nil {
+			return err
+		}
+		p.Mix = mix
+	} else {
+		p.Mix = []load.WeightedEvent{{Event: args[0], Weight: 1}}
+	}
+
+	if rate != "" {
+		r, err := load.ParseRate(rate)
+		if err != nil {
+			return err
+		}
+		p.Rate = r
+	}
+
+	if cmd.Flags().Changed("count") {
+		p.Count = count
+	} else if p.Duration == 0 {
+		p.Duration = 10 * time.Second
+	}
+
+	report, err := load.Run(p)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(report)
+	return nil
+}
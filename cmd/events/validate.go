@@ -0,0 +1,54 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/twitchdev/twitch-cli/internal/events/schema"
+)
+
+func ValidateCommand() (command *cobra.Command) {
+	command = &cobra.Command{
+		Use:   "validate <file|->",
+		Short: "Validates an EventSub notification payload against the schema for its subscription type and version.",
+		Long: `Validates an EventSub notification payload against the schema for its subscription type and version.
+Accepts webhook notification bodies and WebSocket notification messages, read from a file or from stdin with "-".`,
+		Args: cobra.ExactArgs(1),
+		RunE: validateCmdRun,
+		Example: `  twitch event validate payload.json
+  twitch event trigger cheer | twitch event validate -`,
+	}
+
+	return
+}
+
+func validateCmdRun(cmd *cobra.Command, args []string) error {
+	var payload []byte
+	var err error
+	if args[0] == "-" {
+		payload, err = io.ReadAll(os.Stdin)
+	} else {
+		payload, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := schema.Validate(payload)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Errors) == 0 {
+		color.New().Add(color.FgGreen).Println(fmt.Sprintf(`✔ Payload matches the %v v%v schema`, result.SubscriptionType, result.Version))
+		return nil
+	}
+
+	for _, e := range result.Errors {
+		color.New().Add(color.FgRed).Println(fmt.Sprintf(`✗ %v`, e.Error()))
+	}
+	return fmt.Errorf("Payload doesn't match the %v v%v schema; found %v problem(s)", result.SubscriptionType, result.Version, len(result.Errors))
+}
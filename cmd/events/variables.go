@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 const websubDeprecationNotice = "Halt! It appears you are trying to use WebSub, which has been deprecated. For more information, see: https://discuss.dev.twitch.tv/t/deprecation-of-websub-based-webhooks/32152"
 
 var (
@@ -32,4 +34,20 @@ var (
 	websocketClient     string
 	banStart            string
 	banEnd              string
+	messageText         string
+	noticeType          string
+	chatColor           string
+	moderateAction      string
+	rewardType          string
+	participantIDs      []string
+	setOverrides        []string
+	mergeOverrides      []string
+	jqOverrides         []string
+	rate                string
+	duration            time.Duration
+	concurrency         int
+	eventMix            []string
+	retries             int
+	timestampSkew       time.Duration
+	outOfOrder          bool
 )
@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/extension"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var (
+	extensionSecret     string
+	extensionRole       string
+	extensionChannel    string
+	extensionUser       string
+	extensionExpiration time.Duration
+	extensionListen     []string
+	extensionSend       []string
+	extensionVerbose    bool
+)
+
+var extensionCmd = &cobra.Command{
+	Use:   "extension",
+	Short: "Tools for testing Extension backends, such as minting Extension JWTs.",
+}
+
+var extensionJWTCmd = &cobra.Command{
+	Use:   "jwt",
+	Short: "Creates an Extension JWT signed with the Extension secret, like the ones Twitch gives frontends or backends create to call the API.",
+	RunE:  extensionJWTCmdRun,
+	Example: `  twitch extension jwt --role broadcaster --channel 1234
+  twitch extension jwt --role viewer --channel 1234 --user 5678
+  twitch extension jwt --role external --channel 1234 --user 1234 --secret <base64 secret>`,
+}
+
+var extensionConfigureCmd = &cobra.Command{
+	Use:   "configure",
+	Short: "Saves the Extension secret used to sign Extension JWTs.",
+	RunE:  extensionConfigureCmdRun,
+}
+
+func init() {
+	rootCmd.AddCommand(extensionCmd)
+	extensionCmd.AddCommand(extensionJWTCmd, extensionConfigureCmd)
+
+	extensionJWTCmd.Flags().StringVar(&extensionRole, "role", "", fmt.Sprintf("Role of the JWT. Supported values: %v", strings.Join(extension.Roles, ", ")))
+	extensionJWTCmd.Flags().StringVar(&extensionChannel, "channel", "", "ID of the channel the Extension is used on. Required for every role except external, which defaults to \"all\".")
+	extensionJWTCmd.Flags().StringVar(&extensionUser, "user", "", "ID of the user. Defaults to the channel for broadcasters; viewers without one are anonymous.")
+	extensionJWTCmd.Flags().DurationVar(&extensionExpiration, "expiration", time.Hour, "How long the JWT is valid for.")
+	extensionJWTCmd.Flags().StringSliceVar(&extensionListen, "listen", nil, "Overrides the PubSub targets the JWT can listen to, such as broadcast,global.")
+	extensionJWTCmd.Flags().StringSliceVar(&extensionSend, "send", nil, "Overrides the PubSub targets the JWT can send to, such as broadcast. \"*\" allows every target.")
+	extensionJWTCmd.Flags().StringVarP(&extensionSecret, "secret", "s", "", "Base64 encoded Extension secret. Defaults to the one saved with \"twitch extension configure\".")
+	extensionJWTCmd.Flags().BoolVar(&extensionVerbose, "verbose", false, "Prints the claims of the JWT along with the JWT.")
+	extensionJWTCmd.MarkFlagRequired("role")
+
+	extensionConfigureCmd.Flags().StringVarP(&extensionSecret, "secret", "s", "", "Base64 encoded Extension secret, from the Extension's settings or the mock API.")
+	extensionConfigureCmd.MarkFlagRequired("secret")
+}
+
+func extensionJWTCmdRun(cmd *cobra.Command, args []string) error {
+	if extensionSecret == "" {
+		extensionSecret = viper.GetString("extensionSecret")
+		if extensionSecret == "" {
+			return fmt.Errorf("No Extension secret was given. Use --secret, or save one with \"twitch extension configure\"")
+		}
+	}
+
+	claims, err := extension.NewClaims(extensionRole, extensionChannel, extensionUser, extensionExpiration)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("listen") || cmd.Flags().Changed("send") {
+		if claims.PubSubPerms == nil {
+			claims.PubSubPerms = &extension.PubSubPerms{}
+		}
+		if cmd.Flags().Changed("listen") {
+			claims.PubSubPerms.Listen = extensionListen
+		}
+		if cmd.Flags().Changed("send") {
+			claims.PubSubPerms.Send = extensionSend
+		}
+	}
+
+	token, err := extension.Sign(claims, extensionSecret)
+	if err != nil {
+		return err
+	}
+
+	if extensionVerbose {
+		b, _ := json.MarshalIndent(claims, "", "  ")
+		fmt.Println(string(b))
+	}
+	fmt.Println(token)
+	return nil
+}
+
+func extensionConfigureCmdRun(cmd *cobra.Command, args []string) error {
+	// Signing a throwaway JWT checks the secret is valid before it's saved
+	_, err := extension.Sign(extension.Claims{Role: extension.RoleExternal}, extensionSecret)
+	if err != nil {
+		return err
+	}
+
+	viper.Set("extensionSecret", extensionSecret)
+
+	configPath, err := util.GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if err := viper.WriteConfigAs(configPath); err != nil {
+		return fmt.Errorf("Failed to write configuration: %v", err.Error())
+	}
+
+	fmt.Println("Updated configuration.")
+	return nil
+}
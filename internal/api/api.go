@@ -3,24 +3,29 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"os"
 	"runtime"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/twitchdev/twitch-cli/internal/api/spec"
 	"github.com/twitchdev/twitch-cli/internal/login"
+	"github.com/twitchdev/twitch-cli/internal/jq"
 	"github.com/twitchdev/twitch-cli/internal/models"
 	"github.com/twitchdev/twitch-cli/internal/util"
 
 	"github.com/TylerBrock/colorjson"
 	"github.com/fatih/color"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var baseURL = "https://api.twitch.tv/helix"
@@ -31,7 +36,7 @@ type clientInformation struct {
 }
 
 // NewRequest is used to request data from the Twitch API using a HTTP GET request- this function is a wrapper for the apiRequest function that handles the network call
-func NewRequest(method string, path string, queryParameters []string, body []byte, prettyPrint bool, autopaginate *int, verbose bool) error {
+func NewRequest(method string, path string, queryParameters []string, body []byte, prettyPrint bool, autopaginate *int, verbose bool, outputOptions OutputOptions) error {
 	var data models.APIResponse
 	var err error
 	var cursor string
@@ -50,10 +55,22 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		return fmt.Errorf("Error fetching client information: %v", err.Error())
 	}
 
+	out, err := newOutput(outputOptions)
+	if err != nil {
+		return err
+	}
+	printedVerbose := false
+
 	if autopaginate != nil && *autopaginate < 0 {
 		return fmt.Errorf("Invalid pagination value provided. Must be greater than or equal to 0.")
 	}
 
+	// streamed pages are filtered as they arrive, so filters that need every item at once, such as length, would
+	// quietly give results for each page instead
+	if autopaginate != nil && out.streams() && out.Filter != "" && !jq.Streams(out.Filter) {
+		return fmt.Errorf("With jsonl output and --autopaginate, --filter is applied to each page as it arrives, so it must work on each item on its own, such as '.[] | select(.viewer_count > 100)'. Filters such as length, .[0] and keys need the full response; use json output instead.")
+	}
+
 	if viper.GetString("BASE_URL") != "" {
 		baseURL = viper.GetString("BASE_URL")
 	}
@@ -68,14 +85,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		}
 
 		q := u.Query()
-		for _, paramStr := range queryParameters {
-			var value string
-			param := strings.Split(paramStr, "=")
-			if len(param) == 2 {
-				value = param[1]
-			}
-			q.Add(param[0], value)
-		}
+		addQueryParameters(q, queryParameters)
 
 		if cursor != "" {
 			q.Set("after", cursor)
@@ -155,10 +165,25 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 			break
 		}
 
+		// Streamed pages are written as they arrive, so long pulls aren't held in memory
+		if out.streams() {
+			if verbose && !printedVerbose {
+				printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
+				printedVerbose = true
+			}
+			page, err := out.transform(apiResponse.Data)
+			if err != nil {
+				return err
+			}
+			if err := out.writeRows(os.Stdout, page); err != nil {
+				return err
+			}
+		}
+
 		if strings.Contains(path, "schedule") || data.Data == nil {
 			data.Data = apiResponse.Data
 			break // autopagination unsupported
-		} else if runCounter > 1 {
+		} else if runCounter > 1 && !out.streams() {
 			data.Data = append(data.Data.([]interface{}), apiResponse.Data.([]interface{})...)
 		}
 
@@ -198,6 +223,48 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		data.Data = make(map[string]any, 0)
 	}
 
+	if data.Error == "" && out.streams() {
+		if verbose && !printedVerbose {
+			printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
+		}
+		return nil
+	}
+
+	return printResponse(data, out, prettyPrint, isExtensionsLiveEndpoint, func() {
+		if verbose {
+			printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
+		}
+	})
+}
+
+// addQueryParameters adds parameters in the key=value format to q
+func addQueryParameters(q url.Values, queryParameters []string) {
+	for _, paramStr := range queryParameters {
+		var value string
+		param := strings.Split(paramStr, "=")
+		if len(param) == 2 {
+			value = param[1]
+		}
+		q.Add(param[0], value)
+	}
+}
+
+// printResponse prints data in the given output format, calling printVerbose just before the response is printed.
+// Responses with an error are returned as one, so the command exits with a non-zero exit code.
+func printResponse(data models.APIResponse, out output, prettyPrint bool, isExtensionsLiveEndpoint bool, printVerbose func()) error {
+	var err error
+	if data.Error == "" {
+		data.Data, err = out.transform(data.Data)
+		if err != nil {
+			return err
+		}
+
+		if out.Format != OutputJSON && out.Format != OutputYAML {
+			printVerbose()
+			return out.writeRows(os.Stdout, data.Data)
+		}
+	}
+
 	var d []byte
 	if isExtensionsLiveEndpoint {
 		extensionBody := models.ExtensionAPIResponse{
@@ -218,6 +285,23 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		}
 	}
 
+	if out.Format == OutputYAML {
+		printVerbose()
+		var obj any
+		json.Unmarshal(d, &obj)
+		var y bytes.Buffer
+		e := yaml.NewEncoder(&y)
+		e.SetIndent(2)
+		if err := e.Encode(obj); err != nil {
+			return fmt.Errorf("Error marshalling yaml: %v", err)
+		}
+		if data.Error != "" {
+			return fmt.Errorf(y.String())
+		}
+		fmt.Print(y.String())
+		return nil
+	}
+
 	if prettyPrint {
 		var obj map[string]interface{}
 		json.Unmarshal(d, &obj)
@@ -225,9 +309,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		if runtime.GOOS == "windows" {
 			s, _ := json.MarshalIndent(obj, "", "  ")
 
-			if verbose {
-				printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
-			}
+			printVerbose()
 			if data.Error == "" {
 				fmt.Println(string(s))
 			} else {
@@ -245,9 +327,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 			return err
 		}
 
-		if verbose {
-			printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
-		}
+		printVerbose()
 		if data.Error == "" {
 			fmt.Println(string(s))
 		} else {
@@ -256,9 +336,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		return nil
 	}
 
-	if verbose {
-		printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
-	}
+	printVerbose()
 	if data.Error == "" {
 		fmt.Println(string(d))
 	} else {
@@ -269,7 +347,8 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 	return nil
 }
 
-// ValidOptions returns a list of supported endpoints given a specified method as noted in the map endpointMethodSupports, which is located in resources.go of this package.
+// ValidOptions returns a list of supported endpoints given a specified method as noted in the map endpointMethodSupports, which is located in resources.go of this package,
+// along with the endpoints in the API specification.
 func ValidOptions(method string) []string {
 	names := []string{}
 
@@ -279,6 +358,13 @@ func ValidOptions(method string) []string {
 		}
 	}
 
+	endpoints, _ := spec.Endpoints()
+	for _, e := range endpoints {
+		if e.Method == method && !endpointMethodSupports[e.Path][method] {
+			names = append(names, e.Path)
+		}
+	}
+
 	sort.Strings(names)
 
 	return names
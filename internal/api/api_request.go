@@ -7,10 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/request"
-	"golang.org/x/time/rate"
 )
 
 type apiRequestParameters struct {
@@ -35,15 +33,12 @@ func apiRequest(method string, url string, payload []byte, p apiRequestParameter
 	}
 	req.Header.Set("Client-ID", p.ClientID)
 	req.Header.Set("Content-Type", "application/json")
-	rl := rate.NewLimiter(rate.Every(time.Minute), 800)
-
-	client := NewClient(rl)
 
 	if p.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+p.Token)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := getClient().Do(req)
 	if err != nil {
 		fmt.Printf("Error reading body: %v", err)
 		return apiRequestResponse{}, err
@@ -3,8 +3,10 @@
 package api
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -26,6 +28,7 @@ func TestNewRequest(t *testing.T) {
 	viper.Set("accesstoken", "4567")
 	viper.Set("refreshtoken", "123")
 	viper.Set("tokenexpiration", "0")
+	getClient().RetryBackoff = time.Millisecond
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		a.Equal(params.ClientID, r.Header.Get("Client-ID"), "ClientID mismatch")
@@ -56,17 +59,57 @@ func TestNewRequest(t *testing.T) {
 
 	defaultAutoPaginate := 0
 	// tests for normal get requests
-	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, true, nil, false)
-	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
+	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, true, nil, false, OutputOptions{})
+	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false, OutputOptions{})
 
 	// testing cursors autopagination
-	NewRequest("GET", "/cursor", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
+	NewRequest("GET", "/cursor", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false, OutputOptions{})
 
 	// testing 204 no-content apis
-	NewRequest("POST", "/nocontent", []string{"test=1", "test=2"}, nil, false, nil, false)
+	NewRequest("POST", "/nocontent", []string{"test=1", "test=2"}, nil, false, nil, false, OutputOptions{})
 
 	// testing 500 errors
-	NewRequest("GET", "/error", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
+	NewRequest("GET", "/error", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false, OutputOptions{})
+}
+
+func TestNewRequestStreamedFilter(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"data":[{"id":"1","n":1},{"id":"2","n":2}],"pagination":{"cursor":"next"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"3","n":3}]}`))
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	run := func(filter string) (string, error) {
+		r, w, _ := os.Pipe()
+		stdout := os.Stdout
+		os.Stdout = w
+		autopaginate := 0
+		err := NewRequest("GET", "/streams", nil, nil, false, &autopaginate, false, OutputOptions{Format: OutputJSONL, Filter: filter})
+		os.Stdout = stdout
+		w.Close()
+		b, _ := io.ReadAll(r)
+		return string(b), err
+	}
+
+	// filters on each item give the same results whether they're applied to each page or the whole response
+	out, err := run(`.[] | select(.n > 1)`)
+	a.Nil(err)
+	a.Equal("{\"id\":\"2\",\"n\":2}\n{\"id\":\"3\",\"n\":3}\n", out)
+
+	// filters needing the whole response would give a result for each page instead
+	for _, filter := range []string{`length`, `.[0]`, `keys`, `.[] == 1 or length > 0`} {
+		_, err = run(filter)
+		a.NotNil(err, filter)
+	}
 }
 
 func TestValidOptions(t *testing.T) {
@@ -74,6 +117,7 @@ func TestValidOptions(t *testing.T) {
 
 	get := ValidOptions("GET")
 	a.NotEmpty(get)
+	a.Contains(get, "/chat/chatters") // only in the API specification
 
 	potato := ValidOptions("potato")
 	a.Empty(potato)
@@ -0,0 +1,328 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+// MaxBatchSize is the most values Twitch accepts for a repeated query parameter, such as id or login
+const MaxBatchSize = 100
+
+// BatchParameters splits a request into several, each given up to Size of Values for the Param query parameter.
+type BatchParameters struct {
+	Param       string
+	Values      []string
+	Size        int
+	Concurrency int
+}
+
+// NewBatchRequest makes one request per batch of values, running up to Concurrency at once under the shared rate limiter,
+// and prints the data of every request merged into a single response.
+func NewBatchRequest(method string, path string, queryParameters []string, body []byte, prettyPrint bool, verbose bool, outputOptions OutputOptions, batch BatchParameters) error {
+	client, err := GetClientInformation()
+	if err != nil {
+		return fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+
+	out, err := newOutput(outputOptions)
+	if err != nil {
+		return err
+	}
+
+	if batch.Param == "" {
+		return fmt.Errorf("A batch parameter is required")
+	}
+	if batch.Size < 1 || batch.Size > MaxBatchSize {
+		return fmt.Errorf("Invalid batch size provided. Must be between 1 and %v.", MaxBatchSize)
+	}
+	if batch.Concurrency < 1 {
+		return fmt.Errorf("Invalid concurrency provided. Must be at least 1.")
+	}
+
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+
+	var chunks [][]string
+	for i := 0; i < len(batch.Values); i += batch.Size {
+		end := i + batch.Size
+		if end > len(batch.Values) {
+			end = len(batch.Values)
+		}
+		chunks = append(chunks, batch.Values[i:end])
+	}
+
+	responses := make([]apiRequestResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	runConcurrently(len(chunks), batch.Concurrency, func(i int) {
+		u, err := url.Parse(baseURL + path)
+		if err != nil {
+			errs[i] = fmt.Errorf("Error getting url: %v", err)
+			return
+		}
+
+		q := u.Query()
+		addQueryParameters(q, queryParameters)
+		for _, v := range chunks[i] {
+			q.Add(batch.Param, v)
+		}
+		u.RawQuery = q.Encode()
+
+		responses[i], errs[i] = apiRequest(strings.ToUpper(method), u.String(), body, apiRequestParameters{
+			ClientID: client.ClientID,
+			Token:    client.Token,
+		})
+	})
+
+	merged := models.APIResponse{Data: []any{}}
+	var failures []error
+	for i, resp := range responses {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Errorf("Batch %v: %v", i+1, errs[i]))
+			continue
+		}
+
+		var apiResponse models.APIResponse
+		if len(resp.Body) != 0 {
+			if err := json.Unmarshal(resp.Body, &apiResponse); err != nil {
+				failures = append(failures, fmt.Errorf("Batch %v: Error unmarshalling body: %v", i+1, err))
+				continue
+			}
+		}
+		if resp.StatusCode > 299 || resp.StatusCode < 200 {
+			failures = append(failures, fmt.Errorf("Batch %v: %v %v", i+1, resp.StatusCode, apiResponse.Message))
+			continue
+		}
+
+		switch d := apiResponse.Data.(type) {
+		case []any:
+			merged.Data = append(merged.Data.([]any), d...)
+		case nil:
+		default:
+			merged.Data = append(merged.Data.([]any), d)
+		}
+	}
+
+	err = printResponse(merged, out, prettyPrint, false, func() {
+		if !verbose {
+			return
+		}
+		for i, resp := range responses {
+			if errs[i] == nil {
+				printVerboseHeaders(resp.HttpMethod, resp.RequestPath, resp.RequestHeaders, resp.ResponseHeaders, resp.StatusCode, resp.HttpVersion)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(failures) != 0 {
+		return fmt.Errorf("%v of %v batches failed:\n%v", len(failures), len(chunks), errors.Join(failures...))
+	}
+	return nil
+}
+
+// ReadBatchValues reads one value per line, skipping blank lines
+func ReadBatchValues(r io.Reader) ([]string, error) {
+	var values []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		v := strings.TrimSpace(scanner.Text())
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, scanner.Err()
+}
+
+// BatchRequest is a single request in a request list run by RunRequestList.
+type BatchRequest struct {
+	ID     string          `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  batchQuery      `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResult is the line written for each request in a request list.
+type BatchResult struct {
+	Line       int             `json:"line"`
+	ID         string          `json:"id,omitempty"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// batchQuery is a request's query parameters, where each parameter is either a string or a list of strings
+type batchQuery map[string][]string
+
+func (q *batchQuery) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*q = batchQuery{}
+	for k, v := range raw {
+		var values []string
+		if err := json.Unmarshal(v, &values); err != nil {
+			var value string
+			if err := json.Unmarshal(v, &value); err != nil {
+				return fmt.Errorf("query parameter %v must be a string or a list of strings", k)
+			}
+			values = []string{value}
+		}
+		(*q)[k] = values
+	}
+	return nil
+}
+
+// RunRequestList runs every request in r, one JSON BatchRequest per line, with up to concurrency running at once.
+// A BatchResult is written to w for each request, in the same order as r. An error is returned if any of the requests failed.
+func RunRequestList(r io.Reader, w io.Writer, concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("Invalid concurrency provided. Must be at least 1.")
+	}
+
+	var requests []BatchRequest
+	var lines []int
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var req BatchRequest
+		if err := json.Unmarshal([]byte(text), &req); err != nil {
+			return fmt.Errorf("Error parsing line %v: %v", line, err)
+		}
+		if req.Method == "" {
+			req.Method = http.MethodGet
+		}
+		if req.Path == "" {
+			return fmt.Errorf("Error parsing line %v: path is required", line)
+		}
+		if !strings.HasPrefix(req.Path, "/") {
+			req.Path = "/" + req.Path
+		}
+		requests = append(requests, req)
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	client, err := GetClientInformation()
+	if err != nil {
+		return fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+
+	// Results are written in order as soon as every earlier request has finished
+	results := make([]*BatchResult, len(requests))
+	next := 0
+	failed := 0
+	var mu sync.Mutex
+
+	runConcurrently(len(requests), concurrency, func(i int) {
+		req := requests[i]
+		result := BatchResult{Line: lines[i], ID: req.ID, Method: strings.ToUpper(req.Method), Path: req.Path}
+
+		u, err := url.Parse(baseURL + req.Path)
+		if err != nil {
+			result.Error = fmt.Sprintf("Error getting url: %v", err)
+		} else {
+			q := u.Query()
+			for k, values := range req.Query {
+				for _, v := range values {
+					q.Add(k, v)
+				}
+			}
+			u.RawQuery = q.Encode()
+
+			var body []byte
+			if len(req.Body) != 0 {
+				body = req.Body
+			}
+			resp, err := apiRequest(result.Method, u.String(), body, apiRequestParameters{
+				ClientID: client.ClientID,
+				Token:    client.Token,
+			})
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.StatusCode = resp.StatusCode
+				if json.Valid(resp.Body) {
+					result.Response = resp.Body
+				} else if len(resp.Body) != 0 {
+					result.Response, _ = json.Marshal(string(resp.Body))
+				}
+				if resp.StatusCode > 299 || resp.StatusCode < 200 {
+					var apiResponse models.APIResponse
+					json.Unmarshal(resp.Body, &apiResponse)
+					result.Error = apiResponse.Message
+					if result.Error == "" {
+						result.Error = http.StatusText(resp.StatusCode)
+					}
+				}
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if result.Error != "" {
+			failed++
+		}
+		results[i] = &result
+		for ; next < len(results) && results[next] != nil; next++ {
+			b, _ := json.Marshal(results[next])
+			fmt.Fprintln(w, string(b))
+			results[next] = nil
+		}
+	})
+
+	if failed != 0 {
+		return fmt.Errorf("%v of %v requests failed", failed, len(requests))
+	}
+	return nil
+}
+
+// runConcurrently calls f for every index from 0 to n, with up to concurrency calls running at once
+func runConcurrently(n int, concurrency int, f func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency && worker < n; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestNewBatchRequest(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+
+	var mu sync.Mutex
+	var batches [][]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins := r.URL.Query()["login"]
+		a.Equal("1", r.URL.Query().Get("test"))
+
+		mu.Lock()
+		batches = append(batches, logins)
+		mu.Unlock()
+
+		if logins[0] == "error" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Bad Request","status":400,"message":"Invalid login"}`))
+			return
+		}
+		users := []map[string]string{}
+		for _, l := range logins {
+			users = append(users, map[string]string{"login": l})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": users})
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	values, err := ReadBatchValues(strings.NewReader("a\nb\n\n c \nd\ne\n"))
+	a.Nil(err)
+	a.Equal([]string{"a", "b", "c", "d", "e"}, values)
+
+	batch := BatchParameters{Param: "login", Values: values, Size: 2, Concurrency: 2}
+	err = NewBatchRequest("get", "/users", []string{"test=1"}, nil, false, false, OutputOptions{}, batch)
+	a.Nil(err)
+	a.Len(batches, 3)
+
+	batch.Values = []string{"a", "error"}
+	batch.Size = 1
+	err = NewBatchRequest("get", "/users", []string{"test=1"}, nil, false, false, OutputOptions{}, batch)
+	a.NotNil(err)
+	a.Contains(err.Error(), "Invalid login")
+
+	batch.Size = 101
+	a.NotNil(NewBatchRequest("get", "/users", nil, nil, false, false, OutputOptions{}, batch))
+	batch.Size = 1
+	batch.Concurrency = 0
+	a.NotNil(NewBatchRequest("get", "/users", nil, nil, false, false, OutputOptions{}, batch))
+}
+
+func TestRunRequestList(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/users":
+			fmt.Fprintf(w, `{"data":[{"id":%q}]}`, strings.Join(r.URL.Query()["id"], ","))
+		case "/chat/announcements":
+			a.Equal(http.MethodPost, r.Method)
+			a.JSONEq(`{"message":"hi"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","status":404,"message":"No such endpoint"}`))
+		}
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	requests := `{"id":"first","path":"users","query":{"id":["1","2"]}}
+
+{"method":"post","path":"/chat/announcements","query":{"broadcaster_id":"1"},"body":{"message":"hi"}}
+{"path":"/potato"}
+`
+	var out bytes.Buffer
+	err := RunRequestList(strings.NewReader(requests), &out, 2)
+	a.NotNil(err)
+	a.Equal("1 of 3 requests failed", err.Error())
+
+	var results []BatchResult
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var r BatchResult
+		a.Nil(json.Unmarshal([]byte(line), &r))
+		results = append(results, r)
+	}
+	a.Len(results, 3)
+
+	a.Equal(1, results[0].Line)
+	a.Equal("first", results[0].ID)
+	a.Equal(200, results[0].StatusCode)
+	a.JSONEq(`{"data":[{"id":"1,2"}]}`, string(results[0].Response))
+
+	a.Equal(3, results[1].Line)
+	a.Equal("POST", results[1].Method)
+	a.Equal(204, results[1].StatusCode)
+	a.Empty(results[1].Error)
+
+	a.Equal(404, results[2].StatusCode)
+	a.Equal("No such endpoint", results[2].Error)
+
+	a.NotNil(RunRequestList(strings.NewReader(`{"method":"GET"}`), &out, 1))
+	a.NotNil(RunRequestList(strings.NewReader(`potato`), &out, 1))
+}
@@ -4,36 +4,187 @@ package api
 
 import (
 	"context"
+	"io"
+	"log"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/twitchdev/twitch-cli/internal/request"
 	"golang.org/x/time/rate"
 )
 
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	// Once fewer than this fraction of the bucket is left, requests are spread out over the time left until it resets
+	lowRemainingFraction = 0.1
+)
+
+// RLClient is an HTTP client that paces requests to stay within the Twitch API rate limits. Along with the fixed
+// RateLimiter, it follows the Ratelimit-Limit, Ratelimit-Remaining and Ratelimit-Reset headers of each response,
+// waits for the bucket to reset and retries when rate limited, and retries idempotent requests that fail with a 5xx.
 type RLClient struct {
 	client      *http.Client
 	RateLimiter *rate.Limiter
+
+	// MaxRetries is how many times a request is retried after a 429, or after a 5xx for idempotent methods
+	MaxRetries int
+	// RetryBackoff is the base delay before retrying a 5xx. It doubles with each retry, with jitter.
+	RetryBackoff time.Duration
+
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
 }
 
 func (c *RLClient) Do(req *http.Request) (*http.Response, error) {
-	err := c.RateLimiter.Wait(context.Background())
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		err := c.RateLimiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := sleep(ctx, c.delay(time.Now())); err != nil {
+			return nil, err
+		}
+
+		if attempt > 0 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		c.update(resp.Header)
+
+		if attempt >= c.MaxRetries {
+			return resp, nil
+		}
+
+		var wait time.Duration
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			// Rate limited requests aren't processed, so they're safe to retry whatever the method
+			wait = c.delay(time.Now())
+			if wait == 0 {
+				wait = c.backoff(attempt)
+			}
+			log.Printf("Rate limited by the API, retrying in %v", wait.Round(time.Millisecond))
+		case resp.StatusCode >= 500 && isIdempotent(req.Method):
+			wait = c.backoff(attempt)
+			log.Printf("API responded with status %v, retrying in %v", resp.StatusCode, wait.Round(time.Millisecond))
+		default:
+			return resp, nil
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// update records the rate limit bucket from the response headers, if they were returned
+func (c *RLClient) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("Ratelimit-Remaining"))
 	if err != nil {
-		return nil, err
+		return
 	}
-	resp, err := c.client.Do(req)
+	reset, err := strconv.ParseInt(h.Get("Ratelimit-Reset"), 10, 64)
 	if err != nil {
-		return nil, err
+		return
 	}
-	return resp, nil
+	limit, _ := strconv.Atoi(h.Get("Ratelimit-Limit"))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = limit
+	c.remaining = remaining
+	c.reset = time.Unix(reset, 0)
 }
 
-func NewClient(l *rate.Limiter) *RLClient {
-	client := http.Client{
-		Timeout: time.Second * 10,
+// delay returns how long to wait before the next request, based on the last rate limit headers. When the bucket is
+// empty it waits until the reset, and when it's nearly empty it spreads the remaining points until the reset.
+func (c *RLClient) delay(now time.Time) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.reset.IsZero() || !c.reset.After(now) {
+		return 0
+	}
+	untilReset := c.reset.Sub(now)
+
+	if c.remaining <= 0 {
+		return untilReset
+	}
+	if c.limit > 0 && float64(c.remaining) < float64(c.limit)*lowRemainingFraction {
+		return untilReset / time.Duration(c.remaining+1)
+	}
+	return 0
+}
+
+// backoff returns an exponential delay for the given retry attempt, with jitter so parallel clients don't retry in lockstep
+func (c *RLClient) backoff(attempt int) time.Duration {
+	d := c.RetryBackoff << attempt
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
+}
 
+func NewClient(l *rate.Limiter) *RLClient {
 	return &RLClient{
-		client:      &client,
-		RateLimiter: l,
+		client:       request.NewClient(time.Second * 10),
+		RateLimiter:  l,
+		MaxRetries:   defaultMaxRetries,
+		RetryBackoff: defaultRetryBackoff,
 	}
 }
+
+var (
+	defaultClient     *RLClient
+	defaultClientOnce sync.Once
+)
+
+// getClient returns the client shared by every API request in the process, so rate limits are tracked across
+// paginated and repeated calls.
+func getClient() *RLClient {
+	defaultClientOnce.Do(func() {
+		// Twitch gives 800 points per minute by default
+		defaultClient = NewClient(rate.NewLimiter(rate.Every(time.Minute/800), 800))
+	})
+	return defaultClient
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,3 +44,92 @@ func TestNewClient(t *testing.T) {
 	_, err = c.Do(req)
 	a.NotNil(err)
 }
+
+func TestClientRetries(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	calls := 0
+	status := http.StatusTooManyRequests
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		a.Equal("body", string(body))
+
+		if calls == 1 {
+			w.Header().Set("Ratelimit-Limit", "800")
+			w.Header().Set("Ratelimit-Remaining", "0")
+			w.Header().Set("Ratelimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := NewClient(rate.NewLimiter(rate.Inf, 1))
+	c.RetryBackoff = time.Millisecond
+
+	// rate limited requests are retried, with the body sent again
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("body"))
+	resp, err := c.Do(req)
+	a.Nil(err)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal(2, calls)
+
+	// server errors are only retried for idempotent requests
+	calls = 0
+	status = http.StatusServiceUnavailable
+	req, _ = http.NewRequest(http.MethodGet, ts.URL, strings.NewReader("body"))
+	resp, err = c.Do(req)
+	a.Nil(err)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal(2, calls)
+
+	calls = 0
+	req, _ = http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("body"))
+	resp, err = c.Do(req)
+	a.Nil(err)
+	a.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	a.Equal(1, calls)
+
+	// retries stop once MaxRetries is reached
+	calls = 0
+	c.MaxRetries = 0
+	req, _ = http.NewRequest(http.MethodGet, ts.URL, strings.NewReader("body"))
+	resp, err = c.Do(req)
+	a.Nil(err)
+	a.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	a.Equal(1, calls)
+}
+
+func TestClientDelay(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	c := NewClient(rate.NewLimiter(rate.Inf, 1))
+	now := time.Now()
+	a.Equal(time.Duration(0), c.delay(now))
+
+	header := func(limit int, remaining int, reset time.Time) http.Header {
+		h := http.Header{}
+		h.Set("Ratelimit-Limit", strconv.Itoa(limit))
+		h.Set("Ratelimit-Remaining", strconv.Itoa(remaining))
+		h.Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		return h
+	}
+	reset := now.Truncate(time.Second).Add(10 * time.Second)
+
+	// plenty left, so no pacing
+	c.update(header(800, 700, reset))
+	a.Equal(time.Duration(0), c.delay(now))
+
+	// nearly empty, so the remaining points are spread until the reset
+	c.update(header(800, 9, reset))
+	a.Equal(reset.Sub(now)/10, c.delay(now))
+
+	// empty, so wait for the reset
+	c.update(header(800, 0, reset))
+	a.Equal(reset.Sub(now), c.delay(now))
+
+	// the reset has passed
+	a.Equal(time.Duration(0), c.delay(reset.Add(time.Second)))
+}
@@ -0,0 +1,452 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/twitchdev/twitch-cli/internal/jq"
+)
+
+// Collection is a saved sequence of requests, run in order by RunCollection.
+type Collection struct {
+	Name      string              `yaml:"name"`
+	Variables map[string]any      `yaml:"variables"`
+	Requests  []CollectionRequest `yaml:"requests"`
+
+	// dir is the folder of the collection file, which @file bodies are relative to
+	dir string
+}
+
+// CollectionRequest is a request of a collection. Its path, query, body and assertions can use {{variables}}.
+type CollectionRequest struct {
+	Name   string `yaml:"name"`
+	Method string `yaml:"method"`
+	Path   string `yaml:"path"`
+	// Query values are strings, or lists of strings for repeated parameters
+	Query map[string]any `yaml:"query"`
+	// Body is either JSON given as YAML, or @file to read it from a file relative to the collection
+	Body any `yaml:"body"`
+	// Extract sets variables for later requests from the response, such as reward_id: data[0].id
+	Extract map[string]string `yaml:"extract"`
+	Assert  CollectionAssert  `yaml:"assert"`
+}
+
+// CollectionAssert checks a response. Without a status, any 2xx status passes.
+type CollectionAssert struct {
+	Status int `yaml:"status"`
+	// Body maps paths in the response, such as data[0].title, to their expected values
+	Body map[string]any `yaml:"body"`
+	// Expressions are jq-style expressions that must be true for the response, such as '.data | length > 0'
+	Expressions []string `yaml:"expressions"`
+}
+
+// CollectionEnvironment holds the settings that differ between running a collection against production and the mock.
+type CollectionEnvironment struct {
+	// Target and AsUser are the same as --target and --as-user, which take precedence
+	Target    string         `yaml:"target"`
+	AsUser    string         `yaml:"as_user"`
+	Variables map[string]any `yaml:"variables"`
+}
+
+// CollectionResult is the outcome of one request of a collection.
+type CollectionResult struct {
+	Name       string
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	// Failures are the assertions and extractions that failed
+	Failures []string
+	// Error is set when the request couldn't be made, such as for an undefined variable
+	Error string
+	// Skipped is set for requests after a failure with FailFast
+	Skipped bool
+	// Response is the response body, kept for failed requests
+	Response []byte
+}
+
+// Passed returns whether the request ran without errors or failures.
+func (r CollectionResult) Passed() bool {
+	return !r.Skipped && r.Error == "" && len(r.Failures) == 0
+}
+
+// CollectionRunParameters configures RunCollection.
+type CollectionRunParameters struct {
+	// Variables override those of the collection and environment
+	Variables map[string]any
+	// FailFast skips the remaining requests after the first that doesn't pass
+	FailFast bool
+}
+
+// LoadCollection reads a collection from a YAML or JSON file.
+func LoadCollection(path string) (*Collection, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Collection
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("Error parsing collection %v: %v", path, err)
+	}
+	if len(c.Requests) == 0 {
+		return nil, fmt.Errorf("Collection %v has no requests", path)
+	}
+	if c.Name == "" {
+		c.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	for i, r := range c.Requests {
+		if r.Path == "" {
+			return nil, fmt.Errorf("Request %v of collection %v has no path", i+1, path)
+		}
+		if r.Name == "" {
+			c.Requests[i].Name = fmt.Sprintf("%v %v", strings.ToUpper(r.Method), r.Path)
+		}
+	}
+	c.dir = filepath.Dir(path)
+	return &c, nil
+}
+
+// LoadCollectionEnvironment reads an environment from a YAML or JSON file.
+func LoadCollectionEnvironment(path string) (CollectionEnvironment, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return CollectionEnvironment{}, err
+	}
+
+	var env CollectionEnvironment
+	if err := yaml.Unmarshal(b, &env); err != nil {
+		return CollectionEnvironment{}, fmt.Errorf("Error parsing environment %v: %v", path, err)
+	}
+	return env, nil
+}
+
+// RunCollection runs the requests of a collection in order, writing the outcome of each to w as it finishes.
+// Variables come from the collection, then p.Variables, then the values extracted from earlier responses.
+func RunCollection(c *Collection, p CollectionRunParameters, w io.Writer) ([]CollectionResult, error) {
+	client, err := GetClientInformation()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+
+	vars := map[string]any{}
+	for k, v := range c.Variables {
+		vars[k] = normalize(v)
+	}
+	for k, v := range p.Variables {
+		vars[k] = normalize(v)
+	}
+
+	results := make([]CollectionResult, 0, len(c.Requests))
+	failed := false
+	for _, r := range c.Requests {
+		result := CollectionResult{Name: r.Name, Method: strings.ToUpper(r.Method), Path: r.Path}
+		if result.Method == "" {
+			result.Method = http.MethodGet
+		}
+
+		if failed && p.FailFast {
+			result.Skipped = true
+			results = append(results, result)
+			writeCollectionResult(w, result)
+			continue
+		}
+
+		start := time.Now()
+		runCollectionRequest(c, r, vars, client, &result)
+		result.Duration = time.Since(start)
+
+		if !result.Passed() {
+			failed = true
+		}
+		results = append(results, result)
+		writeCollectionResult(w, result)
+	}
+
+	return results, nil
+}
+
+func runCollectionRequest(c *Collection, r CollectionRequest, vars map[string]any, client clientInformation, result *CollectionResult) {
+	path, err := substitute(r.Path, vars)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	result.Path = path
+
+	u, err := url.Parse(baseURL + path)
+	if err != nil {
+		result.Error = fmt.Sprintf("Error getting url: %v", err)
+		return
+	}
+	q := u.Query()
+	for k, v := range r.Query {
+		values := []any{v}
+		if list, ok := v.([]any); ok {
+			values = list
+		}
+		for _, value := range values {
+			s, err := substitute(stringValue(normalize(value)), vars)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			q.Add(k, s)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	body, err := c.requestBody(r.Body, vars)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	resp, err := apiRequest(result.Method, u.String(), body, apiRequestParameters{
+		ClientID: client.ClientID,
+		Token:    client.Token,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	result.StatusCode = resp.StatusCode
+
+	var data any
+	if len(resp.Body) != 0 {
+		if err := json.Unmarshal(resp.Body, &data); err != nil {
+			data = string(resp.Body)
+		}
+	}
+
+	if r.Assert.Status != 0 {
+		if resp.StatusCode != r.Assert.Status {
+			result.Failures = append(result.Failures, fmt.Sprintf("Expected status %v, got %v", r.Assert.Status, resp.StatusCode))
+		}
+	} else if resp.StatusCode > 299 || resp.StatusCode < 200 {
+		result.Failures = append(result.Failures, fmt.Sprintf("Expected a 2xx status, got %v", resp.StatusCode))
+	}
+
+	for _, path := range sortedKeys(r.Assert.Body) {
+		expected, err := substituteValue(normalize(r.Assert.Body[path]), vars)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			continue
+		}
+		actual, err := evaluatePath(path, data)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			result.Failures = append(result.Failures, fmt.Sprintf("Expected %v to be %v, got %v", path, jsonString(expected), jsonString(actual)))
+		}
+	}
+
+	for _, expr := range r.Assert.Expressions {
+		expr, err := substitute(expr, vars)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			continue
+		}
+		f, err := jq.Compile(expr)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			continue
+		}
+		values, err := f(data)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("Error evaluating %v: %v", expr, err))
+			continue
+		}
+		if len(values) == 0 || !jq.Truthy(values[0]) {
+			result.Failures = append(result.Failures, fmt.Sprintf("Expected %v to be true", expr))
+		}
+	}
+
+	for _, name := range sortedKeys(stringMap(r.Extract)) {
+		path := r.Extract[name]
+		v, err := evaluatePath(path, data)
+		if err != nil {
+			result.Failures = append(result.Failures, err.Error())
+			continue
+		}
+		if v == nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("Unable to extract %v: %v is null", name, path))
+			continue
+		}
+		vars[name] = v
+	}
+
+	if !result.Passed() {
+		result.Response = resp.Body
+	}
+}
+
+// requestBody returns the JSON body of a request, with its variables substituted
+func (c *Collection) requestBody(body any, vars map[string]any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.HasPrefix(b, "@") {
+			file := b[1:]
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(c.dir, file)
+			}
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			s, err := substitute(string(content), vars)
+			return []byte(s), err
+		}
+		s, err := substitute(b, vars)
+		return []byte(s), err
+	}
+
+	v, err := substituteValue(normalize(body), vars)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// substitute replaces each {{variable}} in s, returning an error for variables that aren't defined
+func substitute(s string, vars map[string]any) (string, error) {
+	var err error
+	out := variablePattern.ReplaceAllStringFunc(s, func(m string) string {
+		name := variablePattern.FindStringSubmatch(m)[1]
+		v, ok := vars[name]
+		if !ok {
+			err = fmt.Errorf("Undefined variable %v", name)
+			return m
+		}
+		return stringValue(v)
+	})
+	return out, err
+}
+
+// substituteValue substitutes the variables in the strings of a JSON value. A string that's only a variable is
+// replaced by the variable's value as is, so numbers and objects keep their type.
+func substituteValue(v any, vars map[string]any) (any, error) {
+	switch value := v.(type) {
+	case string:
+		if m := variablePattern.FindStringSubmatch(value); m != nil && m[0] == strings.TrimSpace(value) {
+			variable, ok := vars[m[1]]
+			if !ok {
+				return nil, fmt.Errorf("Undefined variable %v", m[1])
+			}
+			return variable, nil
+		}
+		return substitute(value, vars)
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			s, err := substituteValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = s
+		}
+		return out, nil
+	case map[string]any:
+		out := map[string]any{}
+		for k, item := range value {
+			s, err := substituteValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = s
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// evaluatePath evaluates a path such as data[0].id, or any jq-style expression, against a response
+func evaluatePath(path string, data any) (any, error) {
+	expr := path
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	f, err := jq.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	values, err := f(data)
+	if err != nil {
+		return nil, fmt.Errorf("Error evaluating %v: %v", path, err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+// normalize converts a value decoded from YAML to the types encoding/json uses, so it can be compared with responses
+func normalize(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	json.Unmarshal(b, &out)
+	return out
+}
+
+// stringValue formats a variable for a path, query parameter or text body. Strings are used as is, and anything else as JSON.
+func stringValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return jsonString(v)
+}
+
+func stringMap(m map[string]string) map[string]any {
+	out := map[string]any{}
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func writeCollectionResult(w io.Writer, r CollectionResult) {
+	switch {
+	case r.Skipped:
+		color.New(color.FgYellow).Fprintf(w, "- %v (skipped)\n", r.Name)
+	case r.Error != "":
+		color.New(color.FgRed).Fprintf(w, "✗ %v: %v\n", r.Name, r.Error)
+	case len(r.Failures) != 0:
+		color.New(color.FgRed).Fprintf(w, "✗ %v (%v %v)\n", r.Name, r.StatusCode, r.Duration.Round(time.Millisecond))
+		for _, f := range r.Failures {
+			color.New(color.FgRed).Fprintf(w, "    %v\n", f)
+		}
+	default:
+		color.New(color.FgGreen).Fprintf(w, "✔ %v (%v %v)\n", r.Name, r.StatusCode, r.Duration.Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+const testCollection = `
+name: rewards
+variables:
+  broadcaster_id: "1234"
+requests:
+  - name: Create reward
+    method: POST
+    path: /channel_points/custom_rewards
+    query:
+      broadcaster_id: "{{broadcaster_id}}"
+    body:
+      title: "{{title}}"
+      cost: "{{cost}}"
+    extract:
+      reward_id: data[0].id
+    assert:
+      status: 200
+      body:
+        data[0].title: Hydrate
+        data[0].cost: 100
+  - name: Get reward
+    path: channel_points/custom_rewards
+    query:
+      broadcaster_id: "{{broadcaster_id}}"
+      id: "{{reward_id}}"
+    assert:
+      expressions:
+        - '.data | length == 1'
+        - '.data[0].id == "{{reward_id}}"'
+  - name: Update reward
+    method: PATCH
+    path: /channel_points/custom_rewards
+    query:
+      id: "{{reward_id}}"
+    body: "@update.json"
+    assert:
+      status: 204
+  - name: Undefined
+    path: /users
+    query:
+      id: "{{missing}}"
+`
+
+func TestRunCollection(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	color.NoColor = true
+
+	var patched map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			a.Equal("1234", r.URL.Query().Get("broadcaster_id"))
+			var body map[string]any
+			a.Nil(json.NewDecoder(r.Body).Decode(&body))
+			// a field that's only a variable keeps the variable's type
+			a.Equal(float64(100), body["cost"])
+			body["id"] = "abc"
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{body}})
+		case http.MethodGet:
+			a.Equal("abc", r.URL.Query().Get("id"))
+			w.Write([]byte(`{"data":[{"id":"abc","title":"Hydrate"}]}`))
+		case http.MethodPatch:
+			b, _ := io.ReadAll(r.Body)
+			a.Nil(json.Unmarshal(b, &patched))
+			w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "flow.yaml")
+	a.Nil(os.WriteFile(path, []byte(testCollection), 0644))
+	a.Nil(os.WriteFile(filepath.Join(dir, "update.json"), []byte(`{"is_paused":true,"id":"{{reward_id}}"}`), 0644))
+
+	c, err := LoadCollection(path)
+	a.Nil(err)
+	a.Equal("rewards", c.Name)
+	a.Len(c.Requests, 4)
+
+	var out bytes.Buffer
+	results, err := RunCollection(c, CollectionRunParameters{Variables: map[string]any{"title": "Hydrate", "cost": 100}}, &out)
+	a.Nil(err)
+	a.Len(results, 4)
+
+	a.True(results[0].Passed(), results[0].Failures)
+	a.True(results[1].Passed(), results[1].Failures)
+	a.Equal(map[string]any{"is_paused": true, "id": "abc"}, patched)
+
+	// the update returned 200 rather than 204
+	a.False(results[2].Passed())
+	a.Equal([]string{"Expected status 204, got 200"}, results[2].Failures)
+	a.Equal(`{"data":[]}`, string(results[2].Response))
+
+	a.Equal("Undefined variable missing", results[3].Error)
+	a.Contains(out.String(), "✔ Create reward")
+	a.Contains(out.String(), "✗ Update reward")
+
+	// with FailFast, requests after the first failure are skipped
+	results, err = RunCollection(c, CollectionRunParameters{Variables: map[string]any{"title": "Hydrate", "cost": 100}, FailFast: true}, io.Discard)
+	a.Nil(err)
+	a.True(results[3].Skipped)
+
+	var report bytes.Buffer
+	a.Nil(WriteJUnitReport(&report, c.Name, time.Now(), results))
+	var suites junitTestSuites
+	a.Nil(xml.Unmarshal(report.Bytes(), &suites))
+	a.Equal(4, suites.Tests)
+	a.Equal(1, suites.Failures)
+	a.Equal(1, suites.Skipped)
+	a.Equal("Update reward", suites.Suites[0].Cases[2].Name)
+	a.Equal("Expected status 204, got 200", suites.Suites[0].Cases[2].Failure.Message)
+	a.Nil(suites.Suites[0].Cases[0].Failure)
+}
+
+func TestLoadCollection(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "empty.yaml")
+	a.Nil(os.WriteFile(path, []byte("name: empty\n"), 0644))
+	_, err := LoadCollection(path)
+	a.NotNil(err)
+
+	path = filepath.Join(dir, "users.yaml")
+	a.Nil(os.WriteFile(path, []byte("requests:\n  - path: /users\n    method: get\n"), 0644))
+	c, err := LoadCollection(path)
+	a.Nil(err)
+	a.Equal("users", c.Name)
+	a.Equal("GET /users", c.Requests[0].Name)
+
+	path = filepath.Join(dir, "mock.yaml")
+	a.Nil(os.WriteFile(path, []byte("target: mock\nas_user: foo\nvariables:\n  broadcaster_id: 1234\n"), 0644))
+	env, err := LoadCollectionEnvironment(path)
+	a.Nil(err)
+	a.Equal("mock", env.Target)
+	a.Equal("foo", env.AsUser)
+	a.Equal(1234, env.Variables["broadcaster_id"])
+}
@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the results of a collection run as JUnit XML, with one test case per request. Failed
+// assertions are failures, requests that couldn't be made are errors, and the response body of either is included.
+func WriteJUnitReport(w io.Writer, name string, started time.Time, results []CollectionResult) error {
+	suite := junitTestSuite{
+		Name:      name,
+		Tests:     len(results),
+		Timestamp: started.UTC().Format("2006-01-02T15:04:05"),
+	}
+
+	var total time.Duration
+	for _, r := range results {
+		total += r.Duration
+		c := junitTestCase{
+			Name:      r.Name,
+			ClassName: name,
+			Time:      junitSeconds(r.Duration),
+		}
+
+		switch {
+		case r.Skipped:
+			suite.Skipped++
+			c.Skipped = &junitMessage{Message: "Skipped after an earlier failure"}
+		case r.Error != "":
+			suite.Errors++
+			c.Error = &junitMessage{Message: r.Error, Type: "error", Text: fmt.Sprintf("%v %v", r.Method, r.Path)}
+		case len(r.Failures) != 0:
+			suite.Failures++
+			c.Failure = &junitMessage{
+				Message: r.Failures[0],
+				Type:    "assertion",
+				Text:    fmt.Sprintf("%v %v\n%v\n\n%v", r.Method, r.Path, strings.Join(r.Failures, "\n"), string(r.Response)),
+			}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Time = junitSeconds(total)
+
+	report := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
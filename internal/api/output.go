@@ -0,0 +1,235 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/twitchdev/twitch-cli/internal/jq"
+)
+
+const (
+	OutputJSON  = "json"
+	OutputTable = "table"
+	OutputCSV   = "csv"
+	OutputTSV   = "tsv"
+	OutputJSONL = "jsonl"
+	OutputYAML  = "yaml"
+)
+
+var OutputFormats = []string{OutputJSON, OutputTable, OutputCSV, OutputTSV, OutputJSONL, OutputYAML}
+
+// OutputOptions controls how the data of an API response is printed.
+type OutputOptions struct {
+	// Format is one of OutputFormats. Empty is treated as JSON.
+	Format string
+	// Fields limits each item of data to the given fields, in order. Nested fields are separated with a dot, such as "pagination.cursor".
+	Fields []string
+	// Filter is a jq-style expression applied to data before the fields are selected. See jq.Compile for what's supported.
+	Filter string
+}
+
+// output is the compiled form of OutputOptions.
+type output struct {
+	OutputOptions
+	filter jq.Filter
+}
+
+func newOutput(o OutputOptions) (output, error) {
+	if o.Format == "" {
+		o.Format = OutputJSON
+	}
+
+	valid := false
+	for _, f := range OutputFormats {
+		if o.Format == f {
+			valid = true
+		}
+	}
+	if !valid {
+		return output{}, fmt.Errorf("Invalid output format %v. Valid formats: %v", o.Format, strings.Join(OutputFormats, ", "))
+	}
+
+	out := output{OutputOptions: o}
+	if o.Filter != "" {
+		f, err := jq.Compile(o.Filter)
+		if err != nil {
+			return output{}, err
+		}
+		out.filter = f
+	}
+	return out, nil
+}
+
+// streams returns whether pages are written as they arrive, rather than collected into a single response
+func (o output) streams() bool {
+	return o.Format == OutputJSONL
+}
+
+// transform applies the filter and field selection to data. Filters producing several results are collected into an array.
+func (o output) transform(data any) (any, error) {
+	if o.filter != nil {
+		results, err := o.filter(data)
+		if err != nil {
+			return nil, fmt.Errorf("Error applying filter: %v", err)
+		}
+		if len(results) == 1 {
+			data = results[0]
+		} else {
+			data = results
+		}
+	}
+
+	if len(o.Fields) == 0 {
+		return data, nil
+	}
+
+	switch d := data.(type) {
+	case []any:
+		selected := make([]any, 0, len(d))
+		for _, item := range d {
+			selected = append(selected, o.selectFields(item))
+		}
+		return selected, nil
+	default:
+		return o.selectFields(d), nil
+	}
+}
+
+func (o output) selectFields(item any) any {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return item
+	}
+	selected := map[string]any{}
+	for _, f := range o.Fields {
+		selected[f] = lookupField(m, f)
+	}
+	return selected
+}
+
+func lookupField(m map[string]any, path string) any {
+	var v any = m
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+// rows returns data as a list of rows, treating anything other than an array as a single row
+func rows(data any) []any {
+	switch d := data.(type) {
+	case nil:
+		return []any{}
+	case []any:
+		return d
+	}
+	return []any{data}
+}
+
+// columns returns the fields if given, or otherwise every key used by the rows, sorted
+func (o output) columns(r []any) []string {
+	if len(o.Fields) != 0 {
+		return o.Fields
+	}
+	seen := map[string]any{}
+	for _, row := range r {
+		if m, ok := row.(map[string]any); ok {
+			for k := range m {
+				seen[k] = nil
+			}
+		}
+	}
+	if len(seen) == 0 {
+		// rows of plain values, such as the result of .[] | .id
+		return []string{"value"}
+	}
+	return sortedKeys(seen)
+}
+
+// cell formats a value for the table, CSV and TSV formats. Strings are printed as is, while objects and arrays are printed as JSON.
+func cell(row any, column string) string {
+	v := row
+	if m, ok := row.(map[string]any); ok {
+		v = m[column]
+	}
+
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// writeRows writes the rows of data in a row based format: table, CSV, TSV or JSON Lines.
+func (o output) writeRows(w io.Writer, data any) error {
+	r := rows(data)
+
+	switch o.Format {
+	case OutputJSONL:
+		for _, row := range r {
+			b, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w, string(b))
+		}
+		return nil
+	case OutputCSV, OutputTSV:
+		cw := csv.NewWriter(w)
+		if o.Format == OutputTSV {
+			cw.Comma = '\t'
+		}
+		columns := o.columns(r)
+		cw.Write(columns)
+		for _, row := range r {
+			record := make([]string, len(columns))
+			for i, c := range columns {
+				record[i] = cell(row, c)
+			}
+			cw.Write(record)
+		}
+		cw.Flush()
+		return cw.Error()
+	case OutputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		columns := o.columns(r)
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = strings.ToUpper(c)
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range r {
+			record := make([]string, len(columns))
+			for i, c := range columns {
+				// tabs and newlines would break the alignment
+				record[i] = strings.NewReplacer("\t", " ", "\n", " ", "\r", "").Replace(cell(row, c))
+			}
+			fmt.Fprintln(tw, strings.Join(record, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("Output format %v is not row based", o.Format)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestOutput(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	_, err := newOutput(OutputOptions{Format: "potato"})
+	a.NotNil(err)
+	_, err = newOutput(OutputOptions{Filter: ".["})
+	a.NotNil(err)
+
+	var data any
+	json.Unmarshal([]byte(`[
+		{"id":"1","login":"a","display_name":"A","broadcaster":{"id":"9"}},
+		{"id":"2","login":"b, \"the second\"","display_name":"B\tee","broadcaster":{"id":"8"}}
+	]`), &data)
+
+	write := func(o OutputOptions) string {
+		out, err := newOutput(o)
+		a.Nil(err)
+		transformed, err := out.transform(data)
+		a.Nil(err)
+
+		var b bytes.Buffer
+		a.Nil(out.writeRows(&b, transformed))
+		return b.String()
+	}
+
+	a.Equal("id,login,broadcaster.id\n1,a,9\n2,\"b, \"\"the second\"\"\",8\n", write(OutputOptions{Format: OutputCSV, Fields: []string{"id", "login", "broadcaster.id"}}))
+	a.Equal("display_name\tid\n\"B\tee\"\t2\n", write(OutputOptions{Format: OutputTSV, Fields: []string{"display_name", "id"}, Filter: `.[] | select(.id == "2")`}))
+	a.Equal("ID  LOGIN\n1   a\n2   b, \"the second\"\n", write(OutputOptions{Format: OutputTable, Fields: []string{"id", "login"}}))
+	a.Equal("{\"id\":\"1\"}\n{\"id\":\"2\"}\n", write(OutputOptions{Format: OutputJSONL, Fields: []string{"id"}}))
+
+	// without fields, every key is a column, with objects printed as JSON
+	a.Equal("broadcaster,display_name,id,login\n\"{\"\"id\"\":\"\"9\"\"}\",A,1,a\n", write(OutputOptions{Format: OutputCSV, Filter: ".[0]"}))
+
+	// plain values get a single column
+	a.Equal("value\n1\n2\n", write(OutputOptions{Format: OutputCSV, Filter: ".[].id"}))
+}
@@ -0,0 +1,470 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package spec
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The specification is written in a subset of OpenAPI 3, so it can also be used with other tools.
+// Supported fields are paths, operationId, summary, description, query parameters, JSON request bodies, the JSON body of
+// the successful response and the scopes of the twitch_auth security requirement. Schemas support type, description,
+// properties, required, items, enum, nullable, example, and $ref to components/schemas.
+//
+//go:embed helix.json
+var helixSpec []byte
+
+// Endpoint is a single method of a Helix endpoint.
+type Endpoint struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Parameters  []Parameter
+	// Body is the schema of the JSON body, or nil when the endpoint doesn't take one
+	Body   *Schema
+	Scopes []string
+	// Status is the status code of a successful response, or 0 when the specification doesn't give one
+	Status int
+	// Response is the schema of the successful response's JSON body, or nil when it has none
+	Response *Schema
+}
+
+// Parameter is a query parameter of an endpoint.
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Schema      Schema `json:"schema"`
+}
+
+// Schema describes a parameter or body, or a part of one.
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	// Example is a valid value, used when requests are generated from the specification
+	Example interface{} `json:"example,omitempty"`
+}
+
+type document struct {
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Parameters  []Parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+	Security []map[string][]string `json:"security"`
+}
+
+var (
+	endpoints []Endpoint
+	loadErr   error
+	loadOnce  sync.Once
+)
+
+// Endpoints returns every endpoint in the specification, sorted by path and method.
+func Endpoints() ([]Endpoint, error) {
+	loadOnce.Do(func() {
+		endpoints, loadErr = Parse(helixSpec)
+	})
+	return endpoints, loadErr
+}
+
+// Find returns the endpoint for the given method and path, such as GET and /users.
+func Find(method string, path string) (Endpoint, bool) {
+	all, err := Endpoints()
+	if err != nil {
+		return Endpoint{}, false
+	}
+	for _, e := range all {
+		if e.Method == strings.ToUpper(method) && e.Path == path {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// Parse reads the endpoints of a specification in the same format as the embedded one.
+func Parse(b []byte) ([]Endpoint, error) {
+	var doc document
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("Error parsing the API specification: %v", err)
+	}
+
+	var all []Endpoint
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			e := Endpoint{
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Description: op.Description,
+			}
+			for _, p := range op.Parameters {
+				if p.In != "query" {
+					return nil, fmt.Errorf("Error parsing the API specification: %v %v parameter %v must be in the query", e.Method, path, p.Name)
+				}
+				if err := doc.resolve(&p.Schema, 0); err != nil {
+					return nil, err
+				}
+				e.Parameters = append(e.Parameters, p)
+			}
+			if op.RequestBody != nil {
+				content, ok := op.RequestBody.Content["application/json"]
+				if !ok {
+					return nil, fmt.Errorf("Error parsing the API specification: %v %v body must be application/json", e.Method, path)
+				}
+				body := content.Schema
+				if err := doc.resolve(&body, 0); err != nil {
+					return nil, err
+				}
+				e.Body = &body
+			}
+			for code, response := range op.Responses {
+				// the lowest 2xx response is the successful one
+				status, err := strconv.Atoi(code)
+				if err != nil || status < 200 || status > 299 || (e.Status != 0 && e.Status < status) {
+					continue
+				}
+				e.Status = status
+				e.Response = nil
+				if content, ok := response.Content["application/json"]; ok {
+					schema := content.Schema
+					if err := doc.resolve(&schema, 0); err != nil {
+						return nil, err
+					}
+					e.Response = &schema
+				}
+			}
+			for _, requirement := range op.Security {
+				e.Scopes = append(e.Scopes, requirement["twitch_auth"]...)
+			}
+			all = append(all, e)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Path != all[j].Path {
+			return all[i].Path < all[j].Path
+		}
+		return all[i].Method < all[j].Method
+	})
+	return all, nil
+}
+
+// maxRefDepth stops schemas that refer to themselves
+const maxRefDepth = 32
+
+// resolve replaces each $ref in a schema with the schema it refers to
+func (d document) resolve(s *Schema, depth int) error {
+	if depth > maxRefDepth {
+		return fmt.Errorf("Error parsing the API specification: $ref %v is nested too deeply", s.Ref)
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target, found := d.Components.Schemas[name]
+		if !ok || !found {
+			return fmt.Errorf("Error parsing the API specification: unknown $ref %v", s.Ref)
+		}
+		// a description or nullable next to the $ref is kept
+		description, nullable := s.Description, s.Nullable
+		*s = *target
+		if description != "" {
+			s.Description = description
+		}
+		s.Nullable = s.Nullable || nullable
+		return d.resolve(s, depth+1)
+	}
+
+	if s.Properties != nil {
+		properties := make(map[string]*Schema, len(s.Properties))
+		for k, child := range s.Properties {
+			c := *child
+			if err := d.resolve(&c, depth+1); err != nil {
+				return err
+			}
+			properties[k] = &c
+		}
+		s.Properties = properties
+	}
+	if s.Items != nil {
+		items := *s.Items
+		if err := d.resolve(&items, depth+1); err != nil {
+			return err
+		}
+		s.Items = &items
+	}
+	return nil
+}
+
+// ValidateQuery checks the required parameters are given, and that values match their type and enum.
+// Parameters that aren't in the specification are allowed, since it may be behind the API.
+func (e Endpoint) ValidateQuery(q url.Values) error {
+	for _, p := range e.Parameters {
+		values := q[p.Name]
+		if len(values) == 0 {
+			if p.Required {
+				return fmt.Errorf("Missing required parameter %v", p.Name)
+			}
+			continue
+		}
+
+		s := p.Schema
+		if s.Type == "array" && s.Items != nil {
+			s = *s.Items
+		} else if len(values) > 1 {
+			return fmt.Errorf("Parameter %v can only be given once", p.Name)
+		}
+
+		for _, v := range values {
+			if err := s.validateString(p.Name, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateString checks a query parameter value matches the schema
+func (s Schema) validateString(name string, v string) error {
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("Parameter %v must be an integer", name)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return fmt.Errorf("Parameter %v must be a number", name)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("Parameter %v must be true or false", name)
+		}
+	}
+	if len(s.Enum) != 0 && !contains(s.EnumStrings(), v) {
+		return fmt.Errorf("Invalid value %v for %v. Valid values: %v", v, name, strings.Join(s.EnumStrings(), ", "))
+	}
+	return nil
+}
+
+// ValidateBody checks the body is JSON matching the endpoint's body schema.
+func (e Endpoint) ValidateBody(body []byte) error {
+	if e.Body == nil {
+		return nil
+	}
+	if len(body) == 0 {
+		return fmt.Errorf("A body is required. See --scaffold for a template.")
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Errorf("Body is not valid JSON: %v", err)
+	}
+	return e.Body.validate("body", v)
+}
+
+// ValidateResponse checks a response has the status and JSON body of the specification, returning every difference.
+// Unlike request bodies, fields that aren't in the specification are differences too, since they'd be misnamed or missing
+// from the API.
+func (e Endpoint) ValidateResponse(status int, body []byte) []string {
+	var problems []string
+	switch {
+	case e.Status != 0 && status != e.Status:
+		problems = append(problems, fmt.Sprintf("Expected status %v, got %v", e.Status, status))
+	case e.Status == 0 && (status < 200 || status > 299):
+		problems = append(problems, fmt.Sprintf("Expected a 2xx status, got %v", status))
+	}
+	if len(problems) != 0 {
+		return problems
+	}
+
+	if e.Response == nil {
+		if len(bytes.TrimSpace(body)) != 0 {
+			problems = append(problems, "Expected no body")
+		}
+		return problems
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return append(problems, fmt.Sprintf("Body is not valid JSON: %v", err))
+	}
+	return e.Response.check("body", v, true)
+}
+
+func (s Schema) validate(path string, v interface{}) error {
+	if problems := s.check(path, v, false); len(problems) != 0 {
+		return errors.New(problems[0])
+	}
+	return nil
+}
+
+// check returns every way v doesn't match the schema. When strict, fields that aren't in the schema are reported too.
+func (s Schema) check(path string, v interface{}, strict bool) []string {
+	if v == nil && s.Nullable {
+		return nil
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%v must be an object", path)}
+		}
+		var problems []string
+		for _, r := range s.Required {
+			if _, ok := obj[r]; !ok {
+				problems = append(problems, fmt.Sprintf("%v is missing required field %v", path, r))
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child, ok := s.Properties[k]
+			if !ok {
+				if strict && s.Properties != nil {
+					problems = append(problems, fmt.Sprintf("%v has unexpected field %v", path, k))
+				}
+				continue
+			}
+			problems = append(problems, child.check(path+"."+k, obj[k], strict)...)
+		}
+		return problems
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%v must be an array", path)}
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				if problems := s.Items.check(fmt.Sprintf("%v[%v]", path, i), item, strict); len(problems) != 0 {
+					// the other items almost always have the same problems
+					return problems
+				}
+			}
+		}
+		return nil
+	case "string":
+		if _, ok := v.(string); !ok {
+			return []string{fmt.Sprintf("%v must be a string", path)}
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return []string{fmt.Sprintf("%v must be an integer", path)}
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return []string{fmt.Sprintf("%v must be a number", path)}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []string{fmt.Sprintf("%v must be true or false", path)}
+		}
+	}
+
+	if len(s.Enum) != 0 {
+		b, _ := json.Marshal(v)
+		var value string
+		if err := json.Unmarshal(b, &value); err != nil {
+			value = string(b)
+		}
+		if !contains(s.EnumStrings(), value) {
+			return []string{fmt.Sprintf("Invalid value %v for %v. Valid values: %v", value, path, strings.Join(s.EnumStrings(), ", "))}
+		}
+	}
+	return nil
+}
+
+// ScaffoldBody returns an indented JSON template of the endpoint's body, with every field set to an empty value of its
+// type, or the first value of its enum.
+func (e Endpoint) ScaffoldBody() ([]byte, error) {
+	if e.Body == nil {
+		return nil, fmt.Errorf("%v %v doesn't take a body", e.Method, e.Path)
+	}
+	return json.MarshalIndent(e.Body.scaffold(), "", "  ")
+}
+
+func (s Schema) scaffold() interface{} {
+	if len(s.Enum) != 0 {
+		return s.Enum[0]
+	}
+	switch s.Type {
+	case "object":
+		obj := map[string]interface{}{}
+		for k, child := range s.Properties {
+			obj[k] = child.scaffold()
+		}
+		return obj
+	case "array":
+		if s.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{s.Items.scaffold()}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+	return ""
+}
+
+// EnumStrings returns the enum values as strings, as they'd be given on the command line.
+func (s Schema) EnumStrings() []string {
+	values := make([]string, len(s.Enum))
+	for i, v := range s.Enum {
+		switch value := v.(type) {
+		case string:
+			values[i] = value
+		default:
+			b, _ := json.Marshal(value)
+			values[i] = string(b)
+		}
+	}
+	return values
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package spec
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestEndpoints(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	endpoints, err := Endpoints()
+	a.Nil(err)
+	a.NotEmpty(endpoints)
+
+	for _, e := range endpoints {
+		a.NotEmpty(e.OperationID, "%v %v", e.Method, e.Path)
+		a.NotEmpty(e.Summary, "%v %v", e.Method, e.Path)
+		for _, p := range e.Parameters {
+			a.NotEmpty(p.Description, "%v %v %v", e.Method, e.Path, p.Name)
+		}
+		if e.Body != nil {
+			_, err := e.ScaffoldBody()
+			a.Nil(err)
+		}
+	}
+
+	e, ok := Find("get", "/channels/followers")
+	a.True(ok)
+	a.Equal([]string{"moderator:read:followers"}, e.Scopes)
+
+	_, ok = Find("POST", "/users")
+	a.False(ok)
+
+	_, err = Parse([]byte(`{"paths":{"/users":{"get":{"parameters":[{"name":"id","in":"path"}]}}}}`))
+	a.NotNil(err)
+}
+
+func TestValidateQuery(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e, ok := Find("GET", "/videos")
+	a.True(ok)
+
+	a.Nil(e.ValidateQuery(url.Values{"id": {"1", "2"}, "sort": {"views"}, "first": {"20"}, "unknown": {"x"}}))
+	a.NotNil(e.ValidateQuery(url.Values{"sort": {"potato"}}))
+	a.NotNil(e.ValidateQuery(url.Values{"first": {"potato"}}))
+	a.NotNil(e.ValidateQuery(url.Values{"sort": {"views", "time"}}))
+
+	e, ok = Find("GET", "/search/channels")
+	a.True(ok)
+	a.NotNil(e.ValidateQuery(url.Values{}))
+	a.NotNil(e.ValidateQuery(url.Values{"query": {"a"}, "live_only": {"potato"}}))
+	a.Nil(e.ValidateQuery(url.Values{"query": {"a"}, "live_only": {"true"}}))
+}
+
+func TestBody(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e, ok := Find("POST", "/polls")
+	a.True(ok)
+
+	b, err := e.ScaffoldBody()
+	a.Nil(err)
+	var scaffold map[string]interface{}
+	a.Nil(json.Unmarshal(b, &scaffold))
+	a.Equal([]interface{}{map[string]interface{}{"title": ""}}, scaffold["choices"])
+	a.Equal(float64(0), scaffold["duration"])
+
+	a.Nil(e.ValidateBody([]byte(`{"broadcaster_id":"1","title":"Best?","choices":[{"title":"a"},{"title":"b"}],"duration":60}`)))
+	a.NotNil(e.ValidateBody(nil))
+	a.NotNil(e.ValidateBody([]byte(`potato`)))
+	a.NotNil(e.ValidateBody([]byte(`{"broadcaster_id":"1","title":"Best?","choices":[{}],"duration":60}`)))
+	a.NotNil(e.ValidateBody([]byte(`{"broadcaster_id":"1","title":"Best?","choices":[{"title":"a"}],"duration":1.5}`)))
+	a.NotNil(e.ValidateBody([]byte(`{"broadcaster_id":1,"title":"Best?","choices":[{"title":"a"}],"duration":60}`)))
+
+	e, ok = Find("PATCH", "/chat/settings")
+	a.True(ok)
+	a.Nil(e.ValidateBody([]byte(`{"non_moderator_chat_delay_duration":4}`)))
+	a.NotNil(e.ValidateBody([]byte(`{"non_moderator_chat_delay_duration":5}`)))
+
+	e, ok = Find("GET", "/users")
+	a.True(ok)
+	a.Nil(e.ValidateBody(nil))
+	_, err = e.ScaffoldBody()
+	a.NotNil(err)
+}
+
+func TestValidateResponse(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e, ok := Find("GET", "/polls")
+	a.True(ok)
+	a.Equal(200, e.Status)
+
+	poll := `{"id":"1","broadcaster_id":"1","broadcaster_name":"a","broadcaster_login":"a","title":"Best?","choices":[],
+		"bits_voting_enabled":false,"bits_per_vote":0,"channel_points_voting_enabled":false,"channel_points_per_vote":0,
+		"status":"ACTIVE","duration":60,"started_at":"2023-01-01T00:00:00Z","ended_at":null}`
+	a.Empty(e.ValidateResponse(200, []byte(`{"data":[`+poll+`],"pagination":{}}`)))
+	a.Equal([]string{"Expected status 200, got 400"}, e.ValidateResponse(400, []byte(`{}`)))
+	a.Equal([]string{"body is missing required field pagination"}, e.ValidateResponse(200, []byte(`{"data":[]}`)))
+	a.Equal([]string{"body has unexpected field total"}, e.ValidateResponse(200, []byte(`{"data":[],"pagination":{},"total":1}`)))
+	a.Equal([]string{"body.data[0].duration must be an integer", "body.data[0].title must be a string"},
+		e.ValidateResponse(200, []byte(`{"data":[`+strings.Replace(strings.Replace(poll, `"Best?"`, "null", 1), "60", `"60"`, 1)+`],"pagination":{}}`)))
+
+	e, ok = Find("DELETE", "/raids")
+	a.True(ok)
+	a.Equal(204, e.Status)
+	a.Empty(e.ValidateResponse(204, nil))
+	a.Equal([]string{"Expected no body"}, e.ValidateResponse(204, []byte(`{}`)))
+
+	_, err := Parse([]byte(`{"paths":{"/users":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}}`))
+	a.NotNil(err)
+}
@@ -0,0 +1,233 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_server"
+	"github.com/twitchdev/twitch-cli/internal/mock_auth"
+	"github.com/twitchdev/twitch-cli/internal/request"
+)
+
+const (
+	TargetProduction = "production"
+	TargetMock       = "mock"
+)
+
+// DefaultMockPort is the port of `twitch mock-api start`, used when the port of the running server isn't known
+const DefaultMockPort = 8080
+
+const productionURL = "https://api.twitch.tv/helix"
+
+// mockHost is overridden by tests
+var mockHost = "http://localhost"
+
+// TargetParameters chooses the API requests are sent to.
+type TargetParameters struct {
+	// Target is production, mock or mock:<port>. Empty keeps the configured BASE_URL and credentials.
+	Target string
+	// AsUser is the login of the mock user to authorize as. Without it, the mock is called with an app access token.
+	AsUser string
+	// Scopes are requested for the user's authorization
+	Scopes []string
+}
+
+// UseTarget points the API requests of this run at the target. For the mock, it finds the running server and
+// authorizes with a client from the mock's database, so no configuration is needed. The configuration file isn't changed.
+func UseTarget(p TargetParameters) error {
+	if p.Target == "" || p.Target == TargetProduction {
+		if p.AsUser != "" {
+			return fmt.Errorf("--as-user can only be used with --target mock")
+		}
+		if p.Target == TargetProduction {
+			viper.Set("BASE_URL", productionURL)
+		}
+		return nil
+	}
+
+	port, err := parseMockTarget(p.Target)
+	if err != nil {
+		return err
+	}
+	base := fmt.Sprintf("%v:%v", mockHost, port)
+
+	client, err := mockClient(base)
+	if err != nil {
+		return err
+	}
+
+	var token string
+	if p.AsUser != "" {
+		userID, err := mockUserID(base, p.AsUser)
+		if err != nil {
+			return err
+		}
+		token, err = mockToken(base+mock_server.AUTH_NAMESPACE+"/authorize", url.Values{
+			"client_id":     {client.ID},
+			"client_secret": {client.Secret},
+			"grant_type":    {"user_token"},
+			"user_id":       {userID},
+			"scope":         {strings.Join(p.Scopes, " ")},
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		token, err = mockToken(base+mock_server.AUTH_NAMESPACE+"/token", url.Values{
+			"client_id":     {client.ID},
+			"client_secret": {client.Secret},
+			"grant_type":    {"client_credentials"},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	viper.Set("BASE_URL", base+mock_server.MOCK_NAMESPACE)
+	viper.Set("clientid", client.ID)
+	viper.Set("accesstoken", token)
+	// mock tokens aren't refreshed
+	viper.Set("tokenexpiration", "0")
+	return nil
+}
+
+// parseMockTarget returns the port of mock or mock:<port>. Without a port, it's the port of the last server started
+// with `twitch mock-api start`, or DefaultMockPort.
+func parseMockTarget(target string) (int, error) {
+	name, portStr, hasPort := strings.Cut(target, ":")
+	if name != TargetMock {
+		return 0, fmt.Errorf("Invalid target %v. Valid targets: %v, %v, %v:<port>", target, TargetProduction, TargetMock, TargetMock)
+	}
+	if hasPort {
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return 0, fmt.Errorf("Invalid port in target %v", target)
+		}
+		return port, nil
+	}
+	if port, ok := mock_server.RunningPort(); ok {
+		return port, nil
+	}
+	return DefaultMockPort, nil
+}
+
+// MockScopes returns the scopes the mock API requires of a user token for a request, any one of which is enough.
+// Without a method, it returns every scope the mock accepts, for requests that aren't known in advance.
+func MockScopes(method string, path string) []string {
+	seen := map[string]bool{}
+	for _, e := range endpoints.All() {
+		if method != "" && (e.Path() != path || !e.ValidMethod(strings.ToUpper(method))) {
+			continue
+		}
+		methods := []string{strings.ToUpper(method)}
+		if method == "" {
+			methods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete}
+		}
+		for _, m := range methods {
+			for _, s := range e.GetRequiredScopes(m) {
+				if mock_auth.IsValidScope(s, mock_auth.USER_ACCESS_TOKEN) {
+					seen[s] = true
+				}
+			}
+		}
+	}
+
+	scopes := make([]string, 0, len(seen))
+	for s := range seen {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+type mockAuthClient struct {
+	ID          string
+	Secret      string
+	IsExtension bool
+}
+
+// mockClient returns the first client in the mock's database, creating one if there are none. Extensions are skipped,
+// since their secrets are for signing JWTs.
+func mockClient(base string) (mockAuthClient, error) {
+	var clients struct {
+		Data []mockAuthClient `json:"data"`
+	}
+	if err := mockRequest(http.MethodGet, base+"/units/clients", &clients); err != nil {
+		return mockAuthClient{}, err
+	}
+	for _, c := range clients.Data {
+		if !c.IsExtension {
+			return c, nil
+		}
+	}
+
+	if err := mockRequest(http.MethodPost, base+"/units/clients?name=twitch+api", &clients); err != nil {
+		return mockAuthClient{}, err
+	}
+	if len(clients.Data) == 0 {
+		return mockAuthClient{}, fmt.Errorf("Unable to create a mock API client")
+	}
+	return clients.Data[0], nil
+}
+
+func mockUserID(base string, login string) (string, error) {
+	var users struct {
+		Data []struct {
+			ID    string `json:"id"`
+			Login string `json:"login"`
+		} `json:"data"`
+	}
+	// the mock's logins are lowercase
+	query := url.Values{"login": {strings.ToLower(login)}}
+	if err := mockRequest(http.MethodGet, base+"/units/users?"+query.Encode(), &users); err != nil {
+		return "", err
+	}
+	for _, u := range users.Data {
+		if strings.EqualFold(u.Login, login) {
+			return u.ID, nil
+		}
+	}
+	return "", fmt.Errorf("No mock user has the login %v", login)
+}
+
+func mockRequest(method string, u string, v any) error {
+	req, err := request.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := request.NewClient(time.Second * 10).Do(req)
+	if err != nil {
+		return fmt.Errorf("Unable to reach the mock API. Is `twitch mock-api start` running? %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Error from the mock API: %v %v", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+func mockToken(u string, params url.Values) (string, error) {
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := mockRequest(http.MethodPost, u+"?"+params.Encode(), &token); err != nil {
+		return "", fmt.Errorf("Error authorizing with the mock API: %v", err)
+	}
+	return token.AccessToken, nil
+}
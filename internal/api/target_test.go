@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestUseTarget(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	defer viper.Set("BASE_URL", "")
+
+	clients := `{"data":[{"ID":"ext","Secret":"key","IsExtension":true}]}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /units/clients":
+			w.Write([]byte(clients))
+		case "POST /units/clients":
+			// only the extension exists, so a client is created
+			clients = `{"data":[{"ID":"client","Secret":"secret","IsExtension":false}]}`
+			w.Write([]byte(clients))
+		case "GET /units/users":
+			// users are looked up by login, since only the first 100 are listed without one
+			switch q.Get("login") {
+			case "one":
+				w.Write([]byte(`{"data":[{"id":"1","login":"one"}]}`))
+			case "two":
+				w.Write([]byte(`{"data":[{"id":"2","login":"two"}]}`))
+			default:
+				w.Write([]byte(`{"data":[]}`))
+			}
+		case "POST /auth/token":
+			a.Equal("client", q.Get("client_id"))
+			a.Equal("client_credentials", q.Get("grant_type"))
+			w.Write([]byte(`{"access_token":"app"}`))
+		case "POST /auth/authorize":
+			a.Equal("secret", q.Get("client_secret"))
+			a.Equal("2", q.Get("user_id"))
+			a.Equal("a b", q.Get("scope"))
+			w.Write([]byte(`{"access_token":"user"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	host, port, _ := strings.Cut(strings.TrimPrefix(ts.URL, "http://"), ":")
+	mockHost = "http://" + host
+	defer func() { mockHost = "http://localhost" }()
+
+	a.Nil(UseTarget(TargetParameters{Target: "mock:" + port}))
+	a.Equal(ts.URL+"/mock", viper.GetString("BASE_URL"))
+	a.Equal("client", viper.GetString("clientid"))
+	a.Equal("app", viper.GetString("accesstoken"))
+	a.Equal("0", viper.GetString("tokenexpiration"))
+
+	a.Nil(UseTarget(TargetParameters{Target: "mock:" + port, AsUser: "TWO", Scopes: []string{"a", "b"}}))
+	a.Equal("user", viper.GetString("accesstoken"))
+
+	err := UseTarget(TargetParameters{Target: "mock:" + port, AsUser: "three"})
+	a.Equal("No mock user has the login three", err.Error())
+
+	a.NotNil(UseTarget(TargetParameters{Target: "mock:notaport"}))
+	a.NotNil(UseTarget(TargetParameters{Target: "staging"}))
+	a.NotNil(UseTarget(TargetParameters{AsUser: "one"}))
+
+	a.Nil(UseTarget(TargetParameters{Target: TargetProduction}))
+	a.Equal(productionURL, viper.GetString("BASE_URL"))
+}
+
+func TestMockScopes(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal([]string{"user:read:subscriptions"}, MockScopes("get", "/subscriptions/user"))
+	a.Empty(MockScopes("get", "/users"))
+	a.Empty(MockScopes("get", "/not/an/endpoint"))
+
+	all := MockScopes("", "")
+	a.Contains(all, "user:read:subscriptions")
+	a.Contains(all, "moderator:read:chatters")
+	a.Greater(len(all), 10)
+}
@@ -0,0 +1,394 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/jq"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/request"
+)
+
+const (
+	WatchDiff  = "diff"
+	WatchPatch = "patch"
+)
+
+var WatchFormats = []string{WatchDiff, WatchPatch}
+
+// minWatchInterval keeps polling well within the rate limit, even with several watches running at once
+var minWatchInterval = time.Second
+
+// WatchParameters configures Watch.
+type WatchParameters struct {
+	Path            string
+	QueryParameters []string
+	Interval        time.Duration
+	// Key is the field items are matched by between polls, defaulting to id. Items without it are matched by position.
+	Key string
+	// Format is one of WatchFormats
+	Format string
+	// Output's filter and fields are applied to the data before it's compared
+	Output OutputOptions
+	// Until is a jq-style expression evaluated against the data of each poll. Watching stops once it's true.
+	Until string
+	// Exec is a shell command run after each change, given the JSON Patch on stdin and in TWITCH_WATCH_PATCH
+	Exec string
+	// Webhook is a URL sent a POST with the JSON Patch after each change
+	Webhook string
+	// Polls stops watching after this many polls, when greater than zero
+	Polls int
+}
+
+// PatchOperation is an RFC 6902 JSON Patch operation. The patch applies to an object of the watched items keyed by
+// their ID, so adding an item is an add of /<id>, and a changed field is a replace of /<id>/<field>.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+
+	// the value being replaced or removed, for the diff format
+	old any
+}
+
+// MarshalJSON keeps the value of add and replace operations even when it's null, and leaves it out of removes.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+type watchItems struct {
+	keys  []string
+	items map[string]any
+}
+
+// Watch polls a GET endpoint every interval until ctx is done, writing the items that were added, removed or changed
+// since the previous poll. The first poll is written in full as additions, without running the Exec or Webhook hooks.
+func Watch(ctx context.Context, w io.Writer, p WatchParameters) error {
+	client, err := GetClientInformation()
+	if err != nil {
+		return fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+
+	if p.Interval < minWatchInterval {
+		return fmt.Errorf("Invalid interval provided. Must be at least %v.", minWatchInterval)
+	}
+	if p.Key == "" {
+		p.Key = "id"
+	}
+	if p.Format == "" {
+		p.Format = WatchDiff
+	}
+	if p.Format != WatchDiff && p.Format != WatchPatch {
+		return fmt.Errorf("Invalid watch format %v. Valid formats: %v", p.Format, strings.Join(WatchFormats, ", "))
+	}
+
+	out, err := newOutput(p.Output)
+	if err != nil {
+		return err
+	}
+	var until jq.Filter
+	if p.Until != "" {
+		until, err = jq.Compile(p.Until)
+		if err != nil {
+			return err
+		}
+	}
+
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+	u, err := url.Parse(baseURL + p.Path)
+	if err != nil {
+		return fmt.Errorf("Error getting url: %v", err)
+	}
+	q := u.Query()
+	addQueryParameters(q, p.QueryParameters)
+	u.RawQuery = q.Encode()
+
+	var previous *watchItems
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for poll := 1; ; poll++ {
+		data, err := watchPoll(*u, client)
+		if err != nil {
+			return err
+		}
+
+		if data != nil {
+			data, err = out.transform(data)
+			if err != nil {
+				return err
+			}
+
+			current := keyItems(rows(data), p.Key)
+			var ops []PatchOperation
+			if previous == nil {
+				ops = diffItems(&watchItems{items: map[string]any{}}, current)
+			} else {
+				ops = diffItems(previous, current)
+			}
+
+			if len(ops) != 0 {
+				if err := writeWatchChanges(w, p.Format, ops); err != nil {
+					return err
+				}
+				if previous != nil {
+					runWatchHooks(ctx, p, ops)
+				}
+			}
+			previous = current
+
+			if until != nil {
+				results, err := until(data)
+				if err != nil {
+					return fmt.Errorf("Error evaluating --until: %v", err)
+				}
+				for _, r := range results {
+					if jq.Truthy(r) {
+						return nil
+					}
+				}
+			}
+		}
+
+		if p.Polls > 0 && poll >= p.Polls {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchPoll fetches every page of the endpoint through the shared rate limited client, following the cursor the same way
+// as --autopaginate. Rate limits and server errors that outlast the client's retries are logged and skip the whole poll, so
+// a long running watch survives them without reporting the items on the missing pages as removed, while other errors stop it.
+func watchPoll(u url.URL, client clientInformation) (any, error) {
+	items := []any{}
+	cursor := ""
+	for {
+		if cursor != "" {
+			q := u.Query()
+			q.Set("after", cursor)
+			u.RawQuery = q.Encode()
+		}
+
+		resp, err := apiRequest(http.MethodGet, u.String(), nil, apiRequestParameters{
+			ClientID: client.ClientID,
+			Token:    client.Token,
+		})
+		if err != nil {
+			log.Printf("Error polling: %v", err)
+			return nil, nil
+		}
+
+		var apiResponse models.APIResponse
+		if len(resp.Body) != 0 {
+			if err := json.Unmarshal(resp.Body, &apiResponse); err != nil {
+				return nil, fmt.Errorf("Error unmarshalling body: %v", err)
+			}
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			log.Printf("Error polling: %v %v", resp.StatusCode, apiResponse.Message)
+			return nil, nil
+		}
+		if resp.StatusCode > 299 || resp.StatusCode < 200 {
+			return nil, fmt.Errorf("Error polling: %v %v", resp.StatusCode, apiResponse.Message)
+		}
+
+		if apiResponse.Data == nil {
+			return items, nil
+		}
+		page, ok := apiResponse.Data.([]any)
+		if !ok {
+			// data that isn't a list, such as a schedule, is watched as the first page only
+			return apiResponse.Data, nil
+		}
+		items = append(items, page...)
+
+		if apiResponse.Pagination == nil || apiResponse.Pagination.Cursor == "" || apiResponse.Pagination.Cursor == cursor {
+			return items, nil
+		}
+		cursor = apiResponse.Pagination.Cursor
+	}
+}
+
+// keyItems indexes items by key, falling back to their position for items without one
+func keyItems(items []any, key string) *watchItems {
+	w := &watchItems{items: map[string]any{}}
+	for i, item := range items {
+		k := ""
+		if m, ok := item.(map[string]any); ok {
+			k = cell(m, key)
+		}
+		if k == "" {
+			k = fmt.Sprintf("#%v", i)
+		}
+		if _, ok := w.items[k]; ok {
+			// duplicate keys would hide each other, so the later one is kept by position
+			k = fmt.Sprintf("%v#%v", k, i)
+		}
+		w.keys = append(w.keys, k)
+		w.items[k] = item
+	}
+	return w
+}
+
+// diffItems returns the JSON Patch from previous to current, in the order of current followed by any removed items
+func diffItems(previous *watchItems, current *watchItems) []PatchOperation {
+	var ops []PatchOperation
+	for _, k := range current.keys {
+		item := current.items[k]
+		old, ok := previous.items[k]
+		if !ok {
+			ops = append(ops, PatchOperation{Op: "add", Path: "/" + escapePointer(k), Value: item})
+			continue
+		}
+		ops = append(ops, diffItem("/"+escapePointer(k), old, item)...)
+	}
+	for _, k := range previous.keys {
+		if _, ok := current.items[k]; !ok {
+			ops = append(ops, PatchOperation{Op: "remove", Path: "/" + escapePointer(k), old: previous.items[k]})
+		}
+	}
+	return ops
+}
+
+// diffItem compares the fields of an item, or replaces it whole when it isn't an object
+func diffItem(path string, old any, item any) []PatchOperation {
+	if reflect.DeepEqual(old, item) {
+		return nil
+	}
+	oldMap, ok1 := old.(map[string]any)
+	newMap, ok2 := item.(map[string]any)
+	if !ok1 || !ok2 {
+		return []PatchOperation{{Op: "replace", Path: path, Value: item, old: old}}
+	}
+
+	var ops []PatchOperation
+	for _, f := range sortedKeys(newMap) {
+		v, ok := oldMap[f]
+		switch {
+		case !ok:
+			ops = append(ops, PatchOperation{Op: "add", Path: path + "/" + escapePointer(f), Value: newMap[f]})
+		case !reflect.DeepEqual(v, newMap[f]):
+			ops = append(ops, PatchOperation{Op: "replace", Path: path + "/" + escapePointer(f), Value: newMap[f], old: v})
+		}
+	}
+	for _, f := range sortedKeys(oldMap) {
+		if _, ok := newMap[f]; !ok {
+			ops = append(ops, PatchOperation{Op: "remove", Path: path + "/" + escapePointer(f), old: oldMap[f]})
+		}
+	}
+	return ops
+}
+
+// escapePointer escapes a JSON Pointer reference token, per RFC 6901
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func writeWatchChanges(w io.Writer, format string, ops []PatchOperation) error {
+	if format == WatchPatch {
+		b, err := json.Marshal(ops)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	fmt.Fprintf(w, "%v\n", time.Now().Format(time.TimeOnly))
+	for _, op := range ops {
+		path := strings.TrimPrefix(op.Path, "/")
+		switch op.Op {
+		case "add":
+			color.New(color.FgGreen).Fprintf(w, "+ %v: %v\n", path, jsonString(op.Value))
+		case "remove":
+			color.New(color.FgRed).Fprintf(w, "- %v: %v\n", path, jsonString(op.old))
+		case "replace":
+			color.New(color.FgYellow).Fprintf(w, "~ %v: %v -> %v\n", path, jsonString(op.old), jsonString(op.Value))
+		}
+	}
+	return nil
+}
+
+func jsonString(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// runWatchHooks runs the command and sends the webhook for a change. Failures are logged, so watching continues.
+func runWatchHooks(ctx context.Context, p WatchParameters, ops []PatchOperation) {
+	patch, _ := json.Marshal(ops)
+
+	if p.Exec != "" {
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", p.Exec)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", p.Exec)
+		}
+		cmd.Stdin = bytes.NewReader(patch)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), "TWITCH_WATCH_PATCH="+string(patch))
+		if err := cmd.Run(); err != nil {
+			log.Printf("Error running --exec: %v", err)
+		}
+	}
+
+	if p.Webhook != "" {
+		body, _ := json.Marshal(map[string]any{
+			"path":      p.Path,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"patch":     json.RawMessage(patch),
+		})
+		req, err := request.NewRequest(http.MethodPost, p.Webhook, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Error sending --webhook: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := request.NewClient(time.Second * 10).Do(req.WithContext(ctx))
+		if err != nil {
+			log.Printf("Error sending --webhook: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode > 299 || resp.StatusCode < 200 {
+			log.Printf("Error sending --webhook: received status code %v", resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestWatch(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	minWatchInterval = time.Millisecond
+	color.NoColor = true
+
+	polls := []string{
+		`{"data":[{"id":"1","viewer_count":10},{"id":"2","viewer_count":5}]}`,
+		`{"data":[{"id":"1","viewer_count":10},{"id":"2","viewer_count":5}]}`,
+		`{"data":[{"id":"1","viewer_count":25},{"id":"3","viewer_count":1}]}`,
+		`{"data":[]}`,
+	}
+	poll := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("foo", r.URL.Query().Get("user_login"))
+		if poll == 1 {
+			// skipped, rather than treated as every item being removed
+			w.WriteHeader(http.StatusServiceUnavailable)
+			poll++
+			return
+		}
+		w.Write([]byte(polls[poll]))
+		poll++
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	hooks := 0
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Path  string           `json:"path"`
+			Patch []PatchOperation `json:"patch"`
+		}
+		a.Nil(json.NewDecoder(r.Body).Decode(&body))
+		a.Equal("/streams", body.Path)
+		hooks++
+	}))
+	defer webhook.Close()
+
+	// so the 503 reaches Watch, rather than being retried by the client
+	getClient().MaxRetries = 0
+	defer func() { getClient().MaxRetries = defaultMaxRetries }()
+	execFile := filepath.Join(t.TempDir(), "exec")
+	var out bytes.Buffer
+	err := Watch(context.Background(), &out, WatchParameters{
+		Path:            "/streams",
+		QueryParameters: []string{"user_login=foo"},
+		Interval:        time.Millisecond,
+		Format:          WatchPatch,
+		Webhook:         webhook.URL,
+		Exec:            "cat >> " + execFile,
+		Until:           "length == 0",
+	})
+	a.Nil(err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	a.Len(lines, 3)
+	a.Equal(`[{"op":"add","path":"/1","value":{"id":"1","viewer_count":10}},{"op":"add","path":"/2","value":{"id":"2","viewer_count":5}}]`, lines[0])
+	a.Equal(`[{"op":"replace","path":"/1/viewer_count","value":25},{"op":"add","path":"/3","value":{"id":"3","viewer_count":1}},{"op":"remove","path":"/2"}]`, lines[1])
+	a.Equal(`[{"op":"remove","path":"/1"},{"op":"remove","path":"/3"}]`, lines[2])
+	// the first poll doesn't run the hooks
+	a.Equal(2, hooks)
+	execOut, err := os.ReadFile(execFile)
+	a.Nil(err)
+	a.Equal(lines[1]+lines[2], string(execOut))
+
+	poll = 2
+	out.Reset()
+	err = Watch(context.Background(), &out, WatchParameters{Path: "/streams", QueryParameters: []string{"user_login=foo"}, Interval: time.Millisecond, Polls: 1})
+	a.Nil(err)
+	a.Contains(out.String(), `+ 1: {"id":"1","viewer_count":25}`)
+
+	err = Watch(context.Background(), &out, WatchParameters{Path: "/streams", Interval: time.Millisecond, Format: "xml"})
+	a.NotNil(err)
+}
+
+func TestWatchPages(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	minWatchInterval = time.Millisecond
+	color.NoColor = true
+
+	poll := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("foo", r.URL.Query().Get("game_id"))
+		switch r.URL.Query().Get("after") {
+		case "":
+			poll++
+			w.Write([]byte(`{"data":[{"id":"1","viewer_count":10}],"pagination":{"cursor":"page2"}}`))
+		case "page2":
+			if poll == 2 {
+				// a failed page skips the poll, rather than the items on it being treated as removed
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(fmt.Sprintf(`{"data":[{"id":"2","viewer_count":%v}],"pagination":{}}`, poll)))
+		}
+	}))
+	defer ts.Close()
+	viper.Set("BASE_URL", ts.URL)
+
+	getClient().MaxRetries = 0
+	defer func() { getClient().MaxRetries = defaultMaxRetries }()
+	var out bytes.Buffer
+	err := Watch(context.Background(), &out, WatchParameters{
+		Path:            "/streams",
+		QueryParameters: []string{"game_id=foo"},
+		Interval:        time.Millisecond,
+		Format:          WatchPatch,
+		Polls:           3,
+	})
+	a.Nil(err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	a.Len(lines, 2)
+	a.Equal(`[{"op":"add","path":"/1","value":{"id":"1","viewer_count":10}},{"op":"add","path":"/2","value":{"id":"2","viewer_count":1}}]`, lines[0])
+	a.Equal(`[{"op":"replace","path":"/2/viewer_count","value":3}]`, lines[1])
+}
+
+func TestDiffItems(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	previous := keyItems([]any{
+		map[string]any{"id": "a/b", "title": "old", "tags": []any{"x"}},
+		"plain",
+	}, "id")
+	current := keyItems([]any{
+		map[string]any{"id": "a/b", "title": "new", "tags": []any{"x"}, "game": "g"},
+		"changed",
+	}, "id")
+
+	ops := diffItems(previous, current)
+	b, _ := json.Marshal(ops)
+	a.Equal(`[{"op":"add","path":"/a~1b/game","value":"g"},{"op":"replace","path":"/a~1b/title","value":"new"},{"op":"replace","path":"/#1","value":"changed"}]`, string(b))
+	a.Empty(diffItems(current, current))
+}
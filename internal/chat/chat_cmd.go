@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package chat
+
+import (
+	"fmt"
+	"net/rpc"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/twitchdev/twitch-cli/internal/chat/mock_server"
+	rpc_handler "github.com/twitchdev/twitch-cli/internal/rpc"
+)
+
+type ChatCommandParameters struct {
+	Channel string
+	User    string
+	Message string
+	Tier    string
+	Viewers int
+}
+
+func ForwardChatCommand(cmd string, p ChatCommandParameters) error {
+	client, err := rpc.DialHTTP("tcp", fmt.Sprintf(":%v", mock_server.RPC_PORT))
+	if err != nil {
+		return fmt.Errorf("Failed to dial RPC handler for chat server. Is it online?\nError: %v", err.Error())
+	}
+
+	var reply rpc_handler.RPCResponse
+
+	rpcName := mock_server.ResolveRPCName(cmd)
+	if rpcName == "" {
+		return fmt.Errorf("Invalid chat command")
+	}
+
+	// Command line flags to be passed with the command
+	// Add them all, as it wont hurt anything if they're not relevant
+	variables := make(map[string]string)
+	variables["Channel"] = p.Channel
+	variables["User"] = p.User
+	variables["Message"] = p.Message
+	variables["Tier"] = p.Tier
+	variables["Viewers"] = strconv.Itoa(p.Viewers)
+
+	args := &rpc_handler.RPCArgs{
+		RPCName:   rpcName,
+		Variables: variables,
+	}
+
+	err = client.Call("RPCHandler.ExecuteGenericRPC", args, &reply)
+
+	if err != nil {
+		return fmt.Errorf("Failed to call RPC method RPCHandler.ExecuteGenericRPC: %v", err.Error())
+	}
+
+	switch reply.ResponseCode {
+	case mock_server.COMMAND_RESPONSE_SUCCESS:
+		color.New().Add(color.FgGreen).Println("✔ Injected into mock chat server")
+		return nil
+
+	case mock_server.COMMAND_RESPONSE_FAILED_ON_SERVER:
+		return fmt.Errorf(
+			color.New().Add(color.FgRed).Sprintln(fmt.Sprintf("✗ Chat server failed to process command:\n%v", reply.DetailedInfo)),
+		)
+
+	case mock_server.COMMAND_RESPONSE_MISSING_FLAG:
+		return fmt.Errorf(
+			color.New().Add(color.FgRed).Sprintln(fmt.Sprintf("✗ Command rejected for invalid flags:\n%v", reply.DetailedInfo)),
+		)
+
+	case mock_server.COMMAND_RESPONSE_INVALID_CMD:
+		return fmt.Errorf("Invalid chat sub-command: %v", cmd)
+
+	}
+
+	return fmt.Errorf("RPCHandler experienced unexpected response code: %v", reply.ResponseCode)
+}
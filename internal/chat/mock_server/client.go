@@ -0,0 +1,438 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"bufio"
+	"log"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	chat_endpoint "github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/chat"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Anonymous, read-only connections log in with a nick of justinfan followed by any number
+var anonymousNickRegex = regexp.MustCompile(`^justinfan[0-9]+$`)
+
+type Client struct {
+	clientName string
+	conn       net.Conn
+	muWrite    sync.Mutex // Mutex for writing to the connection
+
+	pass       string
+	nick       string
+	user       database.User // The authenticated user; empty for anonymous connections
+	scopes     []string
+	anonymous  bool
+	registered bool
+
+	capabilities   map[string]bool
+	muCapabilities sync.Mutex // Mutex for Client.capabilities
+
+	channels   map[string]database.User // Joined channels, keyed by channel name without the #
+	muChannels sync.Mutex               // Mutex for Client.channels
+}
+
+// Commands that are only sent to clients that requested the twitch.tv/commands capability
+var commandsCapabilityCommands = map[string]bool{
+	"CLEARCHAT":       true,
+	"CLEARMSG":        true,
+	"GLOBALUSERSTATE": true,
+	"ROOMSTATE":       true,
+	"USERNOTICE":      true,
+	"USERSTATE":       true,
+}
+
+func (c *Client) readLoop() {
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if chatServer.DebugEnabled {
+			log.Printf("[%v] > %v", c.clientName, line)
+		}
+
+		m, err := ParseMessage(line)
+		if err != nil {
+			continue
+		}
+
+		if !c.handleMessage(m) {
+			return
+		}
+	}
+}
+
+// handleMessage processes a single message from the client. Returns false if the connection should be closed.
+func (c *Client) handleMessage(m Message) bool {
+	switch m.Command {
+	case "CAP":
+		c.handleCap(m)
+	case "PASS":
+		if len(m.Params) > 0 {
+			c.pass = m.Params[0]
+		}
+	case "NICK":
+		if len(m.Params) == 0 {
+			return true
+		}
+		c.nick = strings.ToLower(m.Params[0])
+		if !c.registered {
+			return c.authenticate()
+		}
+	case "USER":
+		// Not used by Twitch
+	case "PING":
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "PONG", Params: append([]string{"tmi.twitch.tv"}, m.Params...)})
+	case "PONG":
+		// Nothing to do
+	case "QUIT":
+		return false
+	case "JOIN", "PART", "PRIVMSG":
+		if !c.registered {
+			c.sendNumeric("451", m.Command, "You have not registered")
+			return true
+		}
+		if len(m.Params) == 0 {
+			c.sendNumeric("461", m.Command, "Not enough parameters")
+			return true
+		}
+
+		switch m.Command {
+		case "JOIN":
+			for _, channel := range strings.Split(m.Params[0], ",") {
+				c.join(channel)
+			}
+		case "PART":
+			for _, channel := range strings.Split(m.Params[0], ",") {
+				c.part(channel)
+			}
+		case "PRIVMSG":
+			c.privmsg(m)
+		}
+	default:
+		c.sendNumeric("421", m.Command, "Unknown command")
+	}
+
+	return true
+}
+
+func (c *Client) handleCap(m Message) {
+	if len(m.Params) == 0 {
+		return
+	}
+
+	switch strings.ToUpper(m.Params[0]) {
+	case "LS":
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "CAP", Params: []string{"*", "LS", strings.Join(supportedCapabilities, " ")}})
+	case "REQ":
+		if len(m.Params) < 2 {
+			return
+		}
+		requested := strings.Fields(m.Params[1])
+
+		// Capabilities are acknowledged or rejected as a group
+		for _, cap := range requested {
+			if !isSupportedCapability(cap) {
+				c.send(Message{Prefix: "tmi.twitch.tv", Command: "CAP", Params: []string{"*", "NAK", m.Params[1]}})
+				return
+			}
+		}
+
+		c.muCapabilities.Lock()
+		for _, cap := range requested {
+			c.capabilities[cap] = true
+		}
+		c.muCapabilities.Unlock()
+
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "CAP", Params: []string{"*", "ACK", m.Params[1]}})
+	case "END":
+		// Nothing to do
+	}
+}
+
+func isSupportedCapability(cap string) bool {
+	for _, supported := range supportedCapabilities {
+		if cap == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate validates the token given with PASS against the mock API's authorizations, and welcomes the client if it's valid.
+func (c *Client) authenticate() bool {
+	if anonymousNickRegex.MatchString(c.nick) && !strings.HasPrefix(c.pass, "oauth:") {
+		c.anonymous = true
+		c.welcome()
+		return true
+	}
+
+	if !strings.HasPrefix(c.pass, "oauth:") {
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "NOTICE", Params: []string{"*", "Improperly formatted auth"}})
+		return false
+	}
+
+	auth, err := chatServer.db.NewQuery(nil, 100).GetAuthorizationByToken(strings.TrimPrefix(c.pass, "oauth:"))
+	if err != nil {
+		log.Printf("Error fetching authorization: %v", err)
+	}
+
+	expiration, _ := time.Parse(time.RFC3339, auth.ExpiresAt)
+	scopes := strings.Split(auth.Scopes, " ")
+	if auth.Token == "" || time.Now().After(expiration) || !hasScope(scopes, "chat:read") {
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "NOTICE", Params: []string{"*", "Login authentication failed"}})
+		return false
+	}
+
+	user, err := chatServer.db.NewQuery(nil, 100).GetUser(database.User{ID: auth.UserID})
+	if err != nil || user.ID == "" {
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: "NOTICE", Params: []string{"*", "Login authentication failed"}})
+		return false
+	}
+
+	c.user = user
+	c.scopes = scopes
+	c.welcome()
+
+	if chatServer.DebugEnabled {
+		log.Printf("[%v] Logged in as %v", c.clientName, user.UserLogin)
+	}
+
+	return true
+}
+
+func (c *Client) welcome() {
+	c.registered = true
+
+	login := c.login()
+	for _, numeric := range [][]string{
+		{"001", "Welcome, GLHF!"},
+		{"002", "Your host is tmi.twitch.tv"},
+		{"003", "This server is rather new"},
+		{"004", "-"},
+		{"375", "-"},
+		{"372", "You are in a maze of twisty passages, all alike."},
+		{"376", ">"},
+	} {
+		c.send(Message{Prefix: "tmi.twitch.tv", Command: numeric[0], Params: []string{login, numeric[1]}})
+	}
+
+	if !c.anonymous {
+		c.send(Message{Tags: globalUserStateTags(c.user), Prefix: "tmi.twitch.tv", Command: "GLOBALUSERSTATE"})
+	}
+}
+
+func (c *Client) join(channel string) {
+	name := strings.ToLower(strings.TrimPrefix(channel, "#"))
+
+	broadcaster, err := getBroadcasterByChannel(name)
+	if err != nil {
+		log.Printf("Error fetching broadcaster: %v", err)
+		return
+	}
+	if broadcaster.ID == "" {
+		c.send(Message{
+			Tags:    map[string]string{"msg-id": "msg_channel_suspended"},
+			Prefix:  "tmi.twitch.tv",
+			Command: "NOTICE",
+			Params:  []string{"#" + name, "This channel does not exist or has been suspended."},
+		})
+		return
+	}
+
+	c.muChannels.Lock()
+	c.channels[name] = broadcaster
+	c.muChannels.Unlock()
+
+	login := c.login()
+	c.send(Message{Prefix: userPrefix(login), Command: "JOIN", Params: []string{"#" + name}})
+	c.send(Message{Prefix: login + ".tmi.twitch.tv", Command: "353", Params: []string{login, "=", "#" + name, login}})
+	c.send(Message{Prefix: login + ".tmi.twitch.tv", Command: "366", Params: []string{login, "#" + name, "End of /NAMES list"}})
+	if !c.anonymous {
+		c.send(Message{Tags: userStateTags(c.user, broadcaster), Prefix: "tmi.twitch.tv", Command: "USERSTATE", Params: []string{"#" + name}})
+	}
+	c.send(Message{Tags: roomStateTags(broadcaster), Prefix: "tmi.twitch.tv", Command: "ROOMSTATE", Params: []string{"#" + name}})
+
+	chatServer.broadcastMembership(name, c, "JOIN")
+}
+
+func (c *Client) part(channel string) {
+	name := strings.ToLower(strings.TrimPrefix(channel, "#"))
+	if !c.inChannel(name) {
+		return
+	}
+
+	c.muChannels.Lock()
+	delete(c.channels, name)
+	c.muChannels.Unlock()
+
+	c.send(Message{Prefix: userPrefix(c.login()), Command: "PART", Params: []string{"#" + name}})
+	chatServer.broadcastMembership(name, c, "PART")
+}
+
+func (c *Client) privmsg(m Message) {
+	if len(m.Params) < 2 {
+		c.sendNumeric("412", "PRIVMSG", "No text to send")
+		return
+	}
+
+	// Anonymous connections are read-only, and Twitch silently discards anything they send
+	if c.anonymous {
+		return
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(m.Params[0], "#"))
+	c.muChannels.Lock()
+	broadcaster, joined := c.channels[name]
+	c.muChannels.Unlock()
+	if !joined {
+		return
+	}
+
+	if !hasScope(c.scopes, "chat:edit") {
+		c.sendNotice(name, "", "Your message was not sent because your token is missing the chat:edit scope.")
+		return
+	}
+
+	text := m.Params[1]
+	dropReason, err := chat_endpoint.MessageDropReason(chatServer.db, broadcaster.ID, c.user.ID, text)
+	if err != nil {
+		log.Printf("Error checking chat settings: %v", err)
+		return
+	}
+	if dropReason != nil {
+		c.sendNotice(name, dropReason.Code, dropReason.Message)
+		return
+	}
+
+	message := database.ChatMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: broadcaster.ID,
+		UserID:        c.user.ID,
+		UserLogin:     c.user.UserLogin,
+		UserName:      c.user.DisplayName,
+		Text:          text,
+		MessageType:   "text",
+		CreatedAt:     util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+
+	tags := privmsgTags(c.user, broadcaster, message.ID, text)
+	if parentID := m.Tags["reply-parent-msg-id"]; parentID != "" {
+		dbr, err := chatServer.db.NewQuery(nil, 100).GetChatMessages(database.ChatMessage{ID: parentID, BroadcasterID: broadcaster.ID})
+		if err == nil && len(dbr.Data.([]database.ChatMessage)) > 0 {
+			parent := dbr.Data.([]database.ChatMessage)[0]
+			message.ReplyParentMessageID = &parent.ID
+			tags["reply-parent-msg-id"] = parent.ID
+			tags["reply-parent-msg-body"] = parent.Text
+			tags["reply-parent-user-id"] = parent.UserID
+			tags["reply-parent-user-login"] = parent.UserLogin
+			tags["reply-parent-display-name"] = parent.UserName
+		}
+	}
+
+	err = chatServer.db.NewQuery(nil, 100).InsertChatMessage(message)
+	if err != nil {
+		log.Printf("Error inserting chat message: %v", err)
+		return
+	}
+
+	// Twitch doesn't echo messages back to the sender, but does update their state in the channel
+	chatServer.broadcast(name, Message{Tags: tags, Prefix: userPrefix(c.user.UserLogin), Command: "PRIVMSG", Params: []string{"#" + name, text}}, c)
+	c.send(Message{Tags: userStateTags(c.user, broadcaster), Prefix: "tmi.twitch.tv", Command: "USERSTATE", Params: []string{"#" + name}})
+
+	chatServer.emitter.EmitEventSub(trigger.TriggerParameters{
+		Event:        "channel.chat.message",
+		FromUser:     c.user.ID,
+		FromUserName: c.user.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		ItemID:       message.ID,
+		MessageText:  text,
+		Color:        c.user.ChatColor,
+		Database:     &chatServer.db,
+	})
+}
+
+// send writes a message to the client, leaving out anything the client didn't request with CAP REQ.
+func (c *Client) send(m Message) {
+	if commandsCapabilityCommands[m.Command] && !c.hasCapability("twitch.tv/commands") {
+		return
+	}
+	if !c.hasCapability("twitch.tv/tags") {
+		m.Tags = nil
+	}
+
+	line := m.String()
+	if chatServer.DebugEnabled {
+		log.Printf("[%v] < %v", c.clientName, line)
+	}
+
+	c.muWrite.Lock()
+	defer c.muWrite.Unlock()
+	c.conn.Write([]byte(line + "\r\n"))
+}
+
+func (c *Client) sendNumeric(numeric string, command string, text string) {
+	c.send(Message{Prefix: "tmi.twitch.tv", Command: numeric, Params: []string{c.login(), command, text}})
+}
+
+func (c *Client) sendNotice(channel string, msgID string, text string) {
+	m := Message{Prefix: "tmi.twitch.tv", Command: "NOTICE", Params: []string{"#" + channel, text}}
+	if msgID != "" {
+		m.Tags = map[string]string{"msg-id": msgID}
+	}
+	c.send(m)
+}
+
+func (c *Client) login() string {
+	if c.anonymous || c.user.UserLogin == "" {
+		if c.nick == "" {
+			return "*"
+		}
+		return c.nick
+	}
+	return c.user.UserLogin
+}
+
+func (c *Client) hasCapability(cap string) bool {
+	c.muCapabilities.Lock()
+	defer c.muCapabilities.Unlock()
+	return c.capabilities[cap]
+}
+
+func (c *Client) inChannel(channel string) bool {
+	c.muChannels.Lock()
+	defer c.muChannels.Unlock()
+	_, ok := c.channels[channel]
+	return ok
+}
+
+func (c *Client) joinedChannels() []string {
+	c.muChannels.Lock()
+	defer c.muChannels.Unlock()
+
+	channels := []string{}
+	for name := range c.channels {
+		channels = append(channels, name)
+	}
+	return channels
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Message is a single IRC message, including IRCv3 tags.
+// https://dev.twitch.tv/docs/irc/example-parser/
+type Message struct {
+	Tags    map[string]string
+	Prefix  string
+	Command string
+	Params  []string
+}
+
+var tagEscapes = strings.NewReplacer(`\`, `\\`, ";", `\:`, " ", `\s`, "\r", `\r`, "\n", `\n`)
+var tagUnescapes = strings.NewReplacer(`\\`, `\`, `\:`, ";", `\s`, " ", `\r`, "\r", `\n`, "\n")
+
+// ParseMessage parses a raw IRC line, without the trailing CRLF.
+func ParseMessage(line string) (Message, error) {
+	m := Message{Tags: map[string]string{}}
+
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return m, errors.New("empty message")
+	}
+
+	if strings.HasPrefix(line, "@") {
+		rawTags, rest, _ := strings.Cut(line[1:], " ")
+		for _, tag := range strings.Split(rawTags, ";") {
+			key, value, _ := strings.Cut(tag, "=")
+			if key != "" {
+				m.Tags[key] = tagUnescapes.Replace(value)
+			}
+		}
+		line = strings.TrimLeft(rest, " ")
+	}
+
+	if strings.HasPrefix(line, ":") {
+		m.Prefix, line, _ = strings.Cut(line[1:], " ")
+		line = strings.TrimLeft(line, " ")
+	}
+
+	for line != "" {
+		if strings.HasPrefix(line, ":") {
+			m.Params = append(m.Params, line[1:])
+			break
+		}
+
+		var param string
+		param, line, _ = strings.Cut(line, " ")
+		line = strings.TrimLeft(line, " ")
+		if m.Command == "" {
+			m.Command = strings.ToUpper(param)
+		} else {
+			m.Params = append(m.Params, param)
+		}
+	}
+
+	if m.Command == "" {
+		return m, errors.New("missing command")
+	}
+
+	return m, nil
+}
+
+// String formats the message as a raw IRC line, without the trailing CRLF. Tags are sorted by name, as Twitch does.
+func (m Message) String() string {
+	var sb strings.Builder
+
+	if len(m.Tags) > 0 {
+		keys := make([]string, 0, len(m.Tags))
+		for k := range m.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		sb.WriteString("@")
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteString(";")
+			}
+			sb.WriteString(k)
+			sb.WriteString("=")
+			sb.WriteString(tagEscapes.Replace(m.Tags[k]))
+		}
+		sb.WriteString(" ")
+	}
+
+	if m.Prefix != "" {
+		sb.WriteString(":")
+		sb.WriteString(m.Prefix)
+		sb.WriteString(" ")
+	}
+
+	sb.WriteString(m.Command)
+
+	for i, p := range m.Params {
+		sb.WriteString(" ")
+		// Like Twitch, the last of multiple parameters is always sent as a trailing parameter, since many bots split on " :"
+		if i == len(m.Params)-1 && (i > 0 || p == "" || strings.Contains(p, " ") || strings.HasPrefix(p, ":")) {
+			sb.WriteString(":")
+		}
+		sb.WriteString(p)
+	}
+
+	return sb.String()
+}
+
+// userPrefix returns the nick!user@host prefix Twitch uses for messages sent by a user.
+func userPrefix(login string) string {
+	return login + "!" + login + "@" + login + ".tmi.twitch.tv"
+}
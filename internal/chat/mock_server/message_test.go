@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestParseMessage(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	m, err := ParseMessage("@reply-parent-msg-id=abc;client-nonce=a\\sb :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Hello there :)\r\n")
+	a.Nil(err)
+	a.Equal("abc", m.Tags["reply-parent-msg-id"])
+	a.Equal("a b", m.Tags["client-nonce"])
+	a.Equal("ronni!ronni@ronni.tmi.twitch.tv", m.Prefix)
+	a.Equal("PRIVMSG", m.Command)
+	a.Equal([]string{"#dallas", "Hello there :)"}, m.Params)
+
+	m, err = ParseMessage("cap req :twitch.tv/tags twitch.tv/commands")
+	a.Nil(err)
+	a.Equal("CAP", m.Command)
+	a.Equal([]string{"req", "twitch.tv/tags twitch.tv/commands"}, m.Params)
+
+	_, err = ParseMessage("")
+	a.NotNil(err)
+
+	_, err = ParseMessage("@a=b :prefix")
+	a.NotNil(err)
+}
+
+func TestMessageString(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	m := Message{
+		Tags:    map[string]string{"system-msg": "5 raiders; welcome!", "badges": ""},
+		Prefix:  "tmi.twitch.tv",
+		Command: "USERNOTICE",
+		Params:  []string{"#dallas", "hi"},
+	}
+	a.Equal(`@badges=;system-msg=5\sraiders\:\swelcome! :tmi.twitch.tv USERNOTICE #dallas :hi`, m.String())
+
+	m = Message{Prefix: "tmi.twitch.tv", Command: "ROOMSTATE", Params: []string{"#dallas"}}
+	a.Equal(":tmi.twitch.tv ROOMSTATE #dallas", m.String())
+
+	// Round trip
+	parsed, err := ParseMessage(Message{Tags: map[string]string{"msg": "a;b c\\d"}, Command: "PRIVMSG", Params: []string{"#dallas", "x"}}.String())
+	a.Nil(err)
+	a.Equal("a;b c\\d", parsed.Tags["msg"])
+}
+
+func TestEmotesTag(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal("25:0-4,13-17/425618:6-8", emotesTag("Kappa LUL hi Kappa"))
+	a.Equal("25:2-6", emotesTag("é Kappa"))
+	a.Equal("", emotesTag("no emotes here"))
+
+	a.Equal(int64(150), bitsInMessage("cheer100 nice Cheer50"))
+	a.Equal(int64(0), bitsInMessage("cheerful"))
+}
@@ -0,0 +1,311 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	rpc "github.com/twitchdev/twitch-cli/internal/rpc"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	COMMAND_RESPONSE_SUCCESS          int = 0
+	COMMAND_RESPONSE_INVALID_CMD      int = 1
+	COMMAND_RESPONSE_FAILED_ON_SERVER int = 2
+	COMMAND_RESPONSE_MISSING_FLAG     int = 3
+)
+
+// Resolves console commands to their RPC names defined in the chat server
+func ResolveRPCName(cmd string) string {
+	if cmd == "message" {
+		return "ChatServerInjectMessage"
+	} else if cmd == "sub" {
+		return "ChatServerInjectSub"
+	} else if cmd == "raid" {
+		return "ChatServerInjectRaid"
+	} else {
+		return ""
+	}
+}
+
+// Called by the mock API when a chat action is taken through it, so the result shows up in chat
+func RPCForwardEventHandler(args rpc.RPCArgs) rpc.RPCResponse {
+	v := args.Variables
+
+	broadcaster, err := chatServer.db.NewQuery(nil, 100).GetUser(database.User{ID: v["ToUser"]})
+	if err != nil {
+		return failedResponse("ChatServerForwardEvent", err.Error())
+	}
+	if broadcaster.ID == "" {
+		// Not a channel that exists in the mock database, so nobody could be in it
+		return rpc.RPCResponse{ResponseCode: COMMAND_RESPONSE_SUCCESS}
+	}
+	channel := "#" + broadcaster.UserLogin
+
+	var user database.User
+	if v["FromUser"] != "" {
+		user, err = chatServer.db.NewQuery(nil, 100).GetUser(database.User{ID: v["FromUser"]})
+		if err != nil {
+			return failedResponse("ChatServerForwardEvent", err.Error())
+		}
+	}
+
+	roomTags := map[string]string{
+		"room-id":     broadcaster.ID,
+		"tmi-sent-ts": sentTimestamp(),
+	}
+
+	switch v["Event"] {
+	case "channel.chat.message":
+		chatServer.broadcast(broadcaster.UserLogin, Message{
+			Tags:    privmsgTags(user, broadcaster, v["ItemID"], v["MessageText"]),
+			Prefix:  userPrefix(user.UserLogin),
+			Command: "PRIVMSG",
+			Params:  []string{channel, v["MessageText"]},
+		}, nil)
+
+	case "channel.chat.notification":
+		if v["NoticeType"] != "announcement" {
+			break
+		}
+		color := strings.ToUpper(v["Color"])
+		if color == "" {
+			color = "PRIMARY"
+		}
+		tags := userNoticeTags(user, broadcaster, getUserRoles(broadcaster.ID, user.ID), "announcement", "")
+		tags["msg-param-color"] = color
+		chatServer.broadcast(broadcaster.UserLogin, Message{Tags: tags, Prefix: "tmi.twitch.tv", Command: "USERNOTICE", Params: []string{channel, v["MessageText"]}}, nil)
+
+	case "channel.chat.clear":
+		chatServer.broadcast(broadcaster.UserLogin, Message{Tags: roomTags, Prefix: "tmi.twitch.tv", Command: "CLEARCHAT", Params: []string{channel}}, nil)
+
+	case "channel.chat.message_delete":
+		dbr, err := chatServer.db.NewQuery(nil, 100).GetChatMessages(database.ChatMessage{ID: v["ItemID"], BroadcasterID: broadcaster.ID, IsDeleted: true})
+		if err != nil {
+			return failedResponse("ChatServerForwardEvent", err.Error())
+		}
+		text := ""
+		if messages := dbr.Data.([]database.ChatMessage); len(messages) > 0 {
+			text = messages[0].Text
+		}
+		roomTags["login"] = user.UserLogin
+		roomTags["target-msg-id"] = v["ItemID"]
+		chatServer.broadcast(broadcaster.UserLogin, Message{Tags: roomTags, Prefix: "tmi.twitch.tv", Command: "CLEARMSG", Params: []string{channel, text}}, nil)
+
+	case "channel.ban":
+		roomTags["target-user-id"] = user.ID
+		if _, err := strconv.Atoi(v["BanEndTimestamp"]); err == nil {
+			roomTags["ban-duration"] = v["BanEndTimestamp"]
+		}
+		chatServer.broadcast(broadcaster.UserLogin, Message{Tags: roomTags, Prefix: "tmi.twitch.tv", Command: "CLEARCHAT", Params: []string{channel, user.UserLogin}}, nil)
+	}
+
+	return rpc.RPCResponse{ResponseCode: COMMAND_RESPONSE_SUCCESS}
+}
+
+// $ twitch chat message
+func RPCInjectMessageHandler(args rpc.RPCArgs) rpc.RPCResponse {
+	broadcaster, user, resp := getInjectionUsers("ChatServerInjectMessage", args)
+	if resp != nil {
+		return *resp
+	}
+
+	text := args.Variables["Message"]
+	if text == "" {
+		text = "Hello World! This is a test chat message. Kappa"
+	}
+
+	message := database.ChatMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: broadcaster.ID,
+		UserID:        user.ID,
+		UserLogin:     user.UserLogin,
+		UserName:      user.DisplayName,
+		Text:          text,
+		MessageType:   "text",
+		CreatedAt:     util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err := chatServer.db.NewQuery(nil, 100).InsertChatMessage(message)
+	if err != nil {
+		return failedResponse("ChatServerInjectMessage", err.Error())
+	}
+
+	chatServer.broadcast(broadcaster.UserLogin, Message{
+		Tags:    privmsgTags(user, broadcaster, message.ID, text),
+		Prefix:  userPrefix(user.UserLogin),
+		Command: "PRIVMSG",
+		Params:  []string{"#" + broadcaster.UserLogin, text},
+	}, nil)
+
+	chatServer.emitter.EmitEventSub(trigger.TriggerParameters{
+		Event:        "channel.chat.message",
+		FromUser:     user.ID,
+		FromUserName: user.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		ItemID:       message.ID,
+		MessageText:  text,
+		Color:        user.ChatColor,
+		Database:     &chatServer.db,
+	})
+
+	log.Printf("Injected message from %v into #%v", user.UserLogin, broadcaster.UserLogin)
+	return rpc.RPCResponse{ResponseCode: COMMAND_RESPONSE_SUCCESS}
+}
+
+// $ twitch chat sub
+func RPCInjectSubHandler(args rpc.RPCArgs) rpc.RPCResponse {
+	broadcaster, user, resp := getInjectionUsers("ChatServerInjectSub", args)
+	if resp != nil {
+		return *resp
+	}
+
+	tier := args.Variables["Tier"]
+	switch tier {
+	case "":
+		tier = "1000"
+	case "1000", "2000", "3000":
+		// do nothing, these are valid values
+	default:
+		return rpc.RPCResponse{
+			ResponseCode: COMMAND_RESPONSE_MISSING_FLAG,
+			DetailedInfo: "Invalid tier provided. Valid values are 1000, 2000 or 3000",
+		}
+	}
+
+	roles := getUserRoles(broadcaster.ID, user.ID)
+	roles.isSubscriber = true
+
+	tags := userNoticeTags(user, broadcaster, roles, "sub", fmt.Sprintf("%v subscribed at Tier %v.", user.DisplayName, tier[:1]))
+	tags["msg-param-cumulative-months"] = "1"
+	tags["msg-param-months"] = "0"
+	tags["msg-param-multimonth-duration"] = "1"
+	tags["msg-param-multimonth-tenure"] = "0"
+	tags["msg-param-should-share-streak"] = "0"
+	tags["msg-param-sub-plan"] = tier
+	tags["msg-param-sub-plan-name"] = fmt.Sprintf("Channel Subscription (%v)", broadcaster.UserLogin)
+	tags["msg-param-was-gifted"] = "false"
+
+	params := []string{"#" + broadcaster.UserLogin}
+	if text := args.Variables["Message"]; text != "" {
+		params = append(params, text)
+	}
+	chatServer.broadcast(broadcaster.UserLogin, Message{Tags: tags, Prefix: "tmi.twitch.tv", Command: "USERNOTICE", Params: params}, nil)
+
+	chatServer.emitter.EmitEventSub(trigger.TriggerParameters{
+		Event:        "channel.chat.notification",
+		FromUser:     user.ID,
+		FromUserName: user.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		NoticeType:   "sub",
+		Tier:         tier,
+		MessageText:  args.Variables["Message"],
+		Database:     &chatServer.db,
+	})
+
+	log.Printf("Injected sub from %v into #%v", user.UserLogin, broadcaster.UserLogin)
+	return rpc.RPCResponse{ResponseCode: COMMAND_RESPONSE_SUCCESS}
+}
+
+// $ twitch chat raid
+func RPCInjectRaidHandler(args rpc.RPCArgs) rpc.RPCResponse {
+	broadcaster, user, resp := getInjectionUsers("ChatServerInjectRaid", args)
+	if resp != nil {
+		return *resp
+	}
+
+	viewers, _ := strconv.ParseInt(args.Variables["Viewers"], 10, 64)
+	if viewers <= 0 {
+		viewers = util.RandomInt(1000) + 1
+	}
+
+	tags := userNoticeTags(user, broadcaster, getUserRoles(broadcaster.ID, user.ID), "raid", fmt.Sprintf("%v raiders from %v have joined!", viewers, user.DisplayName))
+	tags["msg-param-displayName"] = user.DisplayName
+	tags["msg-param-login"] = user.UserLogin
+	tags["msg-param-profileImageURL"] = user.ProfileImageURL
+	tags["msg-param-viewerCount"] = strconv.FormatInt(viewers, 10)
+	chatServer.broadcast(broadcaster.UserLogin, Message{Tags: tags, Prefix: "tmi.twitch.tv", Command: "USERNOTICE", Params: []string{"#" + broadcaster.UserLogin}}, nil)
+
+	chatServer.emitter.EmitEventSub(trigger.TriggerParameters{
+		Event:        "channel.chat.notification",
+		FromUser:     user.ID,
+		FromUserName: user.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		NoticeType:   "raid",
+		Cost:         viewers,
+		Database:     &chatServer.db,
+	})
+
+	log.Printf("Injected raid from %v into #%v", user.UserLogin, broadcaster.UserLogin)
+	return rpc.RPCResponse{ResponseCode: COMMAND_RESPONSE_SUCCESS}
+}
+
+// getInjectionUsers resolves the channel and user an injection command targets. If no user was given, a random one is drawn from the mock database.
+func getInjectionUsers(rpcName string, args rpc.RPCArgs) (database.User, database.User, *rpc.RPCResponse) {
+	if args.Variables["Channel"] == "" {
+		return database.User{}, database.User{}, &rpc.RPCResponse{
+			ResponseCode: COMMAND_RESPONSE_MISSING_FLAG,
+			DetailedInfo: "Command requires --channel",
+		}
+	}
+
+	broadcaster, err := getBroadcasterByChannel(args.Variables["Channel"])
+	if err != nil {
+		resp := failedResponse(rpcName, err.Error())
+		return database.User{}, database.User{}, &resp
+	}
+	if broadcaster.ID == "" {
+		resp := failedResponse(rpcName, fmt.Sprintf("Channel %v doesn't exist in the mock database", args.Variables["Channel"]))
+		return database.User{}, database.User{}, &resp
+	}
+
+	var user database.User
+	if login := strings.ToLower(args.Variables["User"]); login != "" {
+		user, err = chatServer.db.NewQuery(nil, 100).GetUser(database.User{UserLogin: login})
+		if err != nil {
+			resp := failedResponse(rpcName, err.Error())
+			return database.User{}, database.User{}, &resp
+		}
+		if user.ID == "" {
+			resp := failedResponse(rpcName, fmt.Sprintf("User %v doesn't exist in the mock database", login))
+			return database.User{}, database.User{}, &resp
+		}
+		return broadcaster, user, nil
+	}
+
+	dbr, err := chatServer.db.NewQuery(nil, 1000).GetUsers(database.User{})
+	if err != nil {
+		resp := failedResponse(rpcName, err.Error())
+		return database.User{}, database.User{}, &resp
+	}
+	candidates := []database.User{}
+	for _, u := range dbr.Data.([]database.User) {
+		if u.ID != broadcaster.ID {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		resp := failedResponse(rpcName, "No users in the mock database to chat as. Run \"twitch mock-api generate\" to create some.")
+		return database.User{}, database.User{}, &resp
+	}
+
+	return broadcaster, candidates[util.RandomInt(int64(len(candidates)))], nil
+}
+
+func failedResponse(rpcName string, info string) rpc.RPCResponse {
+	msg := fmt.Sprintf("Error on RPC call (%v): %v", rpcName, info)
+	log.Print(msg)
+	return rpc.RPCResponse{
+		ResponseCode: COMMAND_RESPONSE_FAILED_ON_SERVER,
+		DetailedInfo: msg,
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	rpc_handler "github.com/twitchdev/twitch-cli/internal/rpc"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Port used by the chat server's RPC handler. This is different from the EventSub WebSocket server's, so both can run at once.
+const RPC_PORT = 44748
+
+// Capabilities that can be requested with CAP REQ
+var supportedCapabilities = []string{
+	"twitch.tv/commands",
+	"twitch.tv/membership",
+	"twitch.tv/tags",
+}
+
+type ChatServer struct {
+	DebugEnabled bool // Display debug messages; --debug
+
+	db      database.CLIDatabase
+	emitter *mock_events.Emitter // Delivers the EventSub events of messages sent in chat
+
+	Clients   *util.List[Client] // All connected clients
+	muClients sync.Mutex         // Mutex for ChatServer.Clients
+}
+
+var chatServer *ChatServer
+
+func StartChatServer(enableDebug bool, ip string, port int) error {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return fmt.Errorf("Error connecting to database: %v", err.Error())
+	}
+	defer db.DB.Close()
+
+	// Users are drawn from the mock API's database, so make sure there are some to chat with
+	if db.IsFirstRun() {
+		err := generate.Generate(25)
+		if err != nil {
+			return err
+		}
+	}
+
+	chatServer = &ChatServer{
+		DebugEnabled: enableDebug,
+		db:           db,
+		emitter:      mock_events.NewEmitter(),
+		Clients: &util.List[Client]{
+			Elements: make(map[string]*Client),
+		},
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", ip, port))
+	if err != nil {
+		return fmt.Errorf("Cannot start chat server: %v", err)
+	}
+	defer listener.Close()
+
+	// Allow exit with Ctrl + C
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				// Listener was closed
+				return
+			}
+			go chatServer.handleConnection(conn)
+		}
+	}()
+
+	// Initalize RPC handler, to accept injected messages and events from the mock API
+	rpc := rpc_handler.RPCHandler{
+		Port:     RPC_PORT,
+		Handlers: make(map[string]rpc_handler.HandlerCallback),
+	}
+
+	rpc.RegisterHandler("ChatServerForwardEvent", RPCForwardEventHandler)
+	rpc.RegisterHandler("ChatServerInjectMessage", RPCInjectMessageHandler)
+	rpc.RegisterHandler("ChatServerInjectSub", RPCInjectSubHandler)
+	rpc.RegisterHandler("ChatServerInjectRaid", RPCInjectRaidHandler)
+	err = rpc.StartBackgroundServer()
+	if err != nil {
+		log.Printf("Failed to start RPC handler; messages can't be injected into chat: %v", err)
+	}
+
+	printWelcomeMsg(ip, port)
+
+	<-stop // Wait for Ctrl + C
+
+	log.Print("shutting down ...\n")
+
+	// Let events still being delivered finish before the database is closed
+	chatServer.emitter.Wait()
+	return nil
+}
+
+func printWelcomeMsg(ip string, port int) {
+	lightBlue := color.New(color.FgHiBlue).SprintFunc()
+	lightYellow := color.New(color.FgHiYellow).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	log.Printf(lightBlue("Started chat server on %v:%v"), ip, port)
+	fmt.Println()
+
+	log.Println(yellow("Log in with PASS oauth:<token>, using a user access token from the mock API with the chat:read scope. The chat:edit scope is required to send messages."))
+	log.Println(yellow("Connecting as justinfan<number> without a token gives a read-only, anonymous connection."))
+	fmt.Println()
+
+	log.Println(lightYellow("Messages, subs, and raids can be injected into a channel from another terminal\nExample: \"twitch chat message --channel=<login> --message=\\\"Hello chat\\\"\""))
+	log.Println(lightYellow("Chat actions taken through the mock API, such as sending messages or banning users, are also shown in chat."))
+	fmt.Println()
+
+	log.Printf(lightBlue("Connect to the chat server at: ")+"irc://%v:%v", ip, port)
+}
+
+func (cs *ChatServer) handleConnection(conn net.Conn) {
+	client := &Client{
+		clientName:   util.RandomGUID()[:8],
+		conn:         conn,
+		capabilities: make(map[string]bool),
+		channels:     make(map[string]database.User),
+	}
+
+	cs.muClients.Lock()
+	cs.Clients.Put(client.clientName, client)
+	cs.muClients.Unlock()
+
+	if cs.DebugEnabled {
+		log.Printf("Client connected [%v]", client.clientName)
+	}
+
+	client.readLoop()
+
+	// Let everyone else know the client left the channels it was in
+	for _, channel := range client.joinedChannels() {
+		cs.broadcastMembership(channel, client, "PART")
+	}
+
+	cs.muClients.Lock()
+	cs.Clients.Delete(client.clientName)
+	cs.muClients.Unlock()
+
+	conn.Close()
+
+	if cs.DebugEnabled {
+		log.Printf("Client disconnected [%v]", client.clientName)
+	}
+}
+
+// broadcast sends a message to every client in a channel, optionally skipping the client that sent it.
+func (cs *ChatServer) broadcast(channel string, m Message, except *Client) {
+	cs.muClients.Lock()
+	clients := cs.Clients.All()
+	cs.muClients.Unlock()
+
+	for _, c := range clients {
+		if c == except || !c.inChannel(channel) {
+			continue
+		}
+		c.send(m)
+	}
+}
+
+// broadcastMembership sends JOIN and PART messages to clients in the channel that requested the membership capability.
+func (cs *ChatServer) broadcastMembership(channel string, client *Client, command string) {
+	if client.anonymous {
+		return
+	}
+
+	cs.muClients.Lock()
+	clients := cs.Clients.All()
+	cs.muClients.Unlock()
+
+	for _, c := range clients {
+		if c == client || !c.inChannel(channel) || !c.hasCapability("twitch.tv/membership") {
+			continue
+		}
+		c.send(Message{
+			Prefix:  userPrefix(client.login()),
+			Command: command,
+			Params:  []string{"#" + channel},
+		})
+	}
+}
+
+// getBroadcasterByChannel looks up the broadcaster for a channel name, with or without the leading #.
+func getBroadcasterByChannel(channel string) (database.User, error) {
+	login := strings.ToLower(strings.TrimPrefix(channel, "#"))
+	if login == "" {
+		return database.User{}, nil
+	}
+	return chatServer.db.NewQuery(nil, 100).GetUser(database.User{UserLogin: login})
+}
@@ -0,0 +1,362 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	rpc "github.com/twitchdev/twitch-cli/internal/rpc"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+type testClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (tc *testClient) send(line string) {
+	tc.conn.Write([]byte(line + "\r\n"))
+}
+
+// read returns the next message from the server, or false once the connection is closed or nothing arrives in time.
+func (tc *testClient) read() (Message, bool) {
+	tc.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := tc.reader.ReadString('\n')
+	if err != nil {
+		return Message{}, false
+	}
+	m, err := ParseMessage(strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		tc.t.Fatalf("Unparsable message from server: %q", line)
+	}
+	return m, true
+}
+
+// expect reads messages until one with the given command arrives.
+func (tc *testClient) expect(command string) Message {
+	tc.t.Helper()
+	for {
+		m, ok := tc.read()
+		if !ok {
+			tc.t.Fatalf("Connection ended while waiting for %v", command)
+		}
+		if m.Command == command {
+			return m
+		}
+	}
+}
+
+// expectNone fails if a message with the given command arrives before the server answers a PING.
+func (tc *testClient) expectNone(command string) {
+	tc.t.Helper()
+	tc.send("PING :check")
+	for {
+		m, ok := tc.read()
+		if !ok {
+			tc.t.Fatalf("Connection ended while waiting for PONG")
+		}
+		if m.Command == command {
+			tc.t.Fatalf("Unexpected %v: %v", command, m.String())
+		}
+		if m.Command == "PONG" {
+			return
+		}
+	}
+}
+
+type testChat struct {
+	addr        string
+	broadcaster database.User
+	chatter     database.User
+	viewer      database.User
+	tokens      map[string]string // Tokens by user login
+
+	muEvents sync.Mutex
+	events   []string // Subscription types of the events forwarded by the server's emitter
+}
+
+func (tc *testChat) deliveredEvents() []string {
+	chatServer.emitter.Wait()
+	tc.muEvents.Lock()
+	defer tc.muEvents.Unlock()
+	return tc.events
+}
+
+// connect logs in with the token of the given user, or anonymously if login is a justinfan nick.
+func (tc *testChat) connect(t *testing.T, login string, capabilities string) *testClient {
+	conn, err := net.Dial("tcp", tc.addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	c := &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+	if capabilities != "" {
+		c.send("CAP REQ :" + capabilities)
+		c.expect("CAP")
+	}
+	if token, ok := tc.tokens[login]; ok {
+		c.send("PASS oauth:" + token)
+	}
+	c.send("NICK " + login)
+	c.expect("376")
+	return c
+}
+
+func setupChatServer(t *testing.T, a *assert.Assertions) *testChat {
+	tc := &testChat{tokens: map[string]string{}}
+
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc.muEvents.Lock()
+		tc.events = append(tc.events, r.Header.Get("Twitch-Eventsub-Subscription-Type"))
+		tc.muEvents.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(forward.Close)
+
+	db, err := database.NewConnectionAt(filepath.Join(t.TempDir(), "chat.db"), true)
+	a.Nil(err)
+	t.Cleanup(func() { db.DB.Close() })
+	q := db.NewQuery(nil, 100)
+
+	for _, u := range []*database.User{&tc.broadcaster, &tc.chatter, &tc.viewer} {
+		*u = database.User{ID: util.RandomUserID(), CreatedAt: util.GetTimestamp().Format(time.RFC3339)}
+	}
+	tc.broadcaster.UserLogin, tc.broadcaster.DisplayName = "chatbroadcaster", "ChatBroadcaster"
+	tc.chatter.UserLogin, tc.chatter.DisplayName = "chatter", "Chatter"
+	tc.viewer.UserLogin, tc.viewer.DisplayName = "viewer", "Viewer"
+
+	for _, u := range []database.User{tc.broadcaster, tc.chatter, tc.viewer} {
+		a.Nil(q.InsertUser(u, false))
+	}
+
+	client, err := q.InsertOrUpdateAuthenticationClient(database.AuthenticationClient{ID: util.RandomClientID(), Name: "Chat Test"}, false)
+	a.Nil(err)
+	for login, user := range map[string]database.User{"chatter": tc.chatter, "viewer": tc.viewer} {
+		scopes := "chat:read chat:edit"
+		if login == "viewer" {
+			scopes = "chat:read"
+		}
+		auth, err := q.CreateAuthorization(database.Authorization{ClientID: client.ID, UserID: user.ID, Scopes: scopes})
+		a.Nil(err)
+		tc.tokens[login] = auth.Token
+	}
+
+	chatServer = &ChatServer{
+		db:      db,
+		emitter: &mock_events.Emitter{ForwardAddress: forward.URL, Secret: "secretsecret"},
+		Clients: &util.List[Client]{
+			Elements: make(map[string]*Client),
+		},
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	a.Nil(err)
+	tc.addr = listener.Addr().String()
+
+	var connections sync.WaitGroup
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			connections.Add(1)
+			go func() {
+				defer connections.Done()
+				chatServer.handleConnection(conn)
+			}()
+		}
+	}()
+
+	// Runs after the test's own connections are closed, so the next test's server doesn't replace this one while it's in use
+	t.Cleanup(func() {
+		listener.Close()
+		connections.Wait()
+		chatServer.emitter.Wait()
+	})
+
+	return tc
+}
+
+func TestAuthentication(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	tc := setupChatServer(t, a)
+
+	// anonymous, without a token
+	c := tc.connect(t, "justinfan1", "")
+	c.send("JOIN #chatbroadcaster")
+	c.expect("JOIN")
+
+	// unknown token
+	conn, err := net.Dial("tcp", tc.addr)
+	a.Nil(err)
+	defer conn.Close()
+	c = &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+	c.send("PASS oauth:notarealtoken")
+	c.send("NICK chatter")
+	m := c.expect("NOTICE")
+	a.Equal("Login authentication failed", m.Params[1])
+	_, ok := c.read()
+	a.False(ok, "connection should be closed after failed authentication")
+
+	// missing oauth: prefix
+	conn, err = net.Dial("tcp", tc.addr)
+	a.Nil(err)
+	defer conn.Close()
+	c = &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+	c.send("PASS " + tc.tokens["chatter"])
+	c.send("NICK chatter")
+	m = c.expect("NOTICE")
+	a.Equal("Improperly formatted auth", m.Params[1])
+
+	// valid token
+	c = tc.connect(t, "chatter", "")
+	c.send("PING :tmi.twitch.tv")
+	c.expect("PONG")
+}
+
+func TestCapabilities(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	tc := setupChatServer(t, a)
+
+	conn, err := net.Dial("tcp", tc.addr)
+	a.Nil(err)
+	defer conn.Close()
+	c := &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+
+	c.send("CAP LS 302")
+	m := c.expect("CAP")
+	a.Equal("LS", m.Params[1])
+	a.Equal(strings.Join(supportedCapabilities, " "), m.Params[2])
+
+	// capabilities are rejected as a group if any of them isn't supported
+	c.send("CAP REQ :twitch.tv/tags twitch.tv/unknown")
+	m = c.expect("CAP")
+	a.Equal([]string{"*", "NAK", "twitch.tv/tags twitch.tv/unknown"}, m.Params)
+
+	c.send("CAP REQ :twitch.tv/tags twitch.tv/commands")
+	m = c.expect("CAP")
+	a.Equal([]string{"*", "ACK", "twitch.tv/tags twitch.tv/commands"}, m.Params)
+
+	c.send("PASS oauth:" + tc.tokens["chatter"])
+	c.send("NICK chatter")
+	m = c.expect("GLOBALUSERSTATE")
+	a.Equal(tc.chatter.ID, m.Tags["user-id"])
+}
+
+func TestJoin(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	tc := setupChatServer(t, a)
+
+	c := tc.connect(t, "chatter", "twitch.tv/tags twitch.tv/commands")
+	c.send("JOIN #ChatBroadcaster")
+	m := c.expect("JOIN")
+	a.Equal([]string{"#chatbroadcaster"}, m.Params)
+	c.expect("366")
+	c.expect("USERSTATE")
+	m = c.expect("ROOMSTATE")
+	a.Equal(tc.broadcaster.ID, m.Tags["room-id"])
+	a.Equal("0", m.Tags["emote-only"])
+
+	// ROOMSTATE is a command, so clients without twitch.tv/commands don't get it
+	c = tc.connect(t, "viewer", "")
+	c.send("JOIN #chatbroadcaster")
+	c.expect("366")
+	c.expectNone("ROOMSTATE")
+
+	c.send("JOIN #notachannel")
+	m = c.expect("NOTICE")
+	a.Equal("This channel does not exist or has been suspended.", m.Params[1])
+}
+
+func TestPrivmsg(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	tc := setupChatServer(t, a)
+
+	listener := tc.connect(t, "justinfan123", "twitch.tv/tags")
+	listener.send("JOIN #chatbroadcaster")
+	listener.expect("366")
+
+	sender := tc.connect(t, "chatter", "twitch.tv/tags twitch.tv/commands")
+	sender.send("JOIN #chatbroadcaster")
+	sender.expect("ROOMSTATE")
+
+	sender.send("PRIVMSG #chatbroadcaster :Hello chat Kappa")
+	m := listener.expect("PRIVMSG")
+	a.Equal([]string{"#chatbroadcaster", "Hello chat Kappa"}, m.Params)
+	a.Equal("chatter!chatter@chatter.tmi.twitch.tv", m.Prefix)
+	a.Equal(tc.chatter.ID, m.Tags["user-id"])
+	a.Equal("25:11-15", m.Tags["emotes"])
+
+	// the sender gets their state in the channel rather than their own message
+	sender.expect("USERSTATE")
+	sender.expectNone("PRIVMSG")
+
+	a.Equal([]string{"channel.chat.message"}, tc.deliveredEvents())
+
+	// tokens without chat:edit can't send messages
+	viewer := tc.connect(t, "viewer", "")
+	viewer.send("JOIN #chatbroadcaster")
+	viewer.expect("366")
+	viewer.send("PRIVMSG #chatbroadcaster :Can anyone see this?")
+	m = viewer.expect("NOTICE")
+	a.Equal("Your message was not sent because your token is missing the chat:edit scope.", m.Params[1])
+	listener.expectNone("PRIVMSG")
+
+	// anonymous connections are dropped silently
+	listener.send("PRIVMSG #chatbroadcaster :Hello?")
+	listener.expectNone("NOTICE")
+	sender.expectNone("PRIVMSG")
+
+	a.Equal([]string{"channel.chat.message"}, tc.deliveredEvents())
+}
+
+func TestInjectRPCs(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	tc := setupChatServer(t, a)
+
+	c := tc.connect(t, "justinfan123", "twitch.tv/tags twitch.tv/commands")
+	c.send("JOIN #chatbroadcaster")
+	c.expect("ROOMSTATE")
+
+	resp := RPCInjectMessageHandler(rpc.RPCArgs{Variables: map[string]string{"Channel": "chatbroadcaster", "User": "chatter", "Message": "Injected"}})
+	a.Equal(COMMAND_RESPONSE_SUCCESS, resp.ResponseCode)
+	m := c.expect("PRIVMSG")
+	a.Equal([]string{"#chatbroadcaster", "Injected"}, m.Params)
+	a.Equal("Chatter", m.Tags["display-name"])
+
+	resp = RPCInjectSubHandler(rpc.RPCArgs{Variables: map[string]string{"Channel": "chatbroadcaster", "User": "chatter", "Tier": "2000"}})
+	a.Equal(COMMAND_RESPONSE_SUCCESS, resp.ResponseCode)
+	m = c.expect("USERNOTICE")
+	a.Equal("sub", m.Tags["msg-id"])
+	a.Equal("2000", m.Tags["msg-param-sub-plan"])
+
+	resp = RPCInjectSubHandler(rpc.RPCArgs{Variables: map[string]string{"Channel": "chatbroadcaster", "Tier": "4000"}})
+	a.Equal(COMMAND_RESPONSE_MISSING_FLAG, resp.ResponseCode)
+
+	resp = RPCInjectRaidHandler(rpc.RPCArgs{Variables: map[string]string{"Channel": "chatbroadcaster", "User": "viewer", "Viewers": "42"}})
+	a.Equal(COMMAND_RESPONSE_SUCCESS, resp.ResponseCode)
+	m = c.expect("USERNOTICE")
+	a.Equal("raid", m.Tags["msg-id"])
+	a.Equal("42", m.Tags["msg-param-viewerCount"])
+	a.Equal("viewer", m.Tags["msg-param-login"])
+
+	resp = RPCInjectMessageHandler(rpc.RPCArgs{Variables: map[string]string{"Channel": "notachannel"}})
+	a.Equal(COMMAND_RESPONSE_FAILED_ON_SERVER, resp.ResponseCode)
+
+	a.ElementsMatch([]string{"channel.chat.message", "channel.chat.notification", "channel.chat.notification"}, tc.deliveredEvents())
+}
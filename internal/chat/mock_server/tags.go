@@ -0,0 +1,225 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	chat_event "github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// userRoles describes a user's standing in a channel, which is reflected in their badges.
+type userRoles struct {
+	isBroadcaster bool
+	isModerator   bool
+	isVIP         bool
+	isSubscriber  bool
+}
+
+func getUserRoles(broadcasterID string, userID string) userRoles {
+	roles := userRoles{isBroadcaster: broadcasterID == userID}
+
+	dbr, err := chatServer.db.NewQuery(nil, 1000).GetModeratorsForBroadcaster(broadcasterID)
+	if err == nil && dbr != nil {
+		for _, mod := range dbr.Data.([]database.Moderator) {
+			if mod.UserID == userID {
+				roles.isModerator = true
+			}
+		}
+	}
+
+	dbr, err = chatServer.db.NewQuery(nil, 1000).GetVIPsByBroadcaster(broadcasterID)
+	if err == nil && dbr != nil {
+		for _, vip := range dbr.Data.([]database.VIP) {
+			if vip.UserID == userID {
+				roles.isVIP = true
+			}
+		}
+	}
+
+	dbr, err = chatServer.db.NewQuery(nil, 100).GetSubscriptions(database.Subscription{BroadcasterID: broadcasterID, UserID: userID})
+	if err == nil && dbr != nil {
+		roles.isSubscriber = len(dbr.Data.([]database.Subscription)) > 0
+	}
+
+	return roles
+}
+
+func (r userRoles) badges() string {
+	badges := []string{}
+	if r.isBroadcaster {
+		badges = append(badges, "broadcaster/1")
+	}
+	if r.isModerator {
+		badges = append(badges, "moderator/1")
+	}
+	if r.isVIP {
+		badges = append(badges, "vip/1")
+	}
+	if r.isSubscriber {
+		badges = append(badges, "subscriber/0")
+	}
+	return strings.Join(badges, ",")
+}
+
+func (r userRoles) badgeInfo() string {
+	if r.isSubscriber {
+		return "subscriber/1"
+	}
+	return ""
+}
+
+func (r userRoles) userType() string {
+	if r.isModerator {
+		return "mod"
+	}
+	return ""
+}
+
+// userTags are the tags shared by every message describing a user in a channel.
+func userTags(user database.User, roles userRoles) map[string]string {
+	return map[string]string{
+		"badge-info":   roles.badgeInfo(),
+		"badges":       roles.badges(),
+		"color":        user.ChatColor,
+		"display-name": user.DisplayName,
+		"mod":          boolTag(roles.isModerator),
+		"subscriber":   boolTag(roles.isSubscriber),
+		"turbo":        "0",
+		"user-id":      user.ID,
+		"user-type":    roles.userType(),
+	}
+}
+
+func globalUserStateTags(user database.User) map[string]string {
+	tags := userTags(user, userRoles{})
+	delete(tags, "mod")
+	delete(tags, "subscriber")
+	tags["emote-sets"] = "0"
+	return tags
+}
+
+func userStateTags(user database.User, broadcaster database.User) map[string]string {
+	tags := userTags(user, getUserRoles(broadcaster.ID, user.ID))
+	delete(tags, "user-id")
+	tags["emote-sets"] = "0"
+	return tags
+}
+
+func roomStateTags(broadcaster database.User) map[string]string {
+	tags := map[string]string{
+		"emote-only":     "0",
+		"followers-only": "-1",
+		"r9k":            "0",
+		"room-id":        broadcaster.ID,
+		"slow":           "0",
+		"subs-only":      "0",
+	}
+
+	dbr, err := chatServer.db.NewQuery(nil, 100).GetChatSettingsByBroadcaster(broadcaster.ID)
+	if err != nil || dbr == nil || len(dbr.Data.([]database.ChatSettings)) == 0 {
+		return tags
+	}
+	settings := dbr.Data.([]database.ChatSettings)[0]
+
+	if settings.EmoteMode != nil && *settings.EmoteMode {
+		tags["emote-only"] = "1"
+	}
+	if settings.FollowerMode != nil && *settings.FollowerMode {
+		tags["followers-only"] = "0"
+		if settings.FollowerModeDuration != nil {
+			tags["followers-only"] = strconv.Itoa(*settings.FollowerModeDuration)
+		}
+	}
+	if settings.UniqueChatMode != nil && *settings.UniqueChatMode {
+		tags["r9k"] = "1"
+	}
+	if settings.SlowMode != nil && *settings.SlowMode && settings.SlowModeWaitTime != nil {
+		tags["slow"] = strconv.Itoa(*settings.SlowModeWaitTime)
+	}
+	if settings.SubscriberMode != nil && *settings.SubscriberMode {
+		tags["subs-only"] = "1"
+	}
+
+	return tags
+}
+
+func privmsgTags(user database.User, broadcaster database.User, messageID string, text string) map[string]string {
+	tags := userTags(user, getUserRoles(broadcaster.ID, user.ID))
+	tags["emotes"] = emotesTag(text)
+	tags["first-msg"] = "0"
+	tags["flags"] = ""
+	tags["id"] = messageID
+	tags["returning-chatter"] = "0"
+	tags["room-id"] = broadcaster.ID
+	tags["tmi-sent-ts"] = sentTimestamp()
+
+	if bits := bitsInMessage(text); bits > 0 {
+		tags["bits"] = strconv.FormatInt(bits, 10)
+	}
+
+	return tags
+}
+
+func userNoticeTags(user database.User, broadcaster database.User, roles userRoles, noticeType string, systemMessage string) map[string]string {
+	tags := userTags(user, roles)
+	tags["emotes"] = ""
+	tags["flags"] = ""
+	tags["id"] = util.RandomGUID()
+	tags["login"] = user.UserLogin
+	tags["msg-id"] = noticeType
+	tags["room-id"] = broadcaster.ID
+	tags["system-msg"] = systemMessage
+	tags["tmi-sent-ts"] = sentTimestamp()
+	return tags
+}
+
+// emotesTag builds the emotes tag, in the format <emote ID>:<start>-<end>,<start>-<end>/<emote ID>:<start>-<end>
+func emotesTag(text string) string {
+	positions := map[string][]string{}
+	order := []string{}
+
+	offset := 0
+	for _, fragment := range chat_event.MessageFragments(text) {
+		length := utf8.RuneCountInString(fragment.Text)
+		if fragment.Emote != nil {
+			if _, ok := positions[fragment.Emote.ID]; !ok {
+				order = append(order, fragment.Emote.ID)
+			}
+			positions[fragment.Emote.ID] = append(positions[fragment.Emote.ID], fmt.Sprintf("%v-%v", offset, offset+length-1))
+		}
+		offset += length
+	}
+
+	emotes := []string{}
+	for _, id := range order {
+		emotes = append(emotes, id+":"+strings.Join(positions[id], ","))
+	}
+	return strings.Join(emotes, "/")
+}
+
+func bitsInMessage(text string) int64 {
+	var bits int64
+	for _, fragment := range chat_event.MessageFragments(text) {
+		if fragment.Cheermote != nil {
+			bits += fragment.Cheermote.Bits
+		}
+	}
+	return bits
+}
+
+func sentTimestamp() string {
+	return strconv.FormatInt(util.GetTimestamp().UnixMilli(), 10)
+}
+
+func boolTag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -5,6 +5,7 @@ package database
 import (
 	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -52,6 +53,12 @@ func (q *Query) InsertOrUpdateAuthenticationClient(client AuthenticationClient,
 	}
 
 	client.Secret = generateString(30)
+	if client.IsExtension {
+		// Extension secrets are base64 encoded keys, used to sign Extension JWTs
+		key := make([]byte, 32)
+		rand.Read(key)
+		client.Secret = base64.StdEncoding.EncodeToString(key)
+	}
 
 	for {
 		_, err := db.NamedExec(generateInsertSQL("clients", "id", client, upsert), client)
@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package database
+
+import "strings"
+
+const (
+	AutomodStatusPending  = "pending"
+	AutomodStatusApproved = "approved"
+	AutomodStatusDenied   = "denied"
+)
+
+// AutomodMessage is a chat message held by AutoMod for review by a moderator.
+type AutomodMessage struct {
+	ID            string `db:"id" json:"message_id"`
+	BroadcasterID string `db:"broadcaster_id" json:"broadcaster_id"`
+	UserID        string `db:"user_id" json:"user_id"`
+	UserLogin     string `db:"user_login" json:"user_login"`
+	UserName      string `db:"user_name" json:"user_name"`
+	Text          string `db:"message_text" json:"text"`
+	Category      string `db:"category" json:"category"`
+	Level         int    `db:"level" json:"level"`
+	Status        string `db:"status" json:"status"`
+	HeldAt        string `db:"held_at" json:"held_at"`
+}
+
+// GetAutomodMessages returns the held messages matching the provided filter, newest first.
+func (q *Query) GetAutomodMessages(m AutomodMessage) (*DBResponse, error) {
+	r := []AutomodMessage{}
+
+	sql := generateSQL("SELECT * FROM automod_messages", m, SEP_AND)
+	sql += " ORDER BY held_at DESC" + q.SQL
+
+	rows, err := q.DB.NamedQuery(sql, m)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var am AutomodMessage
+		err := rows.StructScan(&am)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, am)
+	}
+
+	dbr := DBResponse{
+		Data:  r,
+		Limit: q.Limit,
+		Total: len(r),
+	}
+
+	if len(r) != q.Limit {
+		q.PaginationCursor = ""
+	}
+
+	dbr.Cursor = q.PaginationCursor
+
+	return &dbr, err
+}
+
+// InsertAutomodMessage stores a held message. Messages that were already stored (e.g. the same event emitted via webhook and WebSocket) are left untouched.
+func (q *Query) InsertAutomodMessage(m AutomodMessage) error {
+	if m.Status == "" {
+		m.Status = AutomodStatusPending
+	}
+	stmt := generateInsertSQL("automod_messages", "id", m, false)
+	stmt = strings.Replace(stmt, "insert into", "insert or ignore into", 1)
+	_, err := q.DB.NamedExec(stmt, m)
+	return err
+}
+
+// UpdateAutomodMessageStatus records a moderator's decision on a held message.
+func (q *Query) UpdateAutomodMessageStatus(id string, status string) error {
+	_, err := q.DB.Exec("UPDATE automod_messages SET status = $1 WHERE id = $2", status, id)
+	return err
+}
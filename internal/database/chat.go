@@ -2,6 +2,8 @@
 // SPDX-License-Identifier: Apache-2.0
 package database
 
+import "strings"
+
 type ChatSettings struct {
 	BroadcasterID                 string `db:"broadcaster_id" json:"broadcaster_id"`
 	SlowMode                      *bool  `db:"slow_mode" json:"slow_mode"`
@@ -52,3 +54,86 @@ func (q *Query) UpdateChatSettings(s ChatSettings) error {
 	_, err := q.DB.NamedExec(sql, s)
 	return err
 }
+
+type ChatMessage struct {
+	ID                   string  `db:"id" json:"message_id"`
+	BroadcasterID        string  `db:"broadcaster_id" json:"broadcaster_id"`
+	UserID               string  `db:"user_id" json:"user_id"`
+	UserLogin            string  `db:"user_login" json:"user_login"`
+	UserName             string  `db:"user_name" json:"user_name"`
+	Text                 string  `db:"message_text" json:"text"`
+	MessageType          string  `db:"message_type" json:"message_type"`
+	ReplyParentMessageID *string `db:"reply_parent_message_id" json:"reply_parent_message_id"`
+	IsDeleted            bool    `db:"is_deleted" json:"is_deleted"`
+	CreatedAt            string  `db:"created_at" json:"created_at"`
+}
+
+// GetChatMessages returns the stored chat messages matching the provided filter, newest first. Deleted messages are excluded, unless IsDeleted is set on the filter, in which case only deleted messages are returned.
+func (q *Query) GetChatMessages(m ChatMessage) (*DBResponse, error) {
+	r := []ChatMessage{}
+
+	sql := generateSQL("SELECT * FROM chat_messages", m, SEP_AND)
+	if !m.IsDeleted {
+		if strings.Contains(sql, " where ") {
+			sql += " and is_deleted = 0"
+		} else {
+			sql += " where is_deleted = 0"
+		}
+	}
+	sql += " ORDER BY created_at DESC" + q.SQL
+
+	rows, err := q.DB.NamedQuery(sql, m)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cm ChatMessage
+		err := rows.StructScan(&cm)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, cm)
+	}
+
+	dbr := DBResponse{
+		Data:  r,
+		Limit: q.Limit,
+		Total: len(r),
+	}
+
+	if len(r) != q.Limit {
+		q.PaginationCursor = ""
+	}
+
+	dbr.Cursor = q.PaginationCursor
+
+	return &dbr, err
+}
+
+// InsertChatMessage stores a chat message. Messages that were already stored (e.g. a message sent via the API that is then emitted as an event) are left untouched.
+func (q *Query) InsertChatMessage(m ChatMessage) error {
+	stmt := generateInsertSQL("chat_messages", "id", m, false)
+	stmt = strings.Replace(stmt, "insert into", "insert or ignore into", 1)
+	_, err := q.DB.NamedExec(stmt, m)
+	return err
+}
+
+// DeleteChatMessage marks a single message in the broadcaster's chat as deleted.
+func (q *Query) DeleteChatMessage(broadcaster string, id string) error {
+	_, err := q.DB.Exec("UPDATE chat_messages SET is_deleted = 1 WHERE broadcaster_id = $1 AND id = $2", broadcaster, id)
+	return err
+}
+
+// DeleteChatMessagesByUser marks all of a user's messages in the broadcaster's chat as deleted, such as when the user is banned.
+func (q *Query) DeleteChatMessagesByUser(broadcaster string, user string) error {
+	_, err := q.DB.Exec("UPDATE chat_messages SET is_deleted = 1 WHERE broadcaster_id = $1 AND user_id = $2", broadcaster, user)
+	return err
+}
+
+// ClearChatMessages marks all messages in the broadcaster's chat as deleted.
+func (q *Query) ClearChatMessages(broadcaster string) error {
+	_, err := q.DB.Exec("UPDATE chat_messages SET is_deleted = 1 WHERE broadcaster_id = $1", broadcaster)
+	return err
+}
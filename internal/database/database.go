@@ -30,6 +30,16 @@ func NewConnection(extendedBusyTimeout bool) (CLIDatabase, error) {
 	return CLIDatabase{DB: &db}, nil
 }
 
+// NewConnectionAt connects to the database at path rather than the CLI's own database, creating it if it doesn't exist.
+func NewConnectionAt(path string, extendedBusyTimeout bool) (CLIDatabase, error) {
+	db, err := openDatabase(path, extendedBusyTimeout)
+	if err != nil {
+		return CLIDatabase{}, err
+	}
+
+	return CLIDatabase{DB: &db}, nil
+}
+
 // extendedBusyTimeout sets an extended timeout for waiting on a busy database. This is mainly an issue in tests on WSL, so this flag shouldn't be used in production.
 func getDatabase(extendedBusyTimeout bool) (sqlx.DB, error) {
 	home, err := util.GetApplicationDir()
@@ -41,7 +51,10 @@ func getDatabase(extendedBusyTimeout bool) (sqlx.DB, error) {
 		dbFileName = viper.GetString("DB_FILENAME")
 	}
 
-	var path = filepath.Join(home, dbFileName)
+	return openDatabase(filepath.Join(home, dbFileName), extendedBusyTimeout)
+}
+
+func openDatabase(path string, extendedBusyTimeout bool) (sqlx.DB, error) {
 	var needToInit = false
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		needToInit = true
@@ -4,6 +4,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
@@ -131,6 +132,63 @@ func TestRetriveFromDB(t *testing.T) {
 
 	a.NotNil(dbResponse)
 	a.Equal("test", dbResponse.Transport)
+
+	allEvents, err := q.GetEvents()
+	a.Nil(err)
+	found := false
+	for _, e := range allEvents {
+		if e.ID == ecParams.ID {
+			found = true
+			a.Equal("1234", e.FromUser)
+			a.Equal("5678", e.ToUser)
+		}
+	}
+	a.True(found)
+
+	err = q.DeleteEvents([]string{ecParams.ID})
+	a.Nil(err)
+
+	_, err = q.GetEventByID(ecParams.ID)
+	a.NotNil(err)
+}
+
+func TestGetEventsOrder(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	events, err := NewConnectionAt(filepath.Join(t.TempDir(), "events.db"), true)
+	a.Nil(err)
+	defer events.DB.Close()
+	q := events.NewQuery(nil, 100)
+
+	// as strings, these would sort in the opposite order
+	for id, timestamp := range map[string]string{
+		"newest": "2024-01-01T00:00:00.1Z",
+		"middle": "2024-01-01T00:00:00Z",
+		"oldest": "2024-01-01T01:00:00+02:00",
+		"broken": "yesterday",
+	} {
+		a.Nil(q.InsertIntoDB(EventCacheParameters{ID: id, Event: "foo", JSON: "{}", Transport: "test", Timestamp: timestamp}))
+	}
+
+	all, err := q.GetEvents()
+	a.Nil(err)
+	ids := []string{}
+	for _, e := range all {
+		ids = append(ids, e.ID)
+	}
+	a.Equal([]string{"newest", "middle", "oldest", "broken"}, ids)
+}
+
+func TestDeleteEventsError(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	events, err := NewConnectionAt(filepath.Join(t.TempDir(), "events.db"), true)
+	a.Nil(err)
+	defer events.DB.Close()
+
+	_, err = events.DB.Exec("drop table events")
+	a.Nil(err)
+	a.NotNil(events.NewQuery(nil, 100).DeleteEvents([]string{"foo"}))
 }
 
 func TestGenerateString(t *testing.T) {
@@ -447,6 +505,93 @@ func TestModeration(t *testing.T) {
 
 }
 
+func TestChatMessages(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	m := ChatMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: TEST_USER_ID,
+		UserID:        TEST_USER_ID_99,
+		UserLogin:     TEST_USER_LOGIN_99,
+		UserName:      TEST_USER_LOGIN_99,
+		Text:          "hello chat",
+		MessageType:   "text",
+		CreatedAt:     util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err := q.InsertChatMessage(m)
+	a.Nil(err)
+
+	// inserting the same message twice is a no-op
+	err = q.InsertChatMessage(m)
+	a.Nil(err)
+
+	dbr, err := q.GetChatMessages(ChatMessage{ID: m.ID})
+	a.Nil(err)
+	messages := dbr.Data.([]ChatMessage)
+	a.Len(messages, 1)
+	a.Equal("hello chat", messages[0].Text)
+
+	err = q.DeleteChatMessage(TEST_USER_ID, m.ID)
+	a.Nil(err)
+
+	dbr, err = q.GetChatMessages(ChatMessage{ID: m.ID})
+	a.Nil(err)
+	a.Len(dbr.Data.([]ChatMessage), 0)
+
+	dbr, err = q.GetChatMessages(ChatMessage{ID: m.ID, IsDeleted: true})
+	a.Nil(err)
+	a.Len(dbr.Data.([]ChatMessage), 1)
+
+	m.ID = util.RandomGUID()
+	err = q.InsertChatMessage(m)
+	a.Nil(err)
+
+	err = q.DeleteChatMessagesByUser(TEST_USER_ID, TEST_USER_ID_99)
+	a.Nil(err)
+
+	dbr, err = q.GetChatMessages(ChatMessage{BroadcasterID: TEST_USER_ID, UserID: TEST_USER_ID_99})
+	a.Nil(err)
+	a.Len(dbr.Data.([]ChatMessage), 0)
+
+	err = q.ClearChatMessages(TEST_USER_ID)
+	a.Nil(err)
+}
+
+func TestAutomodMessages(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	m := AutomodMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: TEST_USER_ID,
+		UserID:        TEST_USER_ID_99,
+		UserLogin:     TEST_USER_LOGIN_99,
+		UserName:      TEST_USER_LOGIN_99,
+		Text:          "held message",
+		Category:      "swearing",
+		Level:         2,
+		HeldAt:        util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err := q.InsertAutomodMessage(m)
+	a.Nil(err)
+
+	// inserting the same message twice is a no-op
+	err = q.InsertAutomodMessage(m)
+	a.Nil(err)
+
+	dbr, err := q.GetAutomodMessages(AutomodMessage{ID: m.ID})
+	a.Nil(err)
+	messages := dbr.Data.([]AutomodMessage)
+	a.Len(messages, 1)
+	a.Equal(AutomodStatusPending, messages[0].Status)
+
+	err = q.UpdateAutomodMessageStatus(m.ID, AutomodStatusApproved)
+	a.Nil(err)
+
+	dbr, err = q.GetAutomodMessages(AutomodMessage{ID: m.ID})
+	a.Nil(err)
+	a.Equal(AutomodStatusApproved, dbr.Data.([]AutomodMessage)[0].Status)
+}
+
 func TestPolls(t *testing.T) {
 	a := test_setup.SetupTestEnv(t)
 	id := util.RandomGUID()
@@ -490,6 +635,23 @@ func TestPolls(t *testing.T) {
 	polls := dbr.Data.([]Poll)
 	a.GreaterOrEqual(len(polls), 1)
 	a.Equal("test2", polls[0].Title)
+
+	choice := poll.Choices[0]
+	choice.Votes = 12
+	choice.ChannelPointsVotes = 3
+	choice.BitsVotes = 4
+	err = q.SetPollChoiceVotes(choice)
+	a.Nil(err)
+
+	dbr, err = q.GetPolls(Poll{ID: id})
+	a.Nil(err)
+	for _, c := range dbr.Data.([]Poll)[0].Choices {
+		if c.ID == choice.ID {
+			a.Equal(12, c.Votes)
+			a.Equal(3, c.ChannelPointsVotes)
+			a.Equal(4, c.BitsVotes)
+		}
+	}
 }
 
 func TestPredictions(t *testing.T) {
@@ -787,3 +949,32 @@ func TestVideos(t *testing.T) {
 	err = q.DeleteVideo(vms.VideoID)
 	a.Nil(err)
 }
+
+func TestExtensions(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	client, err := q.InsertOrUpdateAuthenticationClient(AuthenticationClient{ID: util.RandomClientID(), Name: "for_testing", IsExtension: true}, false)
+	a.Nil(err)
+	key, err := base64.StdEncoding.DecodeString(client.Secret)
+	a.Nil(err)
+	a.Len(key, 32)
+
+	c := ExtensionConfiguration{ExtensionID: client.ID, Segment: "broadcaster", BroadcasterID: TEST_USER_ID, Content: "hello", Version: "1"}
+	a.Nil(q.SetExtensionConfiguration(c))
+	c.Content = "world"
+	a.Nil(q.SetExtensionConfiguration(c))
+
+	stored, err := q.GetExtensionConfiguration(client.ID, "broadcaster", TEST_USER_ID)
+	a.Nil(err)
+	a.Equal(c, stored)
+
+	stored, err = q.GetExtensionConfiguration(client.ID, "global", "")
+	a.Nil(err)
+	a.Empty(stored.Segment)
+
+	rc := ExtensionRequiredConfiguration{ExtensionID: client.ID, BroadcasterID: TEST_USER_ID, ExtensionVersion: "0.0.1", RequiredConfiguration: "config"}
+	a.Nil(q.SetExtensionRequiredConfiguration(rc))
+	storedRC, err := q.GetExtensionRequiredConfiguration(client.ID, TEST_USER_ID, "0.0.1")
+	a.Nil(err)
+	a.Equal(rc, storedRC)
+}
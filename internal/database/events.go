@@ -2,6 +2,11 @@
 // SPDX-License-Identifier: Apache-2.0
 package database
 
+import (
+	"sort"
+	"time"
+)
+
 // EventCacheParameters is used to define required parameters when writing into the database
 type EventCacheParameters struct {
 	ID        string `db:"id"`
@@ -18,6 +23,8 @@ type EventCacheResponse struct {
 	ID        string
 	Event     string
 	JSON      string
+	FromUser  string `db:"from_user"`
+	ToUser    string `db:"to_user"`
 	Transport string
 	Timestamp string
 }
@@ -41,10 +48,55 @@ func (q *Query) GetEventByID(id string) (EventCacheResponse, error) {
 	db := q.DB
 	var r EventCacheResponse
 
-	err := db.Get(&r, "select id, json, transport, event from events where id = $1", id)
+	err := db.Get(&r, "select id, event, json, from_user, to_user, transport, timestamp from events where id = $1", id)
 	if err != nil {
 		return r, err
 	}
 
 	return r, err
 }
+
+// GetEvents returns every stored event, newest first.
+func (q *Query) GetEvents() ([]EventCacheResponse, error) {
+	db := q.DB
+	r := []EventCacheResponse{}
+
+	err := db.Select(&r, "select id, event, json, from_user, to_user, transport, timestamp from events")
+	if err != nil {
+		return r, err
+	}
+
+	// Timestamps are stored as RFC3339 strings, which don't sort by time when their fractions or offsets differ.
+	// Events with timestamps that can't be parsed are last.
+	times := make(map[string]time.Time, len(r))
+	for _, e := range r {
+		if t, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			times[e.ID] = t
+		}
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		a, aOK := times[r[i].ID]
+		b, bOK := times[r[j].ID]
+		if aOK != bOK {
+			return aOK
+		}
+		return a.After(b)
+	})
+
+	return r, nil
+}
+
+// DeleteEvents removes stored events by their IDs.
+func (q *Query) DeleteEvents(ids []string) error {
+	db := q.DB
+
+	tx := db.MustBegin()
+	for _, id := range ids {
+		_, err := tx.Exec("delete from events where id = $1", id)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package database
+
+// ExtensionConfiguration is a configuration segment of an Extension. Global segments have no broadcaster ID.
+type ExtensionConfiguration struct {
+	ExtensionID   string `db:"extension_id" json:"-"`
+	Segment       string `db:"segment" json:"segment"`
+	BroadcasterID string `db:"broadcaster_id" json:"broadcaster_id,omitempty"`
+	Content       string `db:"content" json:"content"`
+	Version       string `db:"version" json:"version"`
+}
+
+// ExtensionRequiredConfiguration is the configuration a broadcaster must set before an Extension version can be activated on their channel.
+type ExtensionRequiredConfiguration struct {
+	ExtensionID           string `db:"extension_id" json:"extension_id"`
+	BroadcasterID         string `db:"broadcaster_id" json:"broadcaster_id"`
+	ExtensionVersion      string `db:"extension_version" json:"extension_version"`
+	RequiredConfiguration string `db:"required_configuration" json:"required_configuration"`
+}
+
+// GetExtensionConfiguration returns a configuration segment, or an empty one if it hasn't been set.
+func (q *Query) GetExtensionConfiguration(extensionID string, segment string, broadcasterID string) (ExtensionConfiguration, error) {
+	r := []ExtensionConfiguration{}
+	err := q.DB.Select(&r, "SELECT * FROM extension_configurations WHERE extension_id = $1 AND segment = $2 AND broadcaster_id = $3", extensionID, segment, broadcasterID)
+	if err != nil || len(r) == 0 {
+		return ExtensionConfiguration{}, err
+	}
+	return r[0], nil
+}
+
+// SetExtensionConfiguration stores a configuration segment, replacing the segment if it was already set.
+func (q *Query) SetExtensionConfiguration(c ExtensionConfiguration) error {
+	_, err := q.DB.NamedExec(`INSERT INTO extension_configurations VALUES(:extension_id, :segment, :broadcaster_id, :content, :version)
+ON CONFLICT(extension_id, segment, broadcaster_id) DO UPDATE SET content = :content, version = :version`, c)
+	return err
+}
+
+// SetExtensionRequiredConfiguration stores the required configuration of an Extension version on a channel.
+func (q *Query) SetExtensionRequiredConfiguration(c ExtensionRequiredConfiguration) error {
+	_, err := q.DB.NamedExec(`INSERT INTO extension_required_configurations VALUES(:extension_id, :broadcaster_id, :extension_version, :required_configuration)
+ON CONFLICT(extension_id, broadcaster_id, extension_version) DO UPDATE SET required_configuration = :required_configuration`, c)
+	return err
+}
+
+// GetExtensionRequiredConfiguration returns the required configuration of an Extension version on a channel, or an empty one if it hasn't been set.
+func (q *Query) GetExtensionRequiredConfiguration(extensionID string, broadcasterID string, extensionVersion string) (ExtensionRequiredConfiguration, error) {
+	r := []ExtensionRequiredConfiguration{}
+	err := q.DB.Select(&r, "SELECT * FROM extension_required_configurations WHERE extension_id = $1 AND broadcaster_id = $2 AND extension_version = $3", extensionID, broadcasterID, extensionVersion)
+	if err != nil || len(r) == 0 {
+		return ExtensionRequiredConfiguration{}, err
+	}
+	return r[0], nil
+}
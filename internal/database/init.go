@@ -10,7 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-const currentVersion = 7
+const currentVersion = 10
 
 type migrateMap struct {
 	SQL     string
@@ -57,6 +57,18 @@ ALTER TABLE users ADD COLUMN content_labels text not null default '';`,
 		SQL:     `ALTER TABLE stream_schedule DROP COLUMN timezone;`,
 		Message: `Removing deprecated stream_schedule.timezone from database`,
 	},
+	8: {
+		SQL:     `CREATE TABLE chat_messages ( id text not null primary key, broadcaster_id text not null, user_id text not null, user_login text not null default '', user_name text not null default '', message_text text not null, message_type text not null default 'text', reply_parent_message_id text, is_deleted boolean not null default 0, created_at text not null );`,
+		Message: `Adding chat message storage to database.`,
+	},
+	9: {
+		SQL:     `CREATE TABLE automod_messages ( id text not null primary key, broadcaster_id text not null, user_id text not null, user_login text not null default '', user_name text not null default '', message_text text not null, category text not null default '', level int not null default 0, status text not null default 'pending', held_at text not null );`,
+		Message: `Adding AutoMod held message storage to database.`,
+	},
+	10: {
+		SQL:     `CREATE TABLE extension_configurations ( extension_id text not null, segment text not null, broadcaster_id text not null default '', content text not null default '', version text not null default '', primary key (extension_id, segment, broadcaster_id) ); CREATE TABLE extension_required_configurations ( extension_id text not null, broadcaster_id text not null, extension_version text not null, required_configuration text not null default '', primary key (extension_id, broadcaster_id, extension_version) );`,
+		Message: `Adding Extension configuration storage to database.`,
+	},
 }
 
 func checkAndUpdate(db sqlx.DB) error {
@@ -126,7 +138,11 @@ create table prediction_predictions ( prediction_id text not null, user_id text
 create table clips ( id text not null primary key, broadcaster_id text not null, creator_id text not null, video_id text not null, game_id text not null, title text not null, view_count int default 0, created_at text not null, duration real not null, vod_offset int default 0, foreign key (broadcaster_id) references users(id), foreign key (creator_id) references users(id) );
 create table stream_schedule( id text not null primary key, broadcaster_id text not null, starttime text not null, endtime text not null, is_vacation boolean not null default false, is_recurring boolean not null default false, is_canceled boolean not null default false, title text, category_id text, foreign key(broadcaster_id) references users(id), foreign key (category_id) references categories(id));
 create table chat_settings( broadcaster_id text not null primary key, slow_mode boolean not null default 0, slow_mode_wait_time int not null default 10, follower_mode boolean not null default 0, follower_mode_duration int not null default 60, subscriber_mode boolean not null default 0, emote_mode boolean not null default 0, unique_chat_mode boolean not null default 0, non_moderator_chat_delay boolean not null default 0, non_moderator_chat_delay_duration int not null default 10, shieldmode_is_active boolean not null default 0, shieldmode_moderator_id text not null default '', shieldmode_moderator_login text not null default '', shieldmode_moderator_name text not null default '', shieldmode_last_activated text not null default '' );
-create table vips ( broadcaster_id text not null, user_id text not null, created_at text not null default '', primary key (broadcaster_id, user_id), foreign key (broadcaster_id) references users(id), foreign key (user_id) references users(id) );`
+create table vips ( broadcaster_id text not null, user_id text not null, created_at text not null default '', primary key (broadcaster_id, user_id), foreign key (broadcaster_id) references users(id), foreign key (user_id) references users(id) );
+create table chat_messages ( id text not null primary key, broadcaster_id text not null, user_id text not null, user_login text not null default '', user_name text not null default '', message_text text not null, message_type text not null default 'text', reply_parent_message_id text, is_deleted boolean not null default 0, created_at text not null );
+create table automod_messages ( id text not null primary key, broadcaster_id text not null, user_id text not null, user_login text not null default '', user_name text not null default '', message_text text not null, category text not null default '', level int not null default 0, status text not null default 'pending', held_at text not null );
+create table extension_configurations ( extension_id text not null, segment text not null, broadcaster_id text not null default '', content text not null default '', version text not null default '', primary key (extension_id, segment, broadcaster_id) );
+create table extension_required_configurations ( extension_id text not null, broadcaster_id text not null, extension_version text not null, required_configuration text not null default '', primary key (extension_id, broadcaster_id, extension_version) );`
 
 	for i := 1; i <= 5; i++ {
 		tx := db.MustBegin()
@@ -180,6 +180,8 @@ func (q *Query) InsertBan(p UserRequestParams) error {
 	tx := q.DB.MustBegin()
 	tx.NamedExec(stmt, p)
 	tx.NamedExec(`INSERT INTO ban_events VALUES(:id, :event_timestamp, :event_type, :event_version, :broadcaster_id, :user_id, :expires_at)`, ma)
+	// Banned users have their messages removed from chat
+	tx.Exec(`UPDATE chat_messages SET is_deleted = 1 WHERE broadcaster_id = $1 AND user_id = $2`, p.BroadcasterID, p.UserID)
 	return tx.Commit()
 }
 
@@ -89,3 +89,9 @@ func (q *Query) UpdatePollChoice(p PollsChoice) error {
 	_, err := q.DB.Exec("update poll_choices set votes = votes + 1 where id = $1", p.ID)
 	return err
 }
+
+// SetPollChoiceVotes sets the vote totals of a choice, rather than adding a single vote as UpdatePollChoice does.
+func (q *Query) SetPollChoiceVotes(c PollsChoice) error {
+	_, err := q.DB.NamedExec("update poll_choices set votes = :votes, channel_points_votes = :channel_points_votes, bits_votes = :bits_votes where id = :id", c)
+	return err
+}
@@ -30,6 +30,14 @@ type MockEventParameters struct {
 	ClientID            string
 	BanStartTimestamp   string
 	BanEndTimestamp     string
+	MessageText         string
+	NoticeType          string
+	Color               string
+	ModerateAction      string
+	ModeratorUserID     string
+	ModeratorUserName   string
+	RewardType          string
+	ParticipantIDs      []string
 }
 
 type MockEventResponse struct {
@@ -0,0 +1,222 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	ExportFormatJSONL = "jsonl"
+	ExportFormatHAR   = "har"
+)
+
+// ExportParameters are used when writing the event history to a file.
+type ExportParameters struct {
+	Format         string
+	ForwardAddress string // Request URL used in HAR exports
+	Secret         string // If set, HAR requests include the signature headers
+}
+
+type exportedEvent struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	Transport string          `json:"transport"`
+	FromUser  string          `json:"from_user"`
+	ToUser    string          `json:"to_user"`
+	Timestamp string          `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int         `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    harPostData    `json:"postData"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// Events aren't sent when exporting, so every entry has an empty response.
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+type harTimings struct {
+	Send    int `json:"send"`
+	Wait    int `json:"wait"`
+	Receive int `json:"receive"`
+}
+
+// Export writes the given events to w in the requested format.
+func Export(w io.Writer, events []database.EventCacheResponse, p ExportParameters) error {
+	switch p.Format {
+	case ExportFormatJSONL, "":
+		return exportJSONL(w, events)
+	case ExportFormatHAR:
+		return exportHAR(w, events, p)
+	default:
+		return fmt.Errorf("Invalid format %q. Valid formats: %v, %v", p.Format, ExportFormatJSONL, ExportFormatHAR)
+	}
+}
+
+// exportJSONL writes one event per line.
+func exportJSONL(w io.Writer, events []database.EventCacheResponse) error {
+	encoder := json.NewEncoder(w)
+	for _, e := range events {
+		err := encoder.Encode(exportedEvent{
+			ID:        e.ID,
+			Event:     e.Event,
+			Transport: e.Transport,
+			FromUser:  e.FromUser,
+			ToUser:    e.ToUser,
+			Timestamp: e.Timestamp,
+			Payload:   payloadJSON(e.JSON),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportHAR writes the events as the webhook requests that would deliver them, so they can be replayed with any
+// tool that understands HTTP Archives.
+func exportHAR(w io.Writer, events []database.EventCacheResponse, p ExportParameters) error {
+	forwardAddress := p.ForwardAddress
+	if forwardAddress == "" {
+		forwardAddress = "http://localhost:8080/eventsub"
+	}
+
+	out := har{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "twitch-cli", Version: util.GetVersion()},
+			Entries: []harEntry{},
+		},
+	}
+
+	for _, e := range events {
+		var payload models.EventsubResponse
+		json.Unmarshal([]byte(e.JSON), &payload)
+
+		req, err := trigger.NewForwardRequest(trigger.ForwardParamters{
+			ID:                  e.ID,
+			ForwardAddress:      forwardAddress,
+			JSON:                []byte(e.JSON),
+			Transport:           models.TransportWebhook,
+			Timestamp:           e.Timestamp,
+			Secret:              p.Secret,
+			Event:               payload.Subscription.Type,
+			Type:                trigger.EventSubMessageTypeNotification,
+			SubscriptionVersion: payload.Subscription.Version,
+		})
+		if err != nil {
+			return err
+		}
+
+		headers := []harNameValue{}
+		for name, values := range req.Header {
+			for _, value := range values {
+				headers = append(headers, harNameValue{Name: name, Value: value})
+			}
+		}
+		sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+
+		out.Log.Entries = append(out.Log.Entries, harEntry{
+			StartedDateTime: e.Timestamp,
+			Time:            0,
+			Request: harRequest{
+				Method:      req.Method,
+				URL:         req.URL.String(),
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     headers,
+				QueryString: []harNameValue{},
+				PostData:    harPostData{MimeType: "application/json", Text: e.JSON},
+				HeadersSize: -1,
+				BodySize:    len(e.JSON),
+			},
+			Response: harResponse{
+				Cookies:     []harNameValue{},
+				Headers:     []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Timings: harTimings{Send: 0, Wait: 0, Receive: 0},
+			Comment: fmt.Sprintf("%v event %v, originally sent via %v", e.Event, e.ID, e.Transport),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// payloadJSON returns the stored payload as raw JSON, falling back to a JSON string if it was stored malformed.
+func payloadJSON(payload string) json.RawMessage {
+	if json.Valid([]byte(payload)) {
+		return json.RawMessage(payload)
+	}
+	b, _ := json.Marshal(payload)
+	return b
+}
@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package history
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/types"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Filter narrows down the events returned from the event history. Empty fields match everything.
+type Filter struct {
+	Event     string    // Trigger or topic name; "cheer" and "channel.cheer" are equivalent
+	Transport string    // Transport the event was triggered with
+	User      string    // User ID matching either the sender or receiver of the event
+	Since     time.Time // Only include events at or after this time
+	Until     time.Time // Only include events at or before this time
+	Limit     int       // Maximum number of events returned, starting with the newest. 0 for no limit.
+}
+
+var relativeDurationRegex = regexp.MustCompile(`^([0-9]+)d$`)
+
+// GetEvents returns the stored events matching the filter, newest first.
+func GetEvents(f Filter) ([]database.EventCacheResponse, error) {
+	allEvents, err := getAllEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	eventNames := equivalentEventNames(f.Event)
+
+	matches := []database.EventCacheResponse{}
+	for _, e := range allEvents {
+		if eventNames != nil && !eventNames[strings.ToLower(e.Event)] {
+			continue
+		}
+		if f.Transport != "" && !strings.EqualFold(f.Transport, e.Transport) {
+			continue
+		}
+		if f.User != "" && f.User != e.FromUser && f.User != e.ToUser {
+			continue
+		}
+		if !f.Since.IsZero() || !f.Until.IsZero() {
+			ts, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+			if err != nil {
+				continue
+			}
+			if !f.Since.IsZero() && ts.Before(f.Since) {
+				continue
+			}
+			if !f.Until.IsZero() && ts.After(f.Until) {
+				continue
+			}
+		}
+
+		matches = append(matches, e)
+		if f.Limit > 0 && len(matches) >= f.Limit {
+			break
+		}
+	}
+
+	return matches, nil
+}
+
+// GetEvent returns a single stored event by its ID.
+func GetEvent(id string) (database.EventCacheResponse, error) {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return database.EventCacheResponse{}, err
+	}
+	defer db.DB.Close()
+
+	e, err := db.NewQuery(nil, 100).GetEventByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return e, fmt.Errorf("No event with ID %v found in event history", id)
+	}
+	return e, err
+}
+
+// Prune deletes all stored events triggered before the given time, returning how many were deleted. Events whose
+// timestamps can't be parsed are kept, since their age isn't known, and their IDs are returned.
+func Prune(olderThan time.Time) (int, []string, error) {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer db.DB.Close()
+
+	allEvents, err := db.NewQuery(nil, 100).GetEvents()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ids := []string{}
+	unparsed := []string{}
+	for _, e := range allEvents {
+		ts, err := time.Parse(time.RFC3339Nano, e.Timestamp)
+		if err != nil {
+			unparsed = append(unparsed, e.ID)
+			continue
+		}
+		if ts.Before(olderThan) {
+			ids = append(ids, e.ID)
+		}
+	}
+
+	err = db.NewQuery(nil, 100).DeleteEvents(ids)
+	if err != nil {
+		return 0, unparsed, err
+	}
+
+	return len(ids), unparsed, nil
+}
+
+// ParseTime parses a point in time given on the command line. This is either an RFC3339 timestamp, or a duration
+// relative to now, such as "90m", "12h", or "7d".
+func ParseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if matches := relativeDurationRegex.FindStringSubmatch(value); matches != nil {
+		days, _ := strconv.Atoi(matches[1])
+		return util.GetTimestamp().Add(-time.Duration(days) * 24 * time.Hour), nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return util.GetTimestamp().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid time %q. Must be an RFC3339 timestamp, or a duration such as 30m, 12h, or 7d", value)
+	}
+	return t, nil
+}
+
+func getAllEvents() ([]database.EventCacheResponse, error) {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.DB.Close()
+
+	return db.NewQuery(nil, 100).GetEvents()
+}
+
+// equivalentEventNames returns every name an event may have been stored under, since events can be triggered by
+// either their trigger name or their EventSub topic.
+func equivalentEventNames(event string) map[string]bool {
+	if event == "" {
+		return nil
+	}
+
+	names := map[string]bool{strings.ToLower(event): true}
+	for _, e := range types.AllEvents() {
+		trigger := event
+		if alias := e.GetEventSubAlias(event); alias != "" {
+			trigger = alias
+		}
+		if !e.ValidTrigger(trigger) {
+			continue
+		}
+
+		names[strings.ToLower(trigger)] = true
+		for _, transport := range []string{models.TransportWebhook, models.TransportWebSocket} {
+			if topic := e.GetTopic(transport, trigger); topic != "" {
+				names[strings.ToLower(topic)] = true
+			}
+		}
+	}
+	return names
+}
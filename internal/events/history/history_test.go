@@ -0,0 +1,181 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestGetEvents(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	start := util.GetTimestamp()
+	user := util.RandomUserID()
+
+	_, err := trigger.Fire(trigger.TriggerParameters{Event: "cheer", Transport: models.TransportWebhook, ToUser: user, SubscriptionStatus: "enabled", Cost: 100})
+	a.Nil(err)
+	_, err = trigger.Fire(trigger.TriggerParameters{Event: "channel.cheer", Transport: models.TransportWebhook, ToUser: user, SubscriptionStatus: "enabled", Cost: 100})
+	a.Nil(err)
+	_, err = trigger.Fire(trigger.TriggerParameters{Event: "follow", Transport: models.TransportWebhook, ToUser: user, SubscriptionStatus: "enabled"})
+	a.Nil(err)
+
+	events, err := GetEvents(Filter{User: user, Since: start})
+	a.Nil(err)
+	a.Len(events, 3)
+	a.Equal("follow", events[0].Event)
+
+	// Trigger names and topics match each other
+	events, err = GetEvents(Filter{User: user, Event: "cheer"})
+	a.Nil(err)
+	a.Len(events, 2)
+
+	events, err = GetEvents(Filter{User: user, Event: "channel.cheer", Transport: models.TransportWebhook})
+	a.Nil(err)
+	a.Len(events, 2)
+
+	events, err = GetEvents(Filter{User: user, Transport: models.TransportWebSocket})
+	a.Nil(err)
+	a.Len(events, 0)
+
+	events, err = GetEvents(Filter{User: user, Limit: 1})
+	a.Nil(err)
+	a.Len(events, 1)
+
+	events, err = GetEvents(Filter{User: user, Until: start.Add(-time.Minute)})
+	a.Nil(err)
+	a.Len(events, 0)
+
+	events, err = GetEvents(Filter{User: user})
+	a.Nil(err)
+	e, err := GetEvent(events[0].ID)
+	a.Nil(err)
+	a.Equal(user, e.ToUser)
+
+	_, err = GetEvent("notarealid")
+	a.NotNil(err)
+}
+
+func TestExport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	user := util.RandomUserID()
+	_, err := trigger.Fire(trigger.TriggerParameters{Event: "subscribe", Transport: models.TransportWebhook, ToUser: user, SubscriptionStatus: "enabled"})
+	a.Nil(err)
+
+	events, err := GetEvents(Filter{User: user})
+	a.Nil(err)
+	a.Len(events, 1)
+
+	var buf bytes.Buffer
+	err = Export(&buf, events, ExportParameters{Format: ExportFormatJSONL})
+	a.Nil(err)
+
+	scanner := bufio.NewScanner(&buf)
+	a.True(scanner.Scan())
+	var line exportedEvent
+	a.Nil(json.Unmarshal(scanner.Bytes(), &line))
+	a.Equal(events[0].ID, line.ID)
+	a.Equal(user, line.ToUser)
+	a.False(scanner.Scan())
+
+	buf.Reset()
+	err = Export(&buf, events, ExportParameters{Format: ExportFormatHAR, ForwardAddress: "http://localhost:1234/eventsub", Secret: "potatopotato"})
+	a.Nil(err)
+
+	var archive har
+	a.Nil(json.Unmarshal(buf.Bytes(), &archive))
+	a.Equal("1.2", archive.Log.Version)
+	a.Len(archive.Log.Entries, 1)
+
+	request := archive.Log.Entries[0].Request
+	a.Equal("POST", request.Method)
+	a.Equal("http://localhost:1234/eventsub", request.URL)
+	a.Equal(events[0].JSON, request.PostData.Text)
+
+	headers := map[string]string{}
+	for _, h := range request.Headers {
+		headers[h.Name] = h.Value
+	}
+	a.Equal("channel.subscribe", headers["Twitch-Eventsub-Subscription-Type"])
+	a.Equal(events[0].ID, headers["Twitch-Eventsub-Message-Id"])
+	a.True(strings.HasPrefix(headers["Twitch-Eventsub-Message-Signature"], "sha256="))
+
+	err = Export(&buf, events, ExportParameters{Format: "xml"})
+	a.NotNil(err)
+}
+
+func TestParseTime(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	now := util.GetTimestamp()
+
+	ts, err := ParseTime("7d")
+	a.Nil(err)
+	a.WithinDuration(now.Add(-7*24*time.Hour), ts, time.Minute)
+
+	ts, err = ParseTime("90m")
+	a.Nil(err)
+	a.WithinDuration(now.Add(-90*time.Minute), ts, time.Minute)
+
+	ts, err = ParseTime("2023-01-02T03:04:05Z")
+	a.Nil(err)
+	a.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), ts.UTC())
+
+	ts, err = ParseTime("")
+	a.Nil(err)
+	a.True(ts.IsZero())
+
+	_, err = ParseTime("yesterday")
+	a.NotNil(err)
+}
+
+func TestPrune(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	user := util.RandomUserID()
+	_, err := trigger.Fire(trigger.TriggerParameters{
+		Event:              "follow",
+		Transport:          models.TransportWebhook,
+		ToUser:             user,
+		SubscriptionStatus: "enabled",
+		Timestamp:          util.GetTimestamp().Add(-48 * time.Hour).Format(time.RFC3339Nano),
+	})
+	a.Nil(err)
+	_, err = trigger.Fire(trigger.TriggerParameters{Event: "follow", Transport: models.TransportWebhook, ToUser: user, SubscriptionStatus: "enabled"})
+	a.Nil(err)
+
+	// events with timestamps that can't be parsed are kept
+	db, err := database.NewConnection(false)
+	a.Nil(err)
+	unparsable := util.RandomGUID()
+	err = db.NewQuery(nil, 100).InsertIntoDB(database.EventCacheParameters{ID: unparsable, Event: "follow", JSON: "{}", ToUser: user, Transport: models.TransportWebhook, Timestamp: "yesterday"})
+	db.DB.Close()
+	a.Nil(err)
+
+	deleted, unparsed, err := Prune(util.GetTimestamp().Add(-24 * time.Hour))
+	a.Nil(err)
+	a.GreaterOrEqual(deleted, 1)
+	a.Contains(unparsed, unparsable)
+
+	events, err := GetEvents(Filter{User: user})
+	a.Nil(err)
+	a.Len(events, 2)
+	_, err = GetEvent(unparsable)
+	a.Nil(err)
+
+	db, err = database.NewConnection(false)
+	a.Nil(err)
+	defer db.DB.Close()
+	a.Nil(db.NewQuery(nil, 100).DeleteEvents([]string{unparsable}))
+}
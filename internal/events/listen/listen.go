@@ -0,0 +1,270 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package listen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/TylerBrock/colorjson"
+	"github.com/fatih/color"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/request"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/pkg/eventsub"
+)
+
+// Twitch recommends rejecting any message with a timestamp older than 10 minutes.
+const DefaultMaxMessageAge = 10 * time.Minute
+
+type ListenParameters struct {
+	IP             string
+	Port           int
+	Secret         string        // If set, every message must be signed with this secret
+	ForwardAddress string        // If set, verified notifications are forwarded to this URL
+	MaxMessageAge  time.Duration // Messages with older timestamps are rejected. 0 disables the check.
+	Output         io.Writer     // Where received events are printed; defaults to stdout
+}
+
+// Listener is a webhook receiver that handles EventSub messages the same way a production callback should.
+type Listener struct {
+	params ListenParameters
+
+	muSeen sync.Mutex
+	seen   map[string]time.Time // Message IDs already handled, by the time they were received
+}
+
+type webhookMessage struct {
+	Challenge    string `json:"challenge"`
+	Subscription struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Version string `json:"version"`
+		Status  string `json:"status"`
+	} `json:"subscription"`
+}
+
+func NewListener(p ListenParameters) *Listener {
+	if p.Output == nil {
+		p.Output = os.Stdout
+	}
+
+	return &Listener{
+		params: p,
+		seen:   map[string]time.Time{},
+	}
+}
+
+// StartListener receives webhooks until the process is interrupted.
+func StartListener(p ListenParameters) error {
+	listener := NewListener(p)
+
+	listen, err := net.Listen("tcp", fmt.Sprintf("%v:%v", p.IP, p.Port))
+	if err != nil {
+		return fmt.Errorf("Cannot start webhook listener: %v", err)
+	}
+
+	// Allow exit with Ctrl + C
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+
+	server := &http.Server{Handler: listener}
+	go func() {
+		if err := server.Serve(listen); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Cannot start webhook listener: %v", err)
+		}
+	}()
+
+	lightBlue := color.New(color.FgHiBlue).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	log.Printf(lightBlue("Listening for webhooks on ")+"http://%v:%v", p.IP, p.Port)
+	if p.Secret == "" {
+		log.Println(yellow("No secret set; message signatures won't be verified."))
+	}
+	if p.ForwardAddress != "" {
+		log.Printf(lightBlue("Forwarding verified notifications to ")+"%v", p.ForwardAddress)
+	}
+	fmt.Println()
+	log.Println(yellow("Send events with: twitch event trigger <event> -F http://localhost:" + fmt.Sprint(p.Port) + " -s <secret>"))
+
+	<-stop
+	log.Println("Shutting down webhook listener ...")
+	return server.Close()
+}
+
+func (l *Listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		l.reject(w, http.StatusBadRequest, "Unable to read request body: %v", err)
+		return
+	}
+
+	messageID := r.Header.Get("Twitch-Eventsub-Message-Id")
+	messageType := r.Header.Get("Twitch-Eventsub-Message-Type")
+	timestamp := r.Header.Get("Twitch-Eventsub-Message-Timestamp")
+
+	if messageID == "" || messageType == "" {
+		l.reject(w, http.StatusBadRequest, "Missing Twitch-Eventsub-Message-Id or Twitch-Eventsub-Message-Type header")
+		return
+	}
+
+	if l.params.Secret != "" {
+		if !eventsub.ValidSignature(l.params.Secret, r.Header, body) {
+			l.reject(w, http.StatusForbidden, "Invalid signature for message %v", messageID)
+			return
+		}
+	}
+
+	if l.params.MaxMessageAge > 0 && timestamp != "" {
+		ts, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			l.reject(w, http.StatusBadRequest, "Invalid Twitch-Eventsub-Message-Timestamp %q", timestamp)
+			return
+		}
+		now := util.GetTimestamp()
+		if now.Sub(ts) > l.params.MaxMessageAge {
+			l.reject(w, http.StatusForbidden, "Stale message %v; timestamp %v is older than %v", messageID, timestamp, l.params.MaxMessageAge)
+			return
+		}
+		if ts.After(now) {
+			l.reject(w, http.StatusForbidden, "Message %v has timestamp %v, which is in the future", messageID, timestamp)
+			return
+		}
+	}
+
+	var message webhookMessage
+	err = json.Unmarshal(body, &message)
+	if err != nil {
+		l.reject(w, http.StatusBadRequest, "Unable to parse message body: %v", err)
+		return
+	}
+
+	// The challenge is answered every time, since the subscription isn't enabled until Twitch receives it
+	if messageType == trigger.EventSubMessageTypeVerification {
+		l.printf(color.New(color.FgGreen), "✔ Answered verification challenge for %v subscription %v", message.Subscription.Type, message.Subscription.ID)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(message.Challenge))
+		return
+	}
+
+	// Twitch may resend a message it didn't see a response to. Duplicates are acknowledged so they aren't sent again,
+	// but otherwise ignored.
+	if l.isReplay(messageID) {
+		l.printf(color.New(color.FgYellow), "Ignoring replayed message %v", messageID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch messageType {
+	case trigger.EventSubMessageTypeRevocation:
+		l.printf(color.New(color.FgYellow), "Subscription %v to %v was revoked: %v", message.Subscription.ID, message.Subscription.Type, message.Subscription.Status)
+		l.printPayload(body)
+
+	case trigger.EventSubMessageTypeNotification:
+		l.printf(color.New(color.FgGreen), "✔ Received %v v%v notification %v", message.Subscription.Type, message.Subscription.Version, messageID)
+		l.printPayload(body)
+		if l.params.ForwardAddress != "" {
+			l.forward(r, body)
+		}
+
+	default:
+		l.reject(w, http.StatusBadRequest, "Unknown message type %q", messageType)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *Listener) isReplay(messageID string) bool {
+	l.muSeen.Lock()
+	defer l.muSeen.Unlock()
+
+	now := util.GetTimestamp()
+
+	// Messages older than the max age are rejected anyway, so their IDs don't need to be kept.
+	if l.params.MaxMessageAge > 0 {
+		for id, receivedAt := range l.seen {
+			if now.Sub(receivedAt) > l.params.MaxMessageAge {
+				delete(l.seen, id)
+			}
+		}
+	}
+
+	if _, ok := l.seen[messageID]; ok {
+		return true
+	}
+	l.seen[messageID] = now
+	return false
+}
+
+func (l *Listener) forward(r *http.Request, body []byte) {
+	req, err := request.NewRequest(http.MethodPost, l.params.ForwardAddress, bytes.NewBuffer(body))
+	if err != nil {
+		l.printf(color.New(color.FgRed), "✗ Failed to forward message: %v", err)
+		return
+	}
+	for name, values := range r.Header {
+		if name == "User-Agent" || name == "Content-Length" {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	client := request.NewClient(10 * time.Second)
+	resp, err := client.Do(req)
+	if err != nil {
+		l.printf(color.New(color.FgRed), "✗ Failed to forward message: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	l.printf(color.New(color.FgBlue), "Forwarded to %v [%v]", l.params.ForwardAddress, resp.StatusCode)
+}
+
+func (l *Listener) reject(w http.ResponseWriter, status int, format string, a ...interface{}) {
+	l.printf(color.New(color.FgRed), "✗ "+format, a...)
+	w.WriteHeader(status)
+}
+
+func (l *Listener) printf(c *color.Color, format string, a ...interface{}) {
+	c.Fprintln(l.params.Output, fmt.Sprintf(format, a...))
+}
+
+func (l *Listener) printPayload(body []byte) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		fmt.Fprintln(l.params.Output, string(body))
+		return
+	}
+
+	if runtime.GOOS == "windows" {
+		s, _ := json.MarshalIndent(obj, "", "  ")
+		fmt.Fprintln(l.params.Output, string(s))
+		return
+	}
+
+	f := colorjson.NewFormatter()
+	f.Indent = 2
+	f.KeyColor = color.New(color.FgBlue).Add(color.Bold)
+	s, _ := f.Marshal(obj)
+	fmt.Fprintln(l.params.Output, string(s))
+}
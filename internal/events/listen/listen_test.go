@@ -0,0 +1,148 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package listen
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/pkg/eventsub"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+const testSecret = "potatopotato"
+
+func TestListener(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	forwarded := 0
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded++
+		a.Equal("channel.follow", r.Header.Get("Twitch-Eventsub-Subscription-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer downstream.Close()
+
+	var out bytes.Buffer
+	ts := httptest.NewServer(NewListener(ListenParameters{
+		Secret:         testSecret,
+		ForwardAddress: downstream.URL,
+		MaxMessageAge:  DefaultMaxMessageAge,
+		Output:         &out,
+	}))
+	defer ts.Close()
+
+	body := []byte(`{"subscription":{"id":"1","type":"channel.follow","version":"2","status":"enabled"},"event":{}}`)
+	now := util.GetTimestamp().Format(time.RFC3339Nano)
+
+	// Valid notification
+	resp := send(t, ts.URL, "message1", trigger.EventSubMessageTypeNotification, now, testSecret, body)
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+	a.Equal(1, forwarded)
+	a.Contains(out.String(), "channel.follow")
+
+	// Replayed message is acknowledged, but not processed again
+	resp = send(t, ts.URL, "message1", trigger.EventSubMessageTypeNotification, now, testSecret, body)
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+	a.Equal(1, forwarded)
+
+	// Wrong secret
+	resp = send(t, ts.URL, "message2", trigger.EventSubMessageTypeNotification, now, "wrongsecret", body)
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+
+	// Stale timestamp
+	stale := util.GetTimestamp().Add(-time.Hour).Format(time.RFC3339Nano)
+	resp = send(t, ts.URL, "message3", trigger.EventSubMessageTypeNotification, stale, testSecret, body)
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.Equal(1, forwarded)
+
+	// Verification challenge
+	challengeBody := []byte(`{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"1","type":"channel.follow","version":"2","status":"webhook_callback_verification_pending"}}`)
+	resp = send(t, ts.URL, "message4", trigger.EventSubMessageTypeVerification, now, testSecret, challengeBody)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	respBody, _ := io.ReadAll(resp.Body)
+	a.Equal("pogchamp-kappa-360noscope-vohiyo", string(respBody))
+
+	// A resent challenge is answered again
+	resp = send(t, ts.URL, "message4", trigger.EventSubMessageTypeVerification, now, testSecret, challengeBody)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	respBody, _ = io.ReadAll(resp.Body)
+	a.Equal("pogchamp-kappa-360noscope-vohiyo", string(respBody))
+
+	// Timestamp in the future
+	future := util.GetTimestamp().Add(time.Hour).Format(time.RFC3339Nano)
+	resp = send(t, ts.URL, "message5", trigger.EventSubMessageTypeNotification, future, testSecret, body)
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.Equal(1, forwarded)
+}
+
+func TestListenerWithTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var out bytes.Buffer
+	ts := httptest.NewServer(NewListener(ListenParameters{Secret: testSecret, MaxMessageAge: DefaultMaxMessageAge, Output: &out}))
+	defer ts.Close()
+
+	_, err := trigger.Fire(trigger.TriggerParameters{
+		Event:              "cheer",
+		Transport:          models.TransportWebhook,
+		ForwardAddress:     ts.URL,
+		Secret:             testSecret,
+		SubscriptionStatus: "enabled",
+	})
+	a.Nil(err)
+	a.Contains(out.String(), "✔ Received channel.cheer")
+}
+
+// Signatures cover the timestamp header as it was sent, as Twitch signs them, even when it isn't in Go's canonical format
+func TestListenerSignedTimestamp(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var out bytes.Buffer
+	ts := httptest.NewServer(NewListener(ListenParameters{Secret: testSecret, MaxMessageAge: DefaultMaxMessageAge, Output: &out}))
+	defer ts.Close()
+
+	body := []byte(`{"subscription":{"id":"1","type":"channel.follow","version":"2","status":"enabled"},"event":{}}`)
+	timestamp := util.GetTimestamp().Truncate(time.Second).Format("2006-01-02T15:04:05.000000000Z07:00")
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader(body))
+	a.Nil(err)
+	req.Header.Set(eventsub.HeaderMessageID, "abc")
+	req.Header.Set(eventsub.HeaderMessageType, trigger.EventSubMessageTypeNotification)
+	req.Header.Set(eventsub.HeaderMessageTimestamp, timestamp)
+	req.Header.Set(eventsub.HeaderMessageSignature, eventsub.Signature(testSecret, "abc", timestamp, body))
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+}
+
+func send(t *testing.T, url string, id string, messageType string, timestamp string, secret string, body []byte) *http.Response {
+	req, err := trigger.NewForwardRequest(trigger.ForwardParamters{
+		ID:                  id,
+		ForwardAddress:      url,
+		JSON:                body,
+		Transport:           models.TransportWebhook,
+		Timestamp:           timestamp,
+		Secret:              secret,
+		Event:               "channel.follow",
+		Type:                messageType,
+		SubscriptionVersion: "2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
@@ -0,0 +1,283 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package load
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/rpc"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// LoadParameters are used to send a stream of events at a steady rate, such as for sizing handlers before a big event.
+type LoadParameters struct {
+	Mix         []WeightedEvent
+	Rate        float64       // Events per second; zero sends events as fast as the workers can deliver them
+	Duration    time.Duration // Zero only stops once Count events are sent
+	Count       int           // Zero only stops once Duration has passed
+	Concurrency int
+	Trigger     trigger.TriggerParameters // Shared by every event, except for the Event itself which is chosen from Mix
+}
+
+// WeightedEvent is an event in the mix sent during a load test, which is chosen Weight times as often as an event with a weight of 1.
+type WeightedEvent struct {
+	Event   string
+	Version string // Overrides the version set for the whole test
+	Weight  int
+}
+
+// ParseMix parses a list of event=weight pairs, such as follow=80. Events without a weight have a weight of 1, and
+// the version of an event can be chosen with event@version, such as moderate@2=10.
+func ParseMix(mix []string) ([]WeightedEvent, error) {
+	parsed := []WeightedEvent{}
+	for _, m := range mix {
+		event, weight, hasWeight := strings.Cut(m, "=")
+		event, version, _ := strings.Cut(event, "@")
+		w := WeightedEvent{Event: strings.TrimSpace(event), Version: strings.TrimSpace(version), Weight: 1}
+		if hasWeight {
+			n, err := strconv.Atoi(strings.TrimSpace(weight))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("Invalid weight for %v in event mix; weights must be whole numbers greater than 0", w.Event)
+			}
+			w.Weight = n
+		}
+		if w.Event == "" {
+			return nil, fmt.Errorf("Invalid event mix entry \"%v\"; entries must be in the format event=weight", m)
+		}
+		parsed = append(parsed, w)
+	}
+	return parsed, nil
+}
+
+// ParseRate parses a rate such as 500/s or 1200/m into events per second. Numbers without a unit are per second.
+func ParseRate(rate string) (float64, error) {
+	number, unit, _ := strings.Cut(strings.TrimSpace(rate), "/")
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("Invalid rate \"%v\"; rates must be a positive number of events per second or minute, such as 500/s", rate)
+	}
+
+	switch unit {
+	case "", "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	default:
+		return 0, fmt.Errorf("Invalid rate \"%v\"; the unit must be /s or /m", rate)
+	}
+}
+
+type loadTest struct {
+	LoadParameters
+	totalWeight int
+	client      *http.Client
+	report      *Report
+	mu          sync.Mutex
+}
+
+// Run sends events until the duration has passed or the count has been reached, and reports on how they were received.
+// Unlike "event trigger", events aren't kept in the event history, so they can't be retriggered.
+func Run(p LoadParameters) (Report, error) {
+	if p.Concurrency < 1 {
+		return Report{}, fmt.Errorf("Concurrency must be at least 1")
+	}
+	if p.Duration <= 0 && p.Count <= 0 {
+		return Report{}, fmt.Errorf("Load tests need a duration or count to know when to stop")
+	}
+	if len(p.Mix) == 0 {
+		return Report{}, fmt.Errorf("Load tests need at least one event to send")
+	}
+	if p.Trigger.Transport == models.TransportWebhook && p.Trigger.ForwardAddress == "" {
+		return Report{}, fmt.Errorf("Load tests with the webhook transport need a forward address")
+	}
+
+	// A burst of events is usually aimed at a single channel
+	if p.Trigger.ToUser == "" {
+		p.Trigger.ToUser = util.RandomUserID()
+	}
+
+	l := &loadTest{
+		LoadParameters: p,
+		client:         trigger.NewForwardClient(p.Concurrency),
+		report:         newReport(p.Trigger.Transport),
+	}
+
+	// Each event is generated once up front, so mistakes such as an unknown event are found before the test starts
+	for _, w := range p.Mix {
+		_, err := l.generate(w)
+		if err != nil {
+			return Report{}, fmt.Errorf("%v: %v", w.Event, err)
+		}
+		l.totalWeight += w.Weight
+	}
+
+	// WebSocket workers each keep their own connection to the WebSocket server
+	rpcClients := []*rpc.Client{}
+	if p.Trigger.Transport == models.TransportWebSocket {
+		for i := 0; i < p.Concurrency; i++ {
+			client, err := trigger.DialWebSocketServer()
+			if err != nil {
+				return Report{}, err
+			}
+			defer client.Close()
+			rpcClients = append(rpcClients, client)
+		}
+	}
+
+	jobs := make(chan WeightedEvent, p.Concurrency)
+	start := time.Now()
+	go l.schedule(start, jobs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.Concurrency; i++ {
+		var rpcClient *rpc.Client
+		if len(rpcClients) != 0 {
+			rpcClient = rpcClients[i]
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range jobs {
+				l.send(event, rpcClient)
+			}
+		}()
+	}
+	wg.Wait()
+
+	l.report.Elapsed = time.Since(start)
+	l.report.finish()
+	return *l.report, nil
+}
+
+// schedule queues events at the configured rate until the test is over. If the workers fall behind, the rate drops
+// rather than events piling up, which shows up as a lower achieved rate in the report.
+func (l *loadTest) schedule(start time.Time, jobs chan<- WeightedEvent) {
+	defer close(jobs)
+
+	var interval time.Duration
+	if l.Rate > 0 {
+		interval = time.Duration(float64(time.Second) / l.Rate)
+	}
+	end := start.Add(l.Duration)
+
+	for i := 0; l.Count <= 0 || i < l.Count; i++ {
+		next := start.Add(time.Duration(i) * interval)
+		if l.Duration > 0 && !next.Before(end) {
+			return
+		}
+		time.Sleep(time.Until(next))
+		if l.Duration > 0 && !time.Now().Before(end) {
+			return
+		}
+		jobs <- l.pick()
+	}
+}
+
+func (l *loadTest) pick() WeightedEvent {
+	n := int(util.RandomInt(int64(l.totalWeight)))
+	for _, w := range l.Mix {
+		if n < w.Weight {
+			return w
+		}
+		n -= w.Weight
+	}
+	return l.Mix[len(l.Mix)-1]
+}
+
+func (l *loadTest) generate(event WeightedEvent) (trigger.GeneratedEvent, error) {
+	p := l.Trigger
+	p.Event = event.Event
+	if event.Version != "" {
+		p.Version = event.Version
+	}
+
+	g, err := trigger.Generate(p)
+	if err != nil {
+		return g, err
+	}
+
+	g.Response.JSON, err = p.Overrides.Apply(g.Response.JSON)
+	return g, err
+}
+
+func (l *loadTest) send(event WeightedEvent, rpcClient *rpc.Client) {
+	g, err := l.generate(event)
+	if err != nil {
+		l.record(event.Event, result{err: err})
+		return
+	}
+
+	if rpcClient != nil {
+		l.record(event.Event, l.sendWebSocket(g, rpcClient))
+	} else {
+		l.record(event.Event, l.sendWebhook(g))
+	}
+}
+
+func (l *loadTest) sendWebhook(g trigger.GeneratedEvent) result {
+	messageType := trigger.EventSubMessageTypeNotification
+	if !strings.EqualFold(g.Parameters.SubscriptionStatus, "enabled") {
+		messageType = trigger.EventSubMessageTypeRevocation
+	}
+
+	req, err := trigger.NewForwardRequest(trigger.ForwardParamters{
+		ID:                  g.Response.ID,
+		Transport:           g.Parameters.Transport,
+		Timestamp:           g.Parameters.Timestamp,
+		JSON:                g.Response.JSON,
+		Secret:              g.Parameters.Secret,
+		ForwardAddress:      g.Parameters.ForwardAddress,
+		Event:               g.Topic,
+		EventMessageID:      g.Parameters.EventMessageID,
+		Type:                messageType,
+		SubscriptionVersion: g.Version,
+	})
+	if err != nil {
+		return result{err: err}
+	}
+
+	start := time.Now()
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return result{err: err}
+	}
+	// The body is read so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return result{latency: time.Since(start), status: resp.StatusCode}
+}
+
+func (l *loadTest) sendWebSocket(g trigger.GeneratedEvent, client *rpc.Client) result {
+	payload, err := trigger.WebSocketPayload(g.Response.JSON)
+	if err != nil {
+		return result{err: err}
+	}
+
+	start := time.Now()
+	reply, err := trigger.ForwardToWebSocket(client, g.Parameters.WebSocketClient, payload)
+	if err != nil {
+		return result{err: err}
+	}
+
+	r := result{latency: time.Since(start), status: http.StatusOK}
+	if reply.ResponseCode != 0 { // Zero will always be success
+		r.rejection = reply.DetailedInfo
+	}
+	return r
+}
+
+func (l *loadTest) record(event string, r result) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.report.add(event, r)
+}
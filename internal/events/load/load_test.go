@@ -0,0 +1,209 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package load
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestParseMix(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	mix, err := ParseMix([]string{"follow=80", "subscribe=15", "cheer", "moderate@2=5"})
+	a.Nil(err)
+	a.Equal([]WeightedEvent{
+		{Event: "follow", Weight: 80},
+		{Event: "subscribe", Weight: 15},
+		{Event: "cheer", Weight: 1},
+		{Event: "moderate", Version: "2", Weight: 5},
+	}, mix)
+
+	_, err = ParseMix([]string{"follow=0"})
+	a.NotNil(err)
+
+	_, err = ParseMix([]string{"follow=lots"})
+	a.NotNil(err)
+
+	_, err = ParseMix([]string{"=5"})
+	a.NotNil(err)
+}
+
+func TestParseRate(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r, err := ParseRate("500/s")
+	a.Nil(err)
+	a.Equal(500.0, r)
+
+	r, err = ParseRate("120/m")
+	a.Nil(err)
+	a.Equal(2.0, r)
+
+	r, err = ParseRate("25")
+	a.Nil(err)
+	a.Equal(25.0, r)
+
+	_, err = ParseRate("fast")
+	a.NotNil(err)
+
+	_, err = ParseRate("10/h")
+	a.NotNil(err)
+
+	_, err = ParseRate("-5/s")
+	a.NotNil(err)
+}
+
+func TestRun(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	secret := "potatopotato"
+	var mu sync.Mutex
+	topics := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		topics[r.Header.Get("Twitch-Eventsub-Subscription-Type")]++
+		mu.Unlock()
+
+		// Verify the signature the way a handler would, and fail every cheer
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Header.Get("Twitch-Eventsub-Message-Id") + r.Header.Get("Twitch-Eventsub-Message-Timestamp")))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("Twitch-Eventsub-Message-Signature") != expected {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if strings.Contains(string(body), `"channel.cheer"`) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	report, err := Run(LoadParameters{
+		Mix:         []WeightedEvent{{Event: "follow", Weight: 3}, {Event: "cheer", Version: "1", Weight: 1}},
+		Count:       40,
+		Concurrency: 4,
+		Trigger: trigger.TriggerParameters{
+			Transport:          models.TransportWebhook,
+			ForwardAddress:     ts.URL,
+			Secret:             secret,
+			SubscriptionStatus: "enabled",
+			Version:            "2",
+		},
+	})
+	a.Nil(err)
+
+	a.Equal(40, report.Sent)
+	a.Equal(report.Events["follow"], topics["channel.follow"])
+	a.Equal(report.Events["cheer"], topics["channel.cheer"])
+	a.Equal(report.Events["follow"], report.Succeeded)
+	a.Equal(report.Events["cheer"], report.NonSuccess[500])
+	a.Equal(0, report.SignatureFailures)
+	a.Equal(0, report.Errors)
+	a.Len(report.Latencies, 40)
+	a.LessOrEqual(report.Percentile(50), report.Percentile(99))
+	a.Contains(report.String(), "Sent 40 events")
+
+	// A wrong secret is reported as signature failures
+	secret = "notthesecret"
+	report, err = Run(LoadParameters{
+		Mix:         []WeightedEvent{{Event: "follow", Weight: 1}},
+		Count:       5,
+		Concurrency: 1,
+		Trigger: trigger.TriggerParameters{
+			Transport:          models.TransportWebhook,
+			ForwardAddress:     ts.URL,
+			Secret:             "potatopotato",
+			SubscriptionStatus: "enabled",
+			Version:            "2",
+		},
+	})
+	a.Nil(err)
+	a.Equal(5, report.SignatureFailures)
+	a.Equal(5, report.NonSuccess[403])
+}
+
+func TestRunRate(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	report, err := Run(LoadParameters{
+		Mix:         []WeightedEvent{{Event: "follow", Weight: 1}},
+		Rate:        100,
+		Duration:    300 * time.Millisecond,
+		Concurrency: 2,
+		Trigger: trigger.TriggerParameters{
+			Transport:          models.TransportWebhook,
+			ForwardAddress:     ts.URL,
+			SubscriptionStatus: "enabled",
+			Version:            "2",
+		},
+	})
+	a.Nil(err)
+
+	// 100 events per second for 300ms is 30 events
+	a.InDelta(30, report.Sent, 3)
+	a.Equal(report.Sent, report.Succeeded)
+}
+
+func TestRunErrors(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	valid := LoadParameters{
+		Mix:         []WeightedEvent{{Event: "follow", Weight: 1}},
+		Count:       1,
+		Concurrency: 1,
+		Trigger: trigger.TriggerParameters{
+			Transport:      models.TransportWebhook,
+			ForwardAddress: "http://localhost:1",
+			Version:        "2",
+		},
+	}
+
+	p := valid
+	p.Concurrency = 0
+	_, err := Run(p)
+	a.NotNil(err)
+
+	p = valid
+	p.Count = 0
+	_, err = Run(p)
+	a.NotNil(err)
+
+	p = valid
+	p.Mix = []WeightedEvent{{Event: "notanevent", Weight: 1}}
+	_, err = Run(p)
+	a.NotNil(err)
+
+	p = valid
+	p.Trigger.ForwardAddress = ""
+	_, err = Run(p)
+	a.NotNil(err)
+
+	// Unreachable targets are reported rather than stopping the test
+	report, err := Run(valid)
+	a.Nil(err)
+	a.Equal(1, report.Errors)
+	a.Len(report.ErrorMessages, 1)
+}
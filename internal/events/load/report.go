@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package load
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+// The number of distinct error messages kept in a report, so a failing target doesn't use up memory
+const maxErrorMessages = 10
+
+type result struct {
+	latency   time.Duration
+	status    int
+	rejection string // Why the WebSocket server couldn't deliver the event, if it couldn't
+	err       error
+}
+
+// Report summarizes how the events sent during a load test were received.
+type Report struct {
+	Transport string
+	Elapsed   time.Duration
+	Sent      int
+	Events    map[string]int // Events sent, by event type
+	Succeeded int
+	// Responses outside of the 2xx range, by status code. WebSocket events the server couldn't deliver are counted under 0.
+	NonSuccess map[int]int
+	// Webhook handlers reject events with invalid signatures with a 401 or 403 status code
+	SignatureFailures int
+	// Events that got no response at all, such as from timeouts or refused connections
+	Errors        int
+	ErrorMessages map[string]int
+	Latencies     []time.Duration // Sorted from fastest to slowest
+}
+
+func newReport(transport string) *Report {
+	return &Report{
+		Transport:     transport,
+		Events:        map[string]int{},
+		NonSuccess:    map[int]int{},
+		ErrorMessages: map[string]int{},
+	}
+}
+
+func (r *Report) add(event string, res result) {
+	r.Sent++
+	r.Events[event]++
+
+	if res.err != nil {
+		r.Errors++
+		r.addMessage(res.err.Error())
+		return
+	}
+	r.Latencies = append(r.Latencies, res.latency)
+
+	switch {
+	case res.rejection != "":
+		r.NonSuccess[0]++
+		r.addMessage(res.rejection)
+	case res.status >= 200 && res.status <= 299:
+		r.Succeeded++
+	default:
+		r.NonSuccess[res.status]++
+		if res.status == http.StatusUnauthorized || res.status == http.StatusForbidden {
+			r.SignatureFailures++
+		}
+	}
+}
+
+func (r *Report) addMessage(message string) {
+	if _, ok := r.ErrorMessages[message]; ok || len(r.ErrorMessages) < maxErrorMessages {
+		r.ErrorMessages[message]++
+	}
+}
+
+func (r *Report) finish() {
+	sort.Slice(r.Latencies, func(i, j int) bool { return r.Latencies[i] < r.Latencies[j] })
+}
+
+// Percentile returns the latency that p percent of responses were at or under, using the nearest-rank method.
+func (r Report) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(r.Latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(r.Latencies) {
+		rank = len(r.Latencies)
+	}
+	return r.Latencies[rank-1]
+}
+
+// Rate returns the number of events sent per second.
+func (r Report) Rate() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Sent) / r.Elapsed.Seconds()
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Sent %v events in %v (%.1f events/s)\n", r.Sent, r.Elapsed.Round(time.Millisecond), r.Rate())
+	for _, event := range sortedKeys(r.Events) {
+		fmt.Fprintf(&b, "  %-30v %v\n", event, r.Events[event])
+	}
+
+	fmt.Fprintf(&b, "\nSucceeded:           %v\n", r.Succeeded)
+	if r.Transport == models.TransportWebSocket {
+		fmt.Fprintf(&b, "Not delivered:       %v\n", r.NonSuccess[0])
+	} else {
+		nonSuccess := 0
+		codes := []string{}
+		for _, code := range sortedCodes(r.NonSuccess) {
+			nonSuccess += r.NonSuccess[code]
+			codes = append(codes, fmt.Sprintf("%v: %v", code, r.NonSuccess[code]))
+		}
+		fmt.Fprintf(&b, "Non-2xx responses:   %v", nonSuccess)
+		if len(codes) != 0 {
+			fmt.Fprintf(&b, " (%v)", strings.Join(codes, ", "))
+		}
+		fmt.Fprintf(&b, "\nSignature failures:  %v\n", r.SignatureFailures)
+	}
+	fmt.Fprintf(&b, "Errors:              %v\n", r.Errors)
+	for _, message := range sortedKeys(r.ErrorMessages) {
+		fmt.Fprintf(&b, "  %v x %v\n", r.ErrorMessages[message], message)
+	}
+
+	if len(r.Latencies) != 0 {
+		fmt.Fprintf(&b, "\nLatency:  p50 %v  p90 %v  p95 %v  p99 %v  max %v\n",
+			r.Percentile(50).Round(time.Microsecond),
+			r.Percentile(90).Round(time.Microsecond),
+			r.Percentile(95).Round(time.Microsecond),
+			r.Percentile(99).Round(time.Microsecond),
+			r.Latencies[len(r.Latencies)-1].Round(time.Microsecond),
+		)
+	}
+
+	return b.String()
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCodes(m map[int]int) []int {
+	codes := []int{}
+	for c := range m {
+		codes = append(codes, c)
+	}
+	sort.Ints(codes)
+	return codes
+}
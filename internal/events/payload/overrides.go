@@ -0,0 +1,174 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/jq"
+)
+
+// Overrides are changes made to a generated event payload before it's stored, signed and forwarded. They are applied in
+// the order merge patches, then path assignments, then jq filters.
+type Overrides struct {
+	merges  []interface{}
+	sets    []assignment
+	filters []jq.Filter
+}
+
+type assignment struct {
+	path  jq.Path
+	text  string      // The value as given
+	value interface{} // The value decoded as JSON, or the text if it isn't valid JSON
+	raw   bool        // Set with path:=value, so the value is always used as JSON
+}
+
+// valueFor returns the value to store over current. Fields that are currently strings stay strings, so IDs such as
+// event.user_id=12345 aren't turned into numbers, unless the assignment was made with :=.
+func (a assignment) valueFor(current interface{}) interface{} {
+	if _, isString := current.(string); isString && !a.raw {
+		if s, ok := a.value.(string); ok {
+			return s
+		}
+		return a.text
+	}
+	return jq.Copy(a.value)
+}
+
+// NewOverrides parses the values of --set, --merge and --jq, so that any mistakes are reported before events are triggered.
+//
+// Each set is a path=value assignment such as event.reward.title=Hydrate. When the field is currently a string, the value is
+// kept as a string; otherwise it's used as JSON if it's valid JSON and as a string if it isn't. A path:=value assignment always
+// uses the value as JSON, and it must be valid. Each merge is the path to a file holding an RFC 7386 JSON merge patch. Each filter is a jq expression
+// using the subset described in jq.Compile, which must produce a single object.
+func NewOverrides(sets []string, mergeFiles []string, filters []string) (*Overrides, error) {
+	o := &Overrides{}
+
+	for _, file := range mergeFiles {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read merge patch: %v", err)
+		}
+		patch, err := jq.Decode(b)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid merge patch %v: %v", file, err)
+		}
+		o.merges = append(o.merges, patch)
+	}
+
+	for _, s := range sets {
+		i := strings.Index(s, "=")
+		if i == -1 {
+			return nil, fmt.Errorf("Invalid --set value %q; must be in the format path=value", s)
+		}
+		a := assignment{text: s[i+1:]}
+		path := s[:i]
+		if strings.HasSuffix(path, ":") {
+			a.raw = true
+			path = strings.TrimSuffix(path, ":")
+		}
+
+		p, err := jq.ParsePath(path)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid --set value %q: %v", s, err)
+		}
+		a.path = p
+
+		a.value, err = jq.Decode([]byte(a.text))
+		if err != nil {
+			if a.raw {
+				return nil, fmt.Errorf("Invalid --set value %q: the value given with := must be valid JSON: %v", s, err)
+			}
+			a.value = a.text
+		}
+		o.sets = append(o.sets, a)
+	}
+
+	for _, expression := range filters {
+		f, err := jq.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid --jq filter %q: %v", expression, err)
+		}
+		o.filters = append(o.filters, f)
+	}
+
+	return o, nil
+}
+
+// Empty returns true when there are no overrides to apply.
+func (o *Overrides) Empty() bool {
+	return o == nil || len(o.merges)+len(o.sets)+len(o.filters) == 0
+}
+
+// Apply returns the payload with the overrides applied. Key order and untouched values are kept as they were.
+func (o *Overrides) Apply(payload []byte) ([]byte, error) {
+	if o.Empty() {
+		return payload, nil
+	}
+
+	root, err := jq.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, patch := range o.merges {
+		root = mergePatch(root, jq.Copy(patch))
+	}
+
+	for _, a := range o.sets {
+		root, err = a.path.Set(root, a.valueFor(a.path.Get(root)))
+		if err != nil {
+			return nil, fmt.Errorf("Unable to set %v: %v", a.path, err)
+		}
+	}
+
+	for _, f := range o.filters {
+		results, err := f(root)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to apply --jq filter: %v", err)
+		}
+		if len(results) != 1 {
+			return nil, fmt.Errorf("Unable to apply --jq filter: it must produce one payload, but produced %v", len(results))
+		}
+		if _, ok := results[0].(*jq.Object); !ok {
+			return nil, fmt.Errorf("Unable to apply --jq filter: it must produce an object, but produced %s", jsonString(results[0]))
+		}
+		root = results[0]
+	}
+
+	return json.Marshal(root)
+}
+
+// mergePatch applies patch to target as described in RFC 7386.
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(*jq.Object)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(*jq.Object)
+	if !ok {
+		t = jq.NewObject()
+	}
+	for _, k := range p.Keys() {
+		v, _ := p.Get(k)
+		if v == nil {
+			t.Delete(k)
+			continue
+		}
+		current, _ := t.Get(k)
+		t.Set(k, mergePatch(current, v))
+	}
+	return t
+}
+
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package payload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var generated = `{"subscription":{"id":"1","type":"channel.cheer"},"event":{"bits":100,"message":"Cheer100","fragments":[{"text":"a"},{"text":"b"}],"is_anonymous":false}}`
+
+func TestSet(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	o, err := NewOverrides([]string{
+		"event.bits=500",
+		`.event.message="Hydrate"`,
+		"event.user_name=Hydrate",
+		"event.fragments[1].text=c",
+		"event.fragments[2]={\"text\":\"d\"}",
+		"event.reward.title=new",
+		`event["with.dots"]=true`,
+	}, nil, nil)
+	a.Nil(err)
+
+	res, err := o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"subscription":{"id":"1","type":"channel.cheer"},"event":{"bits":500,"message":"Hydrate","fragments":[{"text":"a"},{"text":"c"},{"text":"d"}],"is_anonymous":false,"user_name":"Hydrate","reward":{"title":"new"},"with.dots":true}}`, string(res))
+
+	_, err = NewOverrides([]string{"event.bits"}, nil, nil)
+	a.NotNil(err)
+
+	_, err = NewOverrides([]string{"event..bits=1"}, nil, nil)
+	a.NotNil(err)
+
+	o, err = NewOverrides([]string{"event.bits.value=1"}, nil, nil)
+	a.Nil(err)
+	_, err = o.Apply([]byte(generated))
+	a.NotNil(err)
+
+	o, err = NewOverrides([]string{"event.fragments[5].text=1"}, nil, nil)
+	a.Nil(err)
+	_, err = o.Apply([]byte(generated))
+	a.NotNil(err)
+}
+
+func TestSetKeepsStrings(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	o, err := NewOverrides([]string{
+		"event.message=12345",
+		"event.bits=true",
+		"event.user_id=67890",
+	}, nil, nil)
+	a.Nil(err)
+
+	res, err := o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"subscription":{"id":"1","type":"channel.cheer"},"event":{"bits":true,"message":"12345","fragments":[{"text":"a"},{"text":"b"}],"is_anonymous":false,"user_id":67890}}`, string(res))
+
+	// := always uses the value as JSON
+	o, err = NewOverrides([]string{
+		"event.message:=12345",
+		`.subscription.type:=null`,
+		`event.fragments[0].text:="x"`,
+	}, nil, nil)
+	a.Nil(err)
+
+	res, err = o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"subscription":{"id":"1","type":null},"event":{"bits":100,"message":12345,"fragments":[{"text":"x"},{"text":"b"}],"is_anonymous":false}}`, string(res))
+
+	_, err = NewOverrides([]string{"event.message:=Hydrate"}, nil, nil)
+	a.NotNil(err)
+}
+
+func TestMerge(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	file := filepath.Join(t.TempDir(), "patch.json")
+	err := os.WriteFile(file, []byte(`{"event":{"bits":1,"message":null,"fragments":[],"extra":{"a":null,"b":2}}}`), 0644)
+	a.Nil(err)
+
+	o, err := NewOverrides(nil, []string{file}, nil)
+	a.Nil(err)
+
+	res, err := o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"subscription":{"id":"1","type":"channel.cheer"},"event":{"bits":1,"fragments":[],"is_anonymous":false,"extra":{"b":2}}}`, string(res))
+
+	_, err = NewOverrides(nil, []string{filepath.Join(t.TempDir(), "missing.json")}, nil)
+	a.NotNil(err)
+
+	err = os.WriteFile(file, []byte(`{"event":`), 0644)
+	a.Nil(err)
+	_, err = NewOverrides(nil, []string{file}, nil)
+	a.NotNil(err)
+}
+
+func TestJQ(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	o, err := NewOverrides(nil, nil, []string{
+		`.event.bits += 50 | .event.message = "a | b" | del(.event.fragments[0])`,
+		`. | .event.copy = .subscription.type | .event.is_anonymous = true | .event.bits -= 25`,
+	})
+	a.Nil(err)
+
+	res, err := o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"subscription":{"id":"1","type":"channel.cheer"},"event":{"bits":125,"message":"a | b","fragments":[{"text":"b"}],"is_anonymous":true,"copy":"channel.cheer"}}`, string(res))
+
+	for _, filter := range []string{".event.bits |= 1", ".event.bits = nope", "del(.)", "del(.event[])", ".event.bits +"} {
+		_, err = NewOverrides(nil, nil, []string{filter})
+		a.NotNil(err, filter)
+	}
+
+	// filters use the same subset of jq as the --filter of api commands, but must produce a single object
+	for _, filter := range []string{`.event.message -= "x"`, ".event.bits == 1", ".event.fragments[]"} {
+		o, err = NewOverrides(nil, nil, []string{filter})
+		a.Nil(err, filter)
+		_, err = o.Apply([]byte(generated))
+		a.NotNil(err, filter)
+	}
+
+	o, err = NewOverrides(nil, nil, []string{`.event.fragments = [] | .event.extra = {"a": [1, 2]} | .event.bits -= 0.5 | select(.event.is_anonymous == false) | .event`})
+	a.Nil(err)
+	res, err = o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(`{"bits":99.5,"message":"Cheer100","fragments":[],"is_anonymous":false,"extra":{"a":[1,2]}}`, string(res))
+}
+
+func TestOrder(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	file := filepath.Join(t.TempDir(), "patch.json")
+	err := os.WriteFile(file, []byte(`{"event":{"bits":1}}`), 0644)
+	a.Nil(err)
+
+	// Merge patches are applied first, then --set, then --jq
+	o, err := NewOverrides([]string{"event.bits=2"}, []string{file}, []string{".event.bits += 1"})
+	a.Nil(err)
+
+	res, err := o.Apply([]byte(generated))
+	a.Nil(err)
+	a.Contains(string(res), `"bits":3`)
+
+	var empty *Overrides
+	a.True(empty.Empty())
+	res, err = empty.Apply([]byte(generated))
+	a.Nil(err)
+	a.Equal(generated, string(res))
+}
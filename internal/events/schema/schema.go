@@ -0,0 +1,255 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schemas are written in a subset of JSON Schema, so they can also be used with other tools.
+// Supported keywords are type, properties, required, additionalProperties, items, enum, and format (date-time only).
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// Schema is a JSON Schema describing a notification payload, or a part of one.
+type Schema struct {
+	Title                string             `json:"title,omitempty"`
+	Type                 schemaType         `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Format               string             `json:"format,omitempty"`
+}
+
+// schemaType is either a single JSON type, or a list of allowed types.
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = schemaType{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = multiple
+	return nil
+}
+
+// ValidationError describes a single place a payload doesn't match its schema.
+type ValidationError struct {
+	Path    string // Location in the payload, such as event.user_id
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Path, e.Message)
+}
+
+// Result is the outcome of validating a payload.
+type Result struct {
+	SubscriptionType string
+	Version          string
+	Errors           []ValidationError // Empty if the payload matches the schema
+}
+
+// Get returns the schema for a subscription type and version.
+func Get(subscriptionType string, version string) (*Schema, error) {
+	b, err := schemaFiles.ReadFile(path.Join("schemas", fmt.Sprintf("%v.v%v.json", subscriptionType, version)))
+	if err != nil {
+		return nil, fmt.Errorf("No schema found for %v version %v", subscriptionType, version)
+	}
+
+	var s Schema
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid schema for %v version %v: %v", subscriptionType, version, err)
+	}
+	return &s, nil
+}
+
+// Available returns every subscription type and version with a schema, in the format <type> v<version>.
+func Available() []string {
+	entries, _ := schemaFiles.ReadDir("schemas")
+
+	available := []string{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		i := strings.LastIndex(name, ".v")
+		available = append(available, name[:i]+" v"+name[i+2:])
+	}
+	sort.Strings(available)
+	return available
+}
+
+// Validate checks a notification payload against the schema for its subscription type and version.
+// Both webhook bodies and WebSocket notification messages are accepted.
+func Validate(payload []byte) (Result, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return Result{}, fmt.Errorf("Payload is not valid JSON: %v", err)
+	}
+
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return Result{}, fmt.Errorf("Payload must be a JSON object")
+	}
+
+	// WebSocket messages wrap the notification in metadata
+	if _, hasMetadata := obj["metadata"]; hasMetadata {
+		if inner, ok := obj["payload"].(map[string]interface{}); ok {
+			obj = inner
+		}
+	}
+
+	subscription, ok := obj["subscription"].(map[string]interface{})
+	if !ok {
+		return Result{}, fmt.Errorf("Payload has no subscription object, so its type can't be determined")
+	}
+	subscriptionType, _ := subscription["type"].(string)
+	version, _ := subscription["version"].(string)
+	if subscriptionType == "" || version == "" {
+		return Result{}, fmt.Errorf("Payload is missing subscription.type or subscription.version")
+	}
+
+	s, err := Get(subscriptionType, version)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		SubscriptionType: subscriptionType,
+		Version:          version,
+		Errors:           s.validate(obj, ""),
+	}, nil
+}
+
+func (s *Schema) validate(v interface{}, at string) []ValidationError {
+	errs := []ValidationError{}
+	fail := func(format string, a ...interface{}) []ValidationError {
+		return append(errs, ValidationError{Path: displayPath(at), Message: fmt.Sprintf(format, a...)})
+	}
+
+	actual := jsonType(v)
+	if len(s.Type) > 0 && !s.allowsType(actual) {
+		return fail("expected %v, got %v", strings.Join(s.Type, " or "), actual)
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) {
+				found = true
+			}
+		}
+		if !found {
+			return fail("value %v is not one of %v", v, s.Enum)
+		}
+	}
+
+	switch value := v.(type) {
+	case string:
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+				return fail("%q is not an RFC3339 timestamp", value)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				errs = append(errs, ValidationError{Path: displayPath(joinPath(at, name)), Message: "required field is missing"})
+			}
+		}
+
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			property, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					errs = append(errs, ValidationError{Path: displayPath(joinPath(at, k)), Message: "field is not defined in the schema"})
+				}
+				continue
+			}
+			errs = append(errs, property.validate(value[k], joinPath(at, k))...)
+		}
+
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range value {
+				errs = append(errs, s.Items.validate(item, fmt.Sprintf("%v[%v]", at, i))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func (s *Schema) allowsType(actual string) bool {
+	for _, t := range s.Type {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if value == float64(int64(value)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(at string, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+func displayPath(at string) string {
+	if at == "" {
+		return "(root)"
+	}
+	return at
+}
@@ -0,0 +1,221 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v1"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v2"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automod_message"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automod_terms"
+	"github.com/twitchdev/twitch-cli/internal/events/types/bits_use"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/events/types/custom"
+	"github.com/twitchdev/twitch-cli/internal/events/types/guest_star"
+	"github.com/twitchdev/twitch-cli/internal/events/types/moderate_v1"
+	"github.com/twitchdev/twitch-cli/internal/events/types/moderate_v2"
+	"github.com/twitchdev/twitch-cli/internal/events/types/suspicious_user"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+// Every event the CLI can generate must match the schema for its type and version
+func TestGeneratedEventsMatchSchemas(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	for _, e := range types.AllEvents() {
+		if _, isCustom := e.(custom.Event); isCustom {
+			// User-defined templates don't come with schemas
+			continue
+		}
+
+		for _, transport := range []string{models.TransportWebhook, models.TransportWebSocket} {
+			if !e.ValidTransport(transport) {
+				continue
+			}
+
+			for _, topic := range e.GetAllTopicsByTransport(transport) {
+				trigger := e.GetEventSubAlias(topic)
+				if trigger == "" {
+					trigger = topic
+				}
+
+				variants := []events.MockEventParameters{
+					{},
+					{IsAnonymous: true, IsGift: true, GiftUser: "5", BanEndTimestamp: "2023-01-01T00:10:00Z", Tier: "3000"},
+				}
+				if topic == "channel.channel_points_custom_reward_redemption.update" {
+					variants = append(variants, events.MockEventParameters{EventStatus: "fulfilled"})
+				}
+				if topic == "automod.message.update" {
+					for _, status := range automod_message.Statuses {
+						variants = append(variants, events.MockEventParameters{EventStatus: status})
+					}
+				}
+				if strings.HasPrefix(topic, "channel.suspicious_user.") {
+					for _, status := range suspicious_user.LowTrustStatuses {
+						variants = append(variants, events.MockEventParameters{EventStatus: status})
+					}
+				}
+				if topic == "automod.terms.update" {
+					for _, action := range automod_terms.Actions {
+						variants = append(variants, events.MockEventParameters{ModerateAction: action})
+					}
+				}
+				if topic == "channel.chat.notification" {
+					for _, noticeType := range chat.NoticeTypes {
+						variants = append(variants, events.MockEventParameters{NoticeType: noticeType})
+					}
+				}
+				if topic == "channel.channel_points_automatic_reward_redemption.add" {
+					rewardTypes := automatic_reward_v1.RewardTypes
+					if e.SubscriptionVersion() == "2" {
+						rewardTypes = automatic_reward_v2.RewardTypes
+					}
+					for _, rewardType := range rewardTypes {
+						variants = append(variants, events.MockEventParameters{RewardType: rewardType})
+					}
+				}
+				if topic == "channel.bits.use" {
+					for _, useType := range bits_use.Types {
+						variants = append(variants, events.MockEventParameters{RewardType: useType, MessageText: "Cheer50 hi @bob Kappa"})
+					}
+				}
+				if topic == "channel.guest_star_guest.update" {
+					for _, state := range guest_star.GuestStates {
+						variants = append(variants, events.MockEventParameters{EventStatus: state})
+					}
+				}
+				if strings.HasPrefix(topic, "channel.shared_chat.") {
+					variants = append(variants, events.MockEventParameters{ParticipantIDs: []string{"1", "2"}})
+				}
+				if topic == "channel.moderate" {
+					actions := moderate_v1.Actions
+					if e.SubscriptionVersion() == "2" {
+						actions = moderate_v2.Actions
+					}
+					for _, action := range actions {
+						variants = append(variants, events.MockEventParameters{ModerateAction: action})
+					}
+				}
+
+				for _, p := range variants {
+					p.Transport = transport
+					p.Trigger = trigger
+					p.FromUserID = "1234"
+					p.ToUserID = "5678"
+					p.ClientID = "crq72vsaoijkc83xx42hz6i37"
+					p.SubscriptionStatus = "enabled"
+					p.Timestamp = "2023-01-01T00:00:00Z"
+					if p.Tier == "" {
+						p.Tier = "1000"
+					}
+
+					resp, err := e.GenerateEvent(p)
+					a.Nil(err, topic)
+
+					result, err := Validate(resp.JSON)
+					a.Nil(err, topic)
+					a.Empty(result.Errors, fmt.Sprintf("%v v%v via %v", topic, e.SubscriptionVersion(), transport))
+				}
+			}
+		}
+	}
+}
+
+// Every schema must belong to an event the CLI can generate, so stale schemas are noticed
+func TestSchemasHaveEvents(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	served := map[string]bool{}
+	for _, e := range types.AllEvents() {
+		for _, topic := range e.GetAllTopicsByTransport(models.TransportWebhook) {
+			served[topic+" v"+e.SubscriptionVersion()] = true
+		}
+	}
+
+	for _, s := range Available() {
+		a.True(served[s], s)
+		delete(served, s)
+	}
+	a.Empty(served, "events without a schema")
+}
+
+func TestValidate(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	valid := `{"subscription":{"id":"a","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1","moderator_user_id":"1"},"transport":{"method":"webhook","callback":"https://example.com"},"created_at":"2023-01-01T00:00:00Z","cost":0},
+		"event":{"user_id":"2","user_login":"a","user_name":"A","broadcaster_user_id":"1","broadcaster_user_login":"b","broadcaster_user_name":"B","followed_at":"2023-01-01T00:00:00.123Z"}}`
+	result, err := Validate([]byte(valid))
+	a.Nil(err)
+	a.Equal("channel.follow", result.SubscriptionType)
+	a.Equal("2", result.Version)
+	a.Empty(result.Errors)
+
+	// WebSocket messages are unwrapped
+	result, err = Validate([]byte(`{"metadata":{"message_type":"notification"},"payload":` + valid + `}`))
+	a.Nil(err)
+	a.Empty(result.Errors)
+
+	invalid := `{"subscription":{"id":"a","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1","moderator_user_id":"1"},"transport":{"method":"webhook"},"created_at":"2023-01-01T00:00:00Z","cost":0},
+		"event":{"user_id":2,"user_login":"a","user_name":"A","broadcaster_user_id":"1","broadcaster_user_login":"b","followed_at":"yesterday","extra":true}}`
+	result, err = Validate([]byte(invalid))
+	a.Nil(err)
+	a.ElementsMatch([]ValidationError{
+		{Path: "event.broadcaster_user_name", Message: "required field is missing"},
+		{Path: "event.extra", Message: "field is not defined in the schema"},
+		{Path: "event.followed_at", Message: `"yesterday" is not an RFC3339 timestamp`},
+		{Path: "event.user_id", Message: "expected string, got integer"},
+	}, result.Errors)
+
+	_, err = Validate([]byte(`{"subscription":{"type":"channel.follow","version":"99"}}`))
+	a.NotNil(err)
+
+	_, err = Validate([]byte(`not json`))
+	a.NotNil(err)
+
+	_, err = Validate([]byte(`{"event":{}}`))
+	a.NotNil(err)
+}
+
+// Payloads as Twitch sends them, taken from the EventSub reference rather than generated by the CLI, so the schemas
+// can't just agree with the CLI's own models
+func TestValidateTwitchPayloads(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	payloads := map[string]string{
+		"anonymous cheer": `{
+			"subscription": {"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4", "type": "channel.cheer", "version": "1", "status": "enabled", "cost": 0,
+				"condition": {"broadcaster_user_id": "1337"}, "transport": {"method": "webhook", "callback": "https://example.com/webhooks/callback"},
+				"created_at": "2019-11-16T10:11:12.634234626Z"},
+			"event": {"is_anonymous": true, "user_id": null, "user_login": null, "user_name": null,
+				"broadcaster_user_id": "1337", "broadcaster_user_login": "cooler_user", "broadcaster_user_name": "Cooler_User",
+				"message": "pogchamp", "bits": 1000}
+		}`,
+		"anonymous gift": `{
+			"subscription": {"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4", "type": "channel.subscription.gift", "version": "1", "status": "enabled", "cost": 0,
+				"condition": {"broadcaster_user_id": "1337"}, "transport": {"method": "webhook", "callback": "https://example.com/webhooks/callback"},
+				"created_at": "2019-11-16T10:11:12.634234626Z"},
+			"event": {"user_id": null, "user_login": null, "user_name": null,
+				"broadcaster_user_id": "1337", "broadcaster_user_login": "cooler_user", "broadcaster_user_name": "Cooler_User",
+				"total": 2, "tier": "1000", "cumulative_total": null, "is_anonymous": true}
+		}`,
+		"revoke by a deleted user": `{
+			"subscription": {"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4", "type": "user.authorization.revoke", "version": "1", "status": "enabled", "cost": 1,
+				"condition": {"client_id": "crq72vsaoijkc83xx42hz6i37"}, "transport": {"method": "webhook", "callback": "https://example.com/webhooks/callback"},
+				"created_at": "2019-11-16T10:11:12.634234626Z"},
+			"event": {"client_id": "crq72vsaoijkc83xx42hz6i37", "user_id": "1337", "user_login": null, "user_name": null}
+		}`,
+	}
+
+	for name, payload := range payloads {
+		result, err := Validate([]byte(payload))
+		a.Nil(err, name)
+		a.Empty(result.Errors, name)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const charityTarget = 1500000
+
+// charityStages is a charity campaign that starts, receives a donation followed by a progress event for each step, and then stops.
+func charityStages(s *simulation) []stage {
+	id := util.RandomGUID()
+	current := 0
+
+	amount := func(value int) *models.CharityEventSubEventAmount {
+		return &models.CharityEventSubEventAmount{Value: value, DecimalPlaces: 2, Currency: "USD"}
+	}
+	event := func() models.CharityEventSubEvent {
+		return models.CharityEventSubEvent{
+			ID:                   id,
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			CharityName:          "Example Charity",
+			CharityDescription:   "Example Description",
+			CharityLogo:          "https://abc.cloudfront.net/ppgf/1000/100.png",
+			CharityWebsite:       "https://www.example.com",
+			CurrentAmount:        amount(current),
+			TargetAmount:         amount(charityTarget),
+		}
+	}
+
+	started := event()
+	startedAt := s.start.Format(time.RFC3339Nano)
+	started.StartedAt = &startedAt
+	stages := []stage{{trigger: "charity-start", topic: "channel.charity_campaign.start", at: s.start, event: started}}
+
+	for i := 1; i <= s.Steps; i++ {
+		at := s.at(i, s.Steps)
+		donor := s.users[(i-1)%len(s.users)]
+		value := charityTarget/(2*s.Steps) + int(util.RandomInt(10*1000))
+		current += value
+
+		campaignID := id
+		donation := models.CharityEventSubEvent{
+			CampaignID:           &campaignID,
+			ID:                   util.RandomGUID(),
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			UserID:               &donor.ID,
+			UserLogin:            &donor.Login,
+			UserName:             &donor.Name,
+			CharityName:          started.CharityName,
+			CharityDescription:   started.CharityDescription,
+			CharityLogo:          started.CharityLogo,
+			CharityWebsite:       started.CharityWebsite,
+			Amount:               amount(value),
+		}
+
+		stages = append(stages,
+			stage{trigger: "charity-donate", topic: "channel.charity_campaign.donate", at: at, event: donation},
+			stage{trigger: "charity-progress", topic: "channel.charity_campaign.progress", at: at, event: event()},
+		)
+	}
+
+	stoppedAt := s.start.Add(s.Duration)
+	stopped := event()
+	stoppedAtTimestamp := stoppedAt.Format(time.RFC3339Nano)
+	stopped.StoppedAt = &stoppedAtTimestamp
+
+	return append(stages, stage{trigger: "charity-stop", topic: "channel.charity_campaign.stop", at: stoppedAt, event: stopped})
+}
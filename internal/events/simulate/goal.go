@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// goalStages is a follower goal that begins partway to its target, moves closer with each progress event, and ends once achieved.
+func goalStages(s *simulation) []stage {
+	id := util.RandomGUID()
+	description := s.Title
+	if description == "" {
+		description = "Reach the follower goal"
+	}
+
+	target := int64(100 * s.Steps)
+	current := util.RandomInt(target / 4)
+
+	event := func() models.GoalEventSubEvent {
+		return models.GoalEventSubEvent{
+			ID:                   id,
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			Type:                 "follower",
+			Description:          description,
+			CurrentAmount:        current,
+			TargetAmount:         target,
+			StartedAt:            s.start.Format(time.RFC3339Nano),
+		}
+	}
+
+	stages := []stage{{trigger: "goal-begin", topic: "channel.goal.begin", at: s.start, event: event()}}
+
+	for i := 1; i <= s.Steps; i++ {
+		// Every step makes progress, and the last one reaches the target
+		remaining := target - current
+		if i == s.Steps {
+			current = target
+		} else {
+			current += remaining/int64(s.Steps-i+1) + util.RandomInt(remaining/int64(s.Steps-i+1)+1)/2
+		}
+		stages = append(stages, stage{trigger: "goal-progress", topic: "channel.goal.progress", at: s.at(i, s.Steps), event: event()})
+	}
+
+	endedAt := s.start.Add(s.Duration)
+	achieved := current >= target
+	final := event()
+	ended := endedAt.Format(time.RFC3339Nano)
+	final.EndedAt = &ended
+	final.IsAchieved = &achieved
+
+	return append(stages, stage{trigger: "goal-end", topic: "channel.goal.end", at: endedAt, event: final})
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	// A hype train ends once this long passes without a contribution
+	hypeTrainExpiry   = 5 * time.Minute
+	hypeTrainCooldown = time.Hour
+)
+
+// hypeTrainLevelGoal is the number of points needed to complete a level.
+func hypeTrainLevelGoal(level int64) int64 {
+	return 1000 + 600*level
+}
+
+type hypeTrain struct {
+	level    int64
+	total    int64
+	progress int64 // Points towards the current level
+	last     models.ContributionData
+	totals   map[string]map[string]int64 // Total contributed by each user, by contribution type
+	users    map[string]user
+}
+
+func (h *hypeTrain) contribute(u user) {
+	c := models.ContributionData{
+		TypeOfContribution:           "bits",
+		TotalContribution:            util.RandomInt(900) + 100,
+		UserWhoMadeContribution:      u.ID,
+		UserLoginWhoMadeContribution: u.Login,
+		UserNameWhoMadeContribution:  u.Name,
+	}
+	if util.RandomInt(2) == 0 {
+		// Subscriptions are worth 500 points per tier 1 sub
+		c.TypeOfContribution = "subscription"
+		c.TotalContribution = 500 * (util.RandomInt(3) + 1)
+	}
+
+	h.total += c.TotalContribution
+	h.progress += c.TotalContribution
+	for h.progress >= hypeTrainLevelGoal(h.level) {
+		h.progress -= hypeTrainLevelGoal(h.level)
+		h.level++
+	}
+
+	h.last = c
+	h.users[u.ID] = u
+	if h.totals[c.TypeOfContribution] == nil {
+		h.totals[c.TypeOfContribution] = map[string]int64{}
+	}
+	h.totals[c.TypeOfContribution][u.ID] += c.TotalContribution
+}
+
+// topContributions returns the user who contributed the most of each type.
+func (h *hypeTrain) topContributions() []models.ContributionData {
+	top := []models.ContributionData{}
+	for _, t := range []string{"bits", "subscription"} {
+		var best models.ContributionData
+		for id, total := range h.totals[t] {
+			if total > best.TotalContribution || (total == best.TotalContribution && id < best.UserWhoMadeContribution) {
+				best = models.ContributionData{
+					TotalContribution:            total,
+					TypeOfContribution:           t,
+					UserWhoMadeContribution:      id,
+					UserLoginWhoMadeContribution: h.users[id].Login,
+					UserNameWhoMadeContribution:  h.users[id].Name,
+				}
+			}
+		}
+		if best.UserWhoMadeContribution != "" {
+			top = append(top, best)
+		}
+	}
+	return top
+}
+
+// hypeTrainStages is a hype train that begins with a contribution, levels up as contributions are made with each
+// progress event, and ends once no contributions are made before it expires.
+func hypeTrainStages(s *simulation) []stage {
+	id := util.RandomGUID()
+	h := &hypeTrain{level: 1, totals: map[string]map[string]int64{}, users: map[string]user{}}
+
+	event := func(at time.Time) models.HypeTrainEventSubEvent {
+		progress := h.progress
+		return models.HypeTrainEventSubEvent{
+			ID:                   id,
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			Level:                h.level,
+			Total:                h.total,
+			Progress:             &progress,
+			Goal:                 hypeTrainLevelGoal(h.level),
+			TopContributions:     h.topContributions(),
+			LastContribution:     h.last,
+			StartedAtTimestamp:   s.start.Format(time.RFC3339Nano),
+			ExpiresAtTimestamp:   at.Add(hypeTrainExpiry).Format(time.RFC3339Nano),
+		}
+	}
+
+	next := 0
+	contribute := func(n int) {
+		for i := 0; i < n; i++ {
+			h.contribute(s.users[next%len(s.users)])
+			next++
+		}
+	}
+
+	contribute(1)
+	stages := []stage{{trigger: "hype-train-begin", topic: "channel.hype_train.begin", at: s.start, event: event(s.start)}}
+
+	lastContributionAt := s.start
+	for i := 1; i <= s.Steps; i++ {
+		lastContributionAt = s.at(i, s.Steps)
+		contribute(int(util.RandomInt(3)) + 1)
+		stages = append(stages, stage{trigger: "hype-train-progress", topic: "channel.hype_train.progress", at: lastContributionAt, event: event(lastContributionAt)})
+	}
+
+	endedAt := lastContributionAt.Add(hypeTrainExpiry)
+	final := event(endedAt)
+	final.Progress = nil
+	final.Goal = 0
+	final.ExpiresAtTimestamp = ""
+	final.EndedAtTimestamp = endedAt.Format(time.RFC3339Nano)
+	final.CooldownEndsAtTimestamp = endedAt.Add(hypeTrainCooldown).Format(time.RFC3339Nano)
+
+	return append(stages, stage{trigger: "hype-train-end", topic: "channel.hype_train.end", at: endedAt, event: final})
+}
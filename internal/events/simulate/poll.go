@@ -0,0 +1,140 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	pollBitsPerVote          = 10
+	pollChannelPointsPerVote = 500
+)
+
+type pollChoice struct {
+	id                 string
+	title              string
+	votes              int
+	bitsVotes          int
+	channelPointsVotes int
+}
+
+// pollStages is a poll that begins, gains votes with each progress event, and completes once its duration is over.
+func pollStages(s *simulation) []stage {
+	id := util.RandomGUID()
+	title := s.Title
+	if title == "" {
+		title = "Pineapple on pizza?"
+	}
+	endsAt := s.start.Add(s.Duration)
+
+	choices := []*pollChoice{}
+	for i := 1; i < 5; i++ {
+		choices = append(choices, &pollChoice{id: util.RandomGUID(), title: fmt.Sprintf("Yes but choice %v", i)})
+	}
+
+	event := func(withVotes bool) models.PollEventSubEvent {
+		c := []models.PollEventSubEventChoice{}
+		for _, choice := range choices {
+			ec := models.PollEventSubEventChoice{ID: choice.id, Title: choice.title}
+			if withVotes {
+				ec.Votes = intPointer(choice.votes)
+				ec.BitsVotes = intPointer(choice.bitsVotes)
+				ec.ChannelPointsVotes = intPointer(choice.channelPointsVotes)
+			}
+			c = append(c, ec)
+		}
+
+		return models.PollEventSubEvent{
+			ID:                   id,
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			Title:                title,
+			Choices:              c,
+			BitsVoting: models.PollEventSubEventGoodVoting{
+				IsEnabled:     true,
+				AmountPerVote: pollBitsPerVote,
+			},
+			ChannelPointsVoting: models.PollEventSubEventGoodVoting{
+				IsEnabled:     true,
+				AmountPerVote: pollChannelPointsPerVote,
+			},
+			StartedAt: s.start.Format(time.RFC3339Nano),
+			EndsAt:    endsAt.Format(time.RFC3339Nano),
+		}
+	}
+
+	begin := stage{trigger: "poll-begin", topic: "channel.poll.begin", at: s.start, event: event(false)}
+	if s.Persist {
+		poll := database.Poll{
+			ID:                         id,
+			BroadcasterID:              s.broadcaster.ID,
+			Title:                      title,
+			BitsVotingEnabled:          true,
+			BitsPerVote:                pollBitsPerVote,
+			ChannelPointsVotingEnabled: true,
+			ChannelPointsPerVote:       pollChannelPointsPerVote,
+			Status:                     "ACTIVE",
+			Duration:                   int(s.Duration.Seconds()),
+			StartedAt:                  s.start.Format(time.RFC3339),
+		}
+		for _, c := range choices {
+			poll.Choices = append(poll.Choices, database.PollsChoice{ID: c.id, Title: c.title, PollID: id})
+		}
+		begin.store = func(q *database.Query) error { return q.InsertPoll(poll) }
+	}
+	stages := []stage{begin}
+
+	for i := 1; i <= s.Steps; i++ {
+		for _, c := range choices {
+			bits := int(util.RandomInt(3))
+			channelPoints := int(util.RandomInt(5))
+			c.bitsVotes += bits
+			c.channelPointsVotes += channelPoints
+			c.votes += bits + channelPoints + int(util.RandomInt(20))
+		}
+
+		progress := stage{trigger: "poll-progress", topic: "channel.poll.progress", at: s.at(i, s.Steps), event: event(true)}
+		if s.Persist {
+			progress.store = storePollVotes(choices)
+		}
+		stages = append(stages, progress)
+	}
+
+	final := event(true)
+	final.Status = "completed"
+	final.EndsAt = ""
+	final.EndedAt = endsAt.Format(time.RFC3339Nano)
+	end := stage{trigger: "poll-end", topic: "channel.poll.end", at: endsAt, event: final}
+	if s.Persist {
+		end.store = func(q *database.Query) error {
+			return q.UpdatePoll(database.Poll{ID: id, Status: "COMPLETED", EndedAt: endsAt.Format(time.RFC3339)})
+		}
+	}
+
+	return append(stages, end)
+}
+
+// storePollVotes copies the vote totals as they are now, since the choices keep changing as later stages are built
+func storePollVotes(choices []*pollChoice) func(q *database.Query) error {
+	votes := []database.PollsChoice{}
+	for _, c := range choices {
+		votes = append(votes, database.PollsChoice{ID: c.id, Votes: c.votes, BitsVotes: c.bitsVotes, ChannelPointsVotes: c.channelPointsVotes})
+	}
+
+	return func(q *database.Query) error {
+		for _, v := range votes {
+			err := q.SetPollChoiceVotes(v)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
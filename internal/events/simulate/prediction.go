@@ -0,0 +1,186 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"sort"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Twitch only includes the top 10 predictors of each outcome in events
+const maxTopPredictors = 10
+
+type predictionOutcome struct {
+	id         string
+	title      string
+	color      string
+	predictors []predictor
+}
+
+type predictor struct {
+	user   user
+	amount int
+}
+
+func (o *predictionOutcome) channelPoints() int {
+	sum := 0
+	for _, p := range o.predictors {
+		sum += p.amount
+	}
+	return sum
+}
+
+// predictionStages is a prediction that begins, gains predictors with each progress event, locks once its prediction
+// window is over, and is then resolved with a winning outcome.
+func predictionStages(s *simulation) []stage {
+	id := util.RandomGUID()
+	title := s.Title
+	if title == "" {
+		title = "Will the developer finish this program?"
+	}
+	locksAt := s.start.Add(s.Duration)
+	endedAt := locksAt.Add(time.Minute)
+
+	outcomes := []*predictionOutcome{
+		{id: util.RandomGUID(), title: "yes", color: "blue"},
+		{id: util.RandomGUID(), title: "no", color: "pink"},
+	}
+	winner := outcomes[util.RandomInt(int64(len(outcomes)))]
+
+	event := func(withPredictions bool, resolved bool) models.PredictionEventSubEvent {
+		o := []models.PredictionEventSubEventOutcomes{}
+		for _, outcome := range outcomes {
+			eo := models.PredictionEventSubEventOutcomes{ID: outcome.id, Title: outcome.title, Color: outcome.color}
+			if withPredictions {
+				eo.Users = intPointer(len(outcome.predictors))
+				eo.ChannelPoints = intPointer(outcome.channelPoints())
+				eo.TopPredictors = topPredictors(outcome, outcomes, winner, resolved)
+			}
+			o = append(o, eo)
+		}
+
+		return models.PredictionEventSubEvent{
+			ID:                   id,
+			BroadcasterUserID:    s.broadcaster.ID,
+			BroadcasterUserLogin: s.broadcaster.Login,
+			BroadcasterUserName:  s.broadcaster.Name,
+			Title:                title,
+			Outcomes:             o,
+			StartedAt:            s.start.Format(time.RFC3339Nano),
+		}
+	}
+
+	first := event(false, false)
+	first.LocksAt = locksAt.Format(time.RFC3339Nano)
+	begin := stage{trigger: "prediction-begin", topic: "channel.prediction.begin", at: s.start, event: first}
+	if s.Persist {
+		prediction := database.Prediction{
+			ID:               id,
+			BroadcasterID:    s.broadcaster.ID,
+			Title:            title,
+			PredictionWindow: int(s.Duration.Seconds()),
+			Status:           "ACTIVE",
+			StartedAt:        s.start.Format(time.RFC3339),
+		}
+		for _, o := range outcomes {
+			prediction.Outcomes = append(prediction.Outcomes, database.PredictionOutcome{ID: o.id, Title: o.title, Color: o.color, PredictionID: id})
+		}
+		begin.store = func(q *database.Query) error { return q.InsertPrediction(prediction) }
+	}
+	stages := []stage{begin}
+
+	// Viewers can only make one prediction each, so they're split up across the progress events
+	perStep := len(s.users) / s.Steps
+	if perStep == 0 {
+		perStep = 1
+	}
+	next := 0
+	for i := 1; i <= s.Steps; i++ {
+		added := []database.PredictionPrediction{}
+		for j := 0; j < perStep && next < len(s.users); j++ {
+			outcome := outcomes[util.RandomInt(int64(len(outcomes)))]
+			p := predictor{user: s.users[next], amount: int(util.RandomInt(10*1000)) + 100}
+			outcome.predictors = append(outcome.predictors, p)
+			added = append(added, database.PredictionPrediction{PredictionID: id, UserID: p.user.ID, Amount: p.amount, OutcomeID: outcome.id})
+			next++
+		}
+
+		e := event(true, false)
+		e.LocksAt = locksAt.Format(time.RFC3339Nano)
+		progress := stage{trigger: "prediction-progress", topic: "channel.prediction.progress", at: s.at(i, s.Steps), event: e}
+		if s.Persist {
+			progress.store = func(q *database.Query) error {
+				for _, p := range added {
+					err := q.InsertPredictionPrediction(p)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		stages = append(stages, progress)
+	}
+
+	locked := event(true, false)
+	locked.LockedAt = locksAt.Format(time.RFC3339Nano)
+	lock := stage{trigger: "prediction-lock", topic: "channel.prediction.lock", at: locksAt, event: locked}
+
+	resolved := event(true, true)
+	resolved.WinningOutcomeID = winner.id
+	resolved.Status = "resolved"
+	resolved.EndedAt = endedAt.Format(time.RFC3339Nano)
+	end := stage{trigger: "prediction-end", topic: "channel.prediction.end", at: endedAt, event: resolved}
+
+	if s.Persist {
+		lockedAt := locksAt.Format(time.RFC3339)
+		lock.store = func(q *database.Query) error {
+			return q.UpdatePrediction(database.Prediction{ID: id, BroadcasterID: s.broadcaster.ID, Status: "LOCKED", LockedAt: &lockedAt})
+		}
+		winnerID := winner.id
+		ended := endedAt.Format(time.RFC3339)
+		end.store = func(q *database.Query) error {
+			return q.UpdatePrediction(database.Prediction{ID: id, BroadcasterID: s.broadcaster.ID, Status: "RESOLVED", WinningOutcomeID: &winnerID, EndedAt: &ended})
+		}
+	}
+
+	return append(stages, lock, end)
+}
+
+// topPredictors returns the predictors of outcome who used the most channel points. Once resolved, the winners share
+// every channel point used in proportion to what they used, and the rest win nothing.
+func topPredictors(outcome *predictionOutcome, outcomes []*predictionOutcome, winner *predictionOutcome, resolved bool) *[]models.PredictionEventSubEventTopPredictors {
+	total := 0
+	for _, o := range outcomes {
+		total += o.channelPoints()
+	}
+
+	sorted := append([]predictor{}, outcome.predictors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].amount > sorted[j].amount })
+	if len(sorted) > maxTopPredictors {
+		sorted = sorted[:maxTopPredictors]
+	}
+
+	tp := []models.PredictionEventSubEventTopPredictors{}
+	for _, p := range sorted {
+		t := models.PredictionEventSubEventTopPredictors{
+			UserID:            p.user.ID,
+			UserLogin:         p.user.Login,
+			UserName:          p.user.Name,
+			ChannelPointsUsed: p.amount,
+		}
+		if resolved {
+			won := 0
+			if outcome == winner {
+				won = p.amount * total / winner.channelPoints()
+			}
+			t.ChannelPointsWon = intPointer(won)
+		}
+		tp = append(tp, t)
+	}
+	return &tp
+}
@@ -0,0 +1,249 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// SimulateParameters defines the parameters used to simulate the lifecycle of a poll, prediction, hype train, goal or charity campaign.
+type SimulateParameters struct {
+	Kind            string
+	Transport       string
+	ForwardAddress  string
+	Secret          string
+	WebSocketClient string
+	ToUser          string
+	ToUserName      string
+	Title           string
+	Steps           int           // Number of progress events between the first and last event
+	Duration        time.Duration // How long the simulated object runs for; each kind has its own default
+	Interval        time.Duration // Real time waited between sending each event
+	Timestamp       string        // When the simulated object starts; defaults to now
+	Persist         bool          // Stores the simulated object in the mock API database
+}
+
+type lifecycle struct {
+	build           func(s *simulation) []stage
+	defaultDuration time.Duration
+	persistable     bool // Whether the mock API stores this kind of object
+}
+
+var lifecycles = map[string]lifecycle{
+	"poll":       {build: pollStages, defaultDuration: 5 * time.Minute, persistable: true},
+	"prediction": {build: predictionStages, defaultDuration: 2 * time.Minute, persistable: true},
+	"hype-train": {build: hypeTrainStages, defaultDuration: 5 * time.Minute},
+	"goal":       {build: goalStages, defaultDuration: 24 * time.Hour},
+	"charity":    {build: charityStages, defaultDuration: 2 * time.Hour},
+}
+
+// Kinds are the lifecycles that can be simulated.
+var Kinds = []string{"poll", "prediction", "hype-train", "goal", "charity"}
+
+// simulation holds what's shared by every stage of a lifecycle.
+type simulation struct {
+	SimulateParameters
+	start       time.Time
+	broadcaster user
+	users       []user // Viewers taking part, such as voters, predictors and donors
+}
+
+type user struct {
+	ID    string
+	Login string
+	Name  string
+}
+
+// stage is a single event in a lifecycle.
+type stage struct {
+	trigger string
+	topic   string
+	at      time.Time
+	event   interface{}
+	store   func(q *database.Query) error // Updates the mock API database to match the event; only set when persisting
+}
+
+// at returns the time of progress event i of n, spread evenly across the simulation's duration.
+func (s *simulation) at(i int, n int) time.Time {
+	return s.start.Add(s.Duration * time.Duration(i) / time.Duration(n+1))
+}
+
+// Run sends every event in the lifecycle, in order, and returns the payloads that were sent.
+func Run(p SimulateParameters) ([]string, error) {
+	l, ok := lifecycles[p.Kind]
+	if !ok {
+		return nil, fmt.Errorf("Unable to simulate %q. Valid values are: %v", p.Kind, strings.Join(Kinds, ", "))
+	}
+	if p.Persist && !l.persistable {
+		return nil, fmt.Errorf("The mock API doesn't store %v objects, so --persist can only be used with poll and prediction", p.Kind)
+	}
+	if p.Steps < 1 {
+		return nil, fmt.Errorf("--steps must be at least 1")
+	}
+	if p.Duration <= 0 {
+		p.Duration = l.defaultDuration
+	}
+
+	s, err := newSimulation(p)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads := []string{}
+	subscriptionIDs := map[string]string{}
+	for i, st := range l.build(s) {
+		if i > 0 && p.Interval > 0 {
+			time.Sleep(p.Interval)
+		}
+
+		// Each topic is its own subscription, so events of the same type share a subscription ID
+		if subscriptionIDs[st.topic] == "" {
+			subscriptionIDs[st.topic] = util.RandomGUID()
+		}
+
+		res, err := send(s, st, subscriptionIDs[st.topic])
+		if err != nil {
+			return payloads, err
+		}
+		payloads = append(payloads, res)
+
+		if st.store != nil {
+			db, err := database.NewConnection(false)
+			if err != nil {
+				return payloads, err
+			}
+			err = st.store(db.NewQuery(nil, 100))
+			db.DB.Close()
+			if err != nil {
+				return payloads, fmt.Errorf("Unable to store %v in the mock API database: %v", st.trigger, err)
+			}
+		}
+	}
+
+	return payloads, nil
+}
+
+func newSimulation(p SimulateParameters) (*simulation, error) {
+	s := &simulation{SimulateParameters: p}
+
+	if p.Timestamp == "" {
+		s.start = util.GetTimestamp()
+	} else {
+		start, err := time.Parse(time.RFC3339Nano, p.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid timestamp provided. Please follow RFC3339Nano")
+		}
+		s.start = start
+	}
+
+	if !p.Persist {
+		s.broadcaster = user{ID: p.ToUser, Name: p.ToUserName}
+		if s.broadcaster.ID == "" {
+			s.broadcaster.ID = util.RandomUserID()
+		}
+		if s.broadcaster.Name == "" {
+			s.broadcaster.Name = "testBroadcaster"
+		}
+		s.broadcaster.Login = strings.ToLower(s.broadcaster.Name)
+
+		for i := 1; i <= 3*p.Steps; i++ {
+			s.users = append(s.users, user{ID: util.RandomUserID(), Login: fmt.Sprintf("cli_user%v", i), Name: fmt.Sprintf("cli_user%v", i)})
+		}
+		return s, nil
+	}
+
+	// Persisted objects must belong to users of the mock API, so that they're returned by its endpoints
+	if p.ToUser == "" {
+		return nil, fmt.Errorf("--to-user must be set to the ID of a mock API user when using --persist")
+	}
+
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return nil, err
+	}
+	defer db.DB.Close()
+
+	broadcaster, err := db.NewQuery(nil, 100).GetUser(database.User{ID: p.ToUser})
+	if err != nil {
+		return nil, err
+	}
+	if broadcaster.ID == "" {
+		return nil, fmt.Errorf("User %v doesn't exist in the mock API database. Users can be created with `twitch mock-api generate`", p.ToUser)
+	}
+	s.broadcaster = user{ID: broadcaster.ID, Login: broadcaster.UserLogin, Name: broadcaster.DisplayName}
+
+	dbr, err := db.NewQuery(nil, 100).GetUsers(database.User{})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range dbr.Data.([]database.User) {
+		if u.ID != broadcaster.ID {
+			s.users = append(s.users, user{ID: u.ID, Login: u.UserLogin, Name: u.DisplayName})
+		}
+	}
+	if len(s.users) == 0 {
+		return nil, fmt.Errorf("The mock API database has no users other than the broadcaster to take part. Users can be created with `twitch mock-api generate`")
+	}
+
+	return s, nil
+}
+
+func send(s *simulation, st stage, subscriptionID string) (string, error) {
+	// Messages are stamped with when they're sent, so receivers checking freshness accept them. The simulated timeline
+	// is only used in the payload.
+	timestamp := util.GetTimestamp().Format(time.RFC3339Nano)
+
+	body := models.EventsubResponse{
+		Subscription: models.EventsubSubscription{
+			ID:      subscriptionID,
+			Status:  "enabled",
+			Type:    st.topic,
+			Version: "1",
+			Condition: models.EventsubCondition{
+				BroadcasterUserID: s.broadcaster.ID,
+			},
+			Transport: models.EventsubTransport{
+				Method:   "webhook",
+				Callback: "null",
+			},
+			Cost:      0,
+			CreatedAt: s.start.Format(time.RFC3339Nano),
+		},
+		Event: st.event,
+	}
+
+	event, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	messageID := util.RandomGUID()
+	return trigger.Send(trigger.TriggerParameters{
+		Event:              st.trigger,
+		Transport:          s.Transport,
+		ForwardAddress:     s.ForwardAddress,
+		Secret:             s.Secret,
+		WebSocketClient:    s.WebSocketClient,
+		Timestamp:          timestamp,
+		EventMessageID:     messageID,
+		SubscriptionStatus: "enabled",
+		ToUser:             s.broadcaster.ID,
+	}, st.topic, "1", events.MockEventResponse{
+		ID:     messageID,
+		JSON:   event,
+		ToUser: s.broadcaster.ID,
+	})
+}
+
+func intPointer(i int) *int {
+	return &i
+}
@@ -0,0 +1,215 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package simulate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/schema"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+type lifecyclePayload struct {
+	Subscription models.EventsubSubscription `json:"subscription"`
+	Event        struct {
+		ID            string          `json:"id"`
+		CampaignID    string          `json:"campaign_id"`
+		Total         int64           `json:"total"`
+		Level         int64           `json:"level"`
+		CurrentAmount json.RawMessage `json:"current_amount"`
+		Choices       []struct {
+			Votes int `json:"votes"`
+		} `json:"choices"`
+		Outcomes []struct {
+			ChannelPoints int `json:"channel_points"`
+		} `json:"outcomes"`
+	} `json:"event"`
+}
+
+func TestRun(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	received := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		a.Nil(err)
+		received++
+
+		// later stages are stamped with when they're sent, not when they happen in the simulated timeline
+		sent, err := time.Parse(time.RFC3339Nano, r.Header.Get("Twitch-Eventsub-Message-Timestamp"))
+		a.Nil(err)
+		a.WithinDuration(time.Now(), sent, time.Minute)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	expected := map[string][]string{
+		"poll":       {"channel.poll.begin", "channel.poll.progress", "channel.poll.progress", "channel.poll.progress", "channel.poll.end"},
+		"prediction": {"channel.prediction.begin", "channel.prediction.progress", "channel.prediction.progress", "channel.prediction.progress", "channel.prediction.lock", "channel.prediction.end"},
+		"hype-train": {"channel.hype_train.begin", "channel.hype_train.progress", "channel.hype_train.progress", "channel.hype_train.progress", "channel.hype_train.end"},
+		"goal":       {"channel.goal.begin", "channel.goal.progress", "channel.goal.progress", "channel.goal.progress", "channel.goal.end"},
+		"charity":    {"channel.charity_campaign.start", "channel.charity_campaign.donate", "channel.charity_campaign.progress", "channel.charity_campaign.donate", "channel.charity_campaign.progress", "channel.charity_campaign.donate", "channel.charity_campaign.progress", "channel.charity_campaign.stop"},
+	}
+
+	for _, kind := range Kinds {
+		received = 0
+		payloads, err := Run(SimulateParameters{
+			Kind:           kind,
+			Transport:      models.TransportWebhook,
+			ForwardAddress: ts.URL,
+			Secret:         "potatopotato",
+			ToUser:         "1234",
+			Steps:          3,
+		})
+		a.Nil(err, kind)
+		a.Len(payloads, len(expected[kind]), kind)
+		a.Equal(len(payloads), received, kind)
+
+		var id string
+		var lastAmount int64
+		for i, p := range payloads {
+			result, err := schema.Validate([]byte(p))
+			a.Nil(err)
+			a.Empty(result.Errors, "%v: %v", kind, p)
+
+			var body lifecyclePayload
+			err = json.Unmarshal([]byte(p), &body)
+			a.Nil(err)
+			a.Equal(expected[kind][i], body.Subscription.Type)
+			a.Equal("1234", body.Subscription.Condition.BroadcasterUserID)
+
+			// Every event describes the same object
+			eventID := body.Event.ID
+			if body.Event.CampaignID != "" {
+				eventID = body.Event.CampaignID
+			}
+			if i == 0 {
+				id = eventID
+			}
+			a.Equal(id, eventID, kind)
+
+			// Amounts only ever go up
+			amount := body.Event.Total
+			for _, c := range body.Event.Choices {
+				amount += int64(c.Votes)
+			}
+			for _, o := range body.Event.Outcomes {
+				amount += int64(o.ChannelPoints)
+			}
+			if len(body.Event.CurrentAmount) != 0 {
+				// Goals have a number, while charity campaigns have an amount object
+				var current models.CharityEventSubEventAmount
+				if json.Unmarshal(body.Event.CurrentAmount, &current) != nil {
+					a.Nil(json.Unmarshal(body.Event.CurrentAmount, &current.Value))
+				}
+				amount += int64(current.Value)
+			}
+			if body.Subscription.Type != "channel.charity_campaign.donate" {
+				a.GreaterOrEqual(amount, lastAmount, "%v: %v", kind, p)
+				lastAmount = amount
+			}
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	_, err := Run(SimulateParameters{Kind: "potato", Transport: models.TransportWebhook, Steps: 3})
+	a.NotNil(err)
+
+	_, err = Run(SimulateParameters{Kind: "goal", Transport: models.TransportWebhook, Steps: 3, Persist: true, ToUser: "1"})
+	a.NotNil(err)
+
+	_, err = Run(SimulateParameters{Kind: "poll", Transport: models.TransportWebhook, Steps: 0})
+	a.NotNil(err)
+
+	_, err = Run(SimulateParameters{Kind: "poll", Transport: models.TransportWebhook, Steps: 3, Persist: true})
+	a.NotNil(err)
+
+	_, err = Run(SimulateParameters{Kind: "poll", Transport: models.TransportWebhook, Steps: 3, Persist: true, ToUser: "doesnotexist"})
+	a.NotNil(err)
+
+	_, err = Run(SimulateParameters{Kind: "poll", Transport: models.TransportWebhook, Steps: 3, Timestamp: "yesterday"})
+	a.NotNil(err)
+}
+
+func TestTiming(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	for _, kind := range Kinds {
+		s, err := newSimulation(SimulateParameters{Kind: kind, Steps: 4, Duration: lifecycles[kind].defaultDuration, Timestamp: "2024-01-01T00:00:00Z"})
+		a.Nil(err)
+
+		stages := lifecycles[kind].build(s)
+		a.Equal(s.start, stages[0].at)
+		for i := 1; i < len(stages); i++ {
+			a.False(stages[i].at.Before(stages[i-1].at), kind)
+		}
+	}
+}
+
+func TestPersist(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	db, err := database.NewConnection(true)
+	a.Nil(err)
+	defer db.DB.Close()
+	q := db.NewQuery(nil, 100)
+
+	broadcaster := database.User{ID: util.RandomUserID(), UserLogin: "simbroadcaster", DisplayName: "SimBroadcaster", Email: "", CreatedAt: util.GetTimestamp().Format(time.RFC3339)}
+	viewer := database.User{ID: util.RandomUserID(), UserLogin: "simviewer", DisplayName: "SimViewer", Email: "", CreatedAt: util.GetTimestamp().Format(time.RFC3339)}
+	a.Nil(q.InsertUser(broadcaster, false))
+	a.Nil(q.InsertUser(viewer, false))
+
+	payloads, err := Run(SimulateParameters{Kind: "poll", Transport: models.TransportWebhook, ToUser: broadcaster.ID, Steps: 2, Persist: true})
+	a.Nil(err)
+
+	var pollEnd models.PollEventSubResponse
+	a.Nil(json.Unmarshal([]byte(payloads[len(payloads)-1]), &pollEnd))
+	a.Equal("simbroadcaster", pollEnd.Event.BroadcasterUserLogin)
+
+	dbr, err := q.GetPolls(database.Poll{ID: pollEnd.Event.ID})
+	a.Nil(err)
+	polls := dbr.Data.([]database.Poll)
+	a.Len(polls, 1)
+	a.Equal("COMPLETED", polls[0].Status)
+	for _, c := range polls[0].Choices {
+		for _, ec := range pollEnd.Event.Choices {
+			if ec.ID == c.ID {
+				a.Equal(*ec.Votes, c.Votes)
+				a.Equal(*ec.BitsVotes, c.BitsVotes)
+				a.Equal(*ec.ChannelPointsVotes, c.ChannelPointsVotes)
+			}
+		}
+	}
+
+	payloads, err = Run(SimulateParameters{Kind: "prediction", Transport: models.TransportWebhook, ToUser: broadcaster.ID, Steps: 1, Persist: true})
+	a.Nil(err)
+
+	var predictionEnd models.PredictionEventSubResponse
+	a.Nil(json.Unmarshal([]byte(payloads[len(payloads)-1]), &predictionEnd))
+
+	dbr, err = q.GetPredictions(database.Prediction{ID: predictionEnd.Event.ID})
+	a.Nil(err)
+	predictions := dbr.Data.([]database.Prediction)
+	a.Len(predictions, 1)
+	a.Equal("RESOLVED", predictions[0].Status)
+	a.Equal(predictionEnd.Event.WinningOutcomeID, *predictions[0].WinningOutcomeID)
+	for _, o := range predictions[0].Outcomes {
+		for _, eo := range predictionEnd.Event.Outcomes {
+			if eo.ID == o.ID {
+				a.Equal(*eo.Users, o.Users)
+				a.Equal(*eo.ChannelPoints, o.ChannelPoints)
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/models"
@@ -28,11 +29,7 @@ type ForwardParamters struct {
 	Method              string
 	Type                string
 	SubscriptionVersion string
-}
-
-type header struct {
-	HeaderName  string
-	HeaderValue string
+	Retry               int // Sent as Twitch-Eventsub-Message-Retry; redeliveries of a message count up from 1
 }
 
 const (
@@ -41,16 +38,44 @@ const (
 	EventSubMessageTypeRevocation   = "revocation"
 )
 
-var notificationHeaders = map[string][]header{
-	models.TransportWebhook: {
-		{
-			HeaderName:  `Twitch-Eventsub-Message-Retry`,
-			HeaderValue: `0`,
-		},
-	},
+func ForwardEvent(p ForwardParamters) (*http.Response, error) {
+	req, err := NewForwardRequest(p)
+	if err != nil {
+		return &http.Response{}, err
+	}
+
+	resp, err := NewForwardClient(0).Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
 }
 
-func ForwardEvent(p ForwardParamters) (*http.Response, error) {
+// NewForwardClient returns the HTTP client events are forwarded with. A client can be reused to keep connections open
+// between events, with up to idleConnections kept open to the forward address; zero uses the Go default.
+func NewForwardClient(idleConnections int) *http.Client {
+	// Twitch only supports IPv4 currently, so we will force this TCP connection to only use IPv4
+	var dialer net.Dialer
+	transport := request.NewTransport()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp4", addr)
+	}
+	if idleConnections > 0 {
+		transport.MaxIdleConns = idleConnections
+		transport.MaxIdleConnsPerHost = idleConnections
+	}
+
+	client := request.NewClient(time.Second * 10)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	client.Transport = request.WrapTransport(transport)
+	return client
+}
+
+// NewForwardRequest builds the request an event is forwarded with, including the EventSub headers and signature.
+func NewForwardRequest(p ForwardParamters) (*http.Request, error) {
 	method := http.MethodPost
 	if p.Method != "" {
 		method = p.Method
@@ -58,16 +83,14 @@ func ForwardEvent(p ForwardParamters) (*http.Response, error) {
 
 	req, err := request.NewRequest(method, p.ForwardAddress, bytes.NewBuffer(p.JSON))
 	if err != nil {
-		return &http.Response{}, err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	for _, header := range notificationHeaders[p.Transport] {
-		req.Header.Add(header.HeaderName, header.HeaderValue)
-	}
 
 	switch p.Transport {
 	case models.TransportWebhook:
+		req.Header.Set("Twitch-Eventsub-Message-Retry", strconv.Itoa(p.Retry))
 		req.Header.Set("Twitch-Eventsub-Message-Id", p.ID)
 		req.Header.Set("Twitch-Eventsub-Subscription-Type", p.Event)
 		req.Header.Set("Twitch-Eventsub-Subscription-Version", p.SubscriptionVersion)
@@ -81,41 +104,21 @@ func ForwardEvent(p ForwardParamters) (*http.Response, error) {
 		}
 	}
 
-	// Twitch only supports IPv4 currently, so we will force this TCP connection to only use IPv4
-	var dialer net.Dialer
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-		return dialer.DialContext(ctx, "tcp4", addr)
-	}
-
 	if p.Secret != "" {
 		getSignatureHeader(req, p.ID, p.Secret, p.Transport, p.Timestamp, p.JSON)
 	}
 
-	client := &http.Client{
-		Timeout: time.Second * 10,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-		Transport: transport,
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, nil
+	return req, nil
 }
 
 func getSignatureHeader(req *http.Request, id string, secret string, transport string, timestamp string, payload []byte) {
 	mac := hmac.New(sha256.New, []byte(secret))
-	ts, _ := time.Parse(time.RFC3339Nano, timestamp)
 
 	switch transport {
 	case models.TransportWebhook:
+		// Like Twitch, the timestamp is signed exactly as it's sent in the header
 		req.Header.Set("Twitch-Eventsub-Message-Timestamp", timestamp)
-		prefix := ts.AppendFormat([]byte(id), time.RFC3339Nano)
-		mac.Write(prefix)
+		mac.Write([]byte(id + timestamp))
 		mac.Write(payload)
 		req.Header.Set("Twitch-Eventsub-Message-Signature", fmt.Sprintf("sha256=%x", mac.Sum(nil)))
 	}
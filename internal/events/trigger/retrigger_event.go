@@ -40,7 +40,7 @@ func RefireEvent(id string, p TriggerParameters) (string, error) {
 	}
 
 	if p.ForwardAddress != "" {
-		resp, err := ForwardEvent(ForwardParamters{
+		fp := ForwardParamters{
 			ID:                  id,
 			Transport:           res.Transport,
 			Timestamp:           p.Timestamp,
@@ -51,15 +51,27 @@ func RefireEvent(id string, p TriggerParameters) (string, error) {
 			EventMessageID:      "",
 			Type:                EventSubMessageTypeNotification,
 			SubscriptionVersion: e.SubscriptionVersion(),
-		})
+		}
 
-		if err != nil {
-			return "", err
+		// Without retries the event is sent as a new delivery. Retries are redeliveries of the stored event, so they keep its original timestamp.
+		first := 0
+		if p.Retries > 0 {
+			first = 1
+			if res.Timestamp != "" {
+				fp.Timestamp = res.Timestamp
+			}
 		}
 
-		defer resp.Body.Close()
+		for retry := first; retry <= p.Retries; retry++ {
+			fp.Retry = retry
+			resp, err := ForwardEvent(fp)
+			if err != nil {
+				return "", err
+			}
+			resp.Body.Close()
 
-		fmt.Printf("[%v] Endpoint received refired event.", resp.StatusCode)
+			fmt.Printf("[%v] Endpoint received refired event.", resp.StatusCode)
+		}
 	}
 
 	return res.JSON, nil
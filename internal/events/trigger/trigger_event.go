@@ -7,14 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/rpc"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/viper"
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/payload"
 	"github.com/twitchdev/twitch-cli/internal/events/types"
 	"github.com/twitchdev/twitch-cli/internal/models"
 	rpc_handler "github.com/twitchdev/twitch-cli/internal/rpc"
@@ -53,6 +56,21 @@ type TriggerParameters struct {
 	WebSocketClient     string
 	BanStartTimestamp   string
 	BanEndTimestamp     string
+	MessageText         string
+	NoticeType          string
+	Color               string
+	ModerateAction      string
+	ModeratorUser       string
+	ModeratorUserName   string
+	RewardType          string
+	ParticipantIDs      []string
+	Overrides           *payload.Overrides
+	// Webhook events are delivered again this many times with the same message ID and timestamp, as Twitch does when a delivery fails
+	Retries int
+	// Moves the generated timestamp away from now, such as -11m to test freshness checks. Not used when Timestamp is set.
+	TimestampSkew time.Duration
+	// Database the event is stored in, such as the one of the mock API server that caused it. Defaults to the CLI's database.
+	Database *database.CLIDatabase
 }
 
 type TriggerResponse struct {
@@ -63,8 +81,26 @@ type TriggerResponse struct {
 	Timestamp string
 }
 
+// GeneratedEvent is an event created by Generate, along with the parameters it was created with once defaults were filled in.
+type GeneratedEvent struct {
+	Parameters TriggerParameters
+	Topic      string
+	Version    string
+	Response   events.MockEventResponse
+}
+
 // Fire emits an event using the TriggerParameters defined above.
 func Fire(p TriggerParameters) (string, error) {
+	g, err := Generate(p)
+	if err != nil {
+		return "", err
+	}
+
+	return Send(g.Parameters, g.Topic, g.Version, g.Response)
+}
+
+// Generate creates an event without storing or delivering it, filling in defaults for any parameters that aren't set.
+func Generate(p TriggerParameters) (GeneratedEvent, error) {
 	var resp events.MockEventResponse
 	var err error
 
@@ -104,7 +140,7 @@ func Fire(p TriggerParameters) (string, error) {
 	case "1000", "2000", "3000":
 		// do nothing, these are valid values
 	default:
-		return "", fmt.Errorf(
+		return GeneratedEvent{}, fmt.Errorf(
 			"Discarding event: Invalid tier provided.\n" +
 				"Valid values are 1000, 2000 or 3000")
 	}
@@ -120,12 +156,12 @@ func Fire(p TriggerParameters) (string, error) {
 	}
 
 	if p.Timestamp == "" {
-		p.Timestamp = util.GetTimestamp().Format(time.RFC3339Nano)
+		p.Timestamp = util.GetTimestamp().Add(p.TimestampSkew).Format(time.RFC3339Nano)
 	} else {
 		// Verify custom timestamp
 		_, err := time.Parse(time.RFC3339Nano, p.Timestamp)
 		if err != nil {
-			return "", fmt.Errorf(
+			return GeneratedEvent{}, fmt.Errorf(
 				`Discarding event: Invalid timestamp provided.
 Please follow RFC3339Nano, which is used by Twitch as seen here:
 https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`)
@@ -157,11 +193,19 @@ https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`
 		GiftUser:            p.GiftUser,
 		BanStartTimestamp:   p.BanStartTimestamp,
 		BanEndTimestamp:     p.BanEndTimestamp,
+		MessageText:         p.MessageText,
+		NoticeType:          p.NoticeType,
+		Color:               p.Color,
+		ModerateAction:      p.ModerateAction,
+		ModeratorUserID:     p.ModeratorUser,
+		ModeratorUserName:   p.ModeratorUserName,
+		RewardType:          p.RewardType,
+		ParticipantIDs:      p.ParticipantIDs,
 	}
 
 	e, err := types.GetByTriggerAndTransportAndVersion(p.Event, p.Transport, p.Version)
 	if err != nil {
-		return "", err
+		return GeneratedEvent{}, err
 	}
 
 	newTrigger := e.GetEventSubAlias(p.Event)
@@ -171,14 +215,85 @@ https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`
 
 	resp, err = e.GenerateEvent(eventParamaters)
 	if err != nil {
-		return "", err
+		return GeneratedEvent{}, err
+	}
+
+	topic := e.GetTopic(p.Transport, p.Event)
+	if topic == "" && e.GetEventSubAlias(p.Event) != "" {
+		topic = p.Event
 	}
 
-	db, err := database.NewConnection(false)
+	return GeneratedEvent{Parameters: p, Topic: topic, Version: e.SubscriptionVersion(), Response: resp}, nil
+}
+
+// Send stores and delivers an event that has already been generated, the same way Fire does for the events it generates.
+// It's used by commands that build payloads themselves, such as event simulate. The payload overrides in p are applied first.
+func Send(p TriggerParameters, topic string, version string, resp events.MockEventResponse) (string, error) {
+	resp, err := store(p, topic, resp)
 	if err != nil {
 		return "", err
 	}
 
+	return deliver(p, topic, version, resp)
+}
+
+// FireOutOfOrder generates and stores count events in order, then delivers them all at once in a random order, to test
+// that handlers don't rely on events arriving in the order they happened. The payloads are returned in the order they were generated.
+func FireOutOfOrder(p TriggerParameters, count int) ([]string, error) {
+	generated := []GeneratedEvent{}
+	for i := 0; i < count; i++ {
+		g, err := Generate(p)
+		if err != nil {
+			return nil, err
+		}
+
+		g.Response, err = store(g.Parameters, g.Topic, g.Response)
+		if err != nil {
+			return nil, err
+		}
+		generated = append(generated, g)
+	}
+
+	order := rand.Perm(len(generated))
+	payloads := make([]string, len(generated))
+	errs := make([]error, len(generated))
+
+	var wg sync.WaitGroup
+	for _, i := range order {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g := generated[i]
+			payloads[i], errs[i] = deliver(g.Parameters, g.Topic, g.Version, g.Response)
+		}(i)
+	}
+	wg.Wait()
+
+	return payloads, errors.Join(errs...)
+}
+
+// store applies the payload overrides in p to an event, and then stores it in the event history.
+func store(p TriggerParameters, topic string, resp events.MockEventResponse) (events.MockEventResponse, error) {
+	var err error
+
+	// Overrides are applied before the event is stored or forwarded, so retriggers reuse them and the signature covers them
+	if len(resp.JSON) != 0 {
+		resp.JSON, err = p.Overrides.Apply(resp.JSON)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	db := p.Database
+	if db == nil {
+		conn, err := database.NewConnection(false)
+		if err != nil {
+			return resp, err
+		}
+		defer conn.DB.Close()
+		db = &conn
+	}
+
 	//color.New().Add(color.FgGreen).Println(fmt.Sprintf(`Insert into DB with %v`, resp.ID));
 	err = db.NewQuery(nil, 100).InsertIntoDB(database.EventCacheParameters{
 		ID:        resp.ID,
@@ -190,13 +305,30 @@ https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`
 		Timestamp: p.Timestamp,
 	})
 	if err != nil {
-		return "", err
+		return resp, err
 	}
-	topic := e.GetTopic(p.Transport, p.Event)
-	if topic == "" && e.GetEventSubAlias(p.Event) != "" {
-		topic = p.Event
+
+	// Chat messages are kept in the mock chat store, so they can later be acted on through the mock API (e.g. deleted by a moderator)
+	if topic == "channel.chat.message" && strings.EqualFold(p.SubscriptionStatus, "enabled") {
+		err = storeChatMessage(*db, resp.JSON)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	// Held messages are kept too, so they can be approved or denied through the mock API
+	if topic == "automod.message.hold" && strings.EqualFold(p.SubscriptionStatus, "enabled") {
+		err = storeAutomodMessage(*db, resp.JSON)
+		if err != nil {
+			return resp, err
+		}
 	}
 
+	return resp, nil
+}
+
+// deliver forwards an event that has already been stored to the forward address or the WebSocket server, and returns its payload.
+func deliver(p TriggerParameters, topic string, version string, resp events.MockEventResponse) (string, error) {
 	messageType := EventSubMessageTypeNotification
 	// Set to "revocation" if SubscriptionStatus is not set to "enabled"
 	// We don't have to worry about "webhook_callback_verification" in this bit of code, since it's an entirely different command. All this code is from "event trigger".
@@ -205,77 +337,43 @@ https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`
 	}
 
 	if p.ForwardAddress != "" && strings.EqualFold(p.Transport, "webhook") { // Forwarding to an address requires Webhook, as its done via HTTP
-		resp, err := ForwardEvent(ForwardParamters{
-			ID:                  resp.ID,
-			Transport:           p.Transport,
-			Timestamp:           p.Timestamp,
-			JSON:                resp.JSON,
-			Secret:              p.Secret,
-			ForwardAddress:      p.ForwardAddress,
-			Event:               topic,
-			EventMessageID:      p.EventMessageID,
-			Type:                messageType,
-			SubscriptionVersion: e.SubscriptionVersion(),
-		})
-		if err != nil {
-			return "", err
-		}
-		defer resp.Body.Close()
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return "", err
-		}
-
-		respTrigger := string(body)
-		if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-			color.New().Add(color.FgGreen).Println(fmt.Sprintf(`✔ Request Sent. Received Status Code: %v`, resp.StatusCode))
-			color.New().Add(color.FgGreen).Println(fmt.Sprintf(`✔ Server Said: %s`, respTrigger))
-		} else {
-			color.New().Add(color.FgRed).Println(fmt.Sprintf(`✗ Invalid response. Received Status Code: %v`, resp.StatusCode))
-			color.New().Add(color.FgRed).Println(fmt.Sprintf(`✗ Server Said: %s`, respTrigger))
+		// Retries reuse the message ID and timestamp of the first delivery, so handlers' deduplication and replay checks can be tested
+		for retry := 0; retry <= p.Retries; retry++ {
+			err := forwardWebhook(ForwardParamters{
+				ID:                  resp.ID,
+				Transport:           p.Transport,
+				Timestamp:           p.Timestamp,
+				JSON:                resp.JSON,
+				Secret:              p.Secret,
+				ForwardAddress:      p.ForwardAddress,
+				Event:               topic,
+				EventMessageID:      p.EventMessageID,
+				Type:                messageType,
+				SubscriptionVersion: version,
+				Retry:               retry,
+			})
+			if err != nil {
+				return "", err
+			}
 		}
 	}
 
 	// Forward to WebSocket server via RPC
 	if strings.EqualFold(p.Transport, "websocket") {
-		client, err := rpc.DialHTTP("tcp", ":44747")
+		client, err := DialWebSocketServer()
 		if err != nil {
-			return "", errors.New(
-				"Failed to dial RPC handler for WebSocket server; It may not be running. See `twitch event websocket --help` for help on starting the WebSocket server.\n" +
-					"Error: " + err.Error(),
-			)
+			return "", err
 		}
+		defer client.Close()
 
-		var reply rpc_handler.RPCResponse
-
-		// Modify transport
-		modifiedTransportJSON := models.EventsubResponse{}
-		err = json.Unmarshal([]byte(resp.JSON), &modifiedTransportJSON)
+		resp.JSON, err = WebSocketPayload(resp.JSON)
 		if err != nil {
-			return "", errors.New("Unexpected error unmarshling JSON before forwarding to WebSocket server: " + err.Error())
-		}
-		modifiedTransportJSON.Subscription.Transport.Method = "websocket"
-		modifiedTransportJSON.Subscription.Transport.Callback = ""
-		modifiedTransportJSON.Subscription.Transport.SessionID = "WebSocket-Server-Will-Set"
-		rawModifiedTransportJSON, _ := json.Marshal(modifiedTransportJSON)
-		resp.JSON = rawModifiedTransportJSON
-
-		// Trigger any EventSub subscription that's available over 1st party WebSocket connections
-		variables := make(map[string]string)
-		variables["ClientName"] = p.WebSocketClient
-
-		args := &rpc_handler.RPCArgs{
-			RPCName:   "EventSubWebSocketForwardEvent",
-			Body:      string(resp.JSON),
-			Variables: variables,
+			return "", err
 		}
 
-		err = client.Call("RPCHandler.ExecuteGenericRPC", args, &reply)
-
-		// Error checking for RPC internals
+		reply, err := ForwardToWebSocket(client, p.WebSocketClient, resp.JSON)
 		if err != nil {
-			return "", errors.New("Failed to send via RPC to WebSocket server: " + err.Error())
+			return "", err
 		}
 
 		// Error checking for everything else
@@ -288,3 +386,122 @@ https://dev.twitch.tv/docs/eventsub/handling-webhook-events#processing-an-event`
 
 	return string(resp.JSON), nil
 }
+
+// forwardWebhook forwards an event to a webhook and prints how the server responded.
+func forwardWebhook(fp ForwardParamters) error {
+	resp, err := ForwardEvent(fp)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	sent := "Request Sent"
+	if fp.Retry > 0 {
+		sent = fmt.Sprintf("Retry %v Sent", fp.Retry)
+	}
+
+	respTrigger := string(body)
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		color.New().Add(color.FgGreen).Println(fmt.Sprintf(`✔ %v. Received Status Code: %v`, sent, resp.StatusCode))
+		color.New().Add(color.FgGreen).Println(fmt.Sprintf(`✔ Server Said: %s`, respTrigger))
+	} else {
+		color.New().Add(color.FgRed).Println(fmt.Sprintf(`✗ Invalid response. Received Status Code: %v`, resp.StatusCode))
+		color.New().Add(color.FgRed).Println(fmt.Sprintf(`✗ Server Said: %s`, respTrigger))
+	}
+
+	return nil
+}
+
+// DialWebSocketServer connects to the RPC handler of the mock EventSub WebSocket server.
+func DialWebSocketServer() (*rpc.Client, error) {
+	client, err := rpc.DialHTTP("tcp", ":44747")
+	if err != nil {
+		return nil, errors.New(
+			"Failed to dial RPC handler for WebSocket server; It may not be running. See `twitch event websocket --help` for help on starting the WebSocket server.\n" +
+				"Error: " + err.Error(),
+		)
+	}
+	return client, nil
+}
+
+// WebSocketPayload changes the transport of an event's payload to WebSocket, as the mock WebSocket server expects.
+func WebSocketPayload(payload []byte) ([]byte, error) {
+	modifiedTransportJSON := models.EventsubResponse{}
+	err := json.Unmarshal(payload, &modifiedTransportJSON)
+	if err != nil {
+		return nil, errors.New("Unexpected error unmarshling JSON before forwarding to WebSocket server: " + err.Error())
+	}
+	modifiedTransportJSON.Subscription.Transport.Method = "websocket"
+	modifiedTransportJSON.Subscription.Transport.Callback = ""
+	modifiedTransportJSON.Subscription.Transport.SessionID = "WebSocket-Server-Will-Set"
+	return json.Marshal(modifiedTransportJSON)
+}
+
+// ForwardToWebSocket sends an event to the mock EventSub WebSocket server through its RPC handler, which delivers it to
+// clientName, or to every client subscribed to the event if it's empty.
+func ForwardToWebSocket(client *rpc.Client, clientName string, payload []byte) (rpc_handler.RPCResponse, error) {
+	var reply rpc_handler.RPCResponse
+
+	// Trigger any EventSub subscription that's available over 1st party WebSocket connections
+	variables := make(map[string]string)
+	variables["ClientName"] = clientName
+
+	args := &rpc_handler.RPCArgs{
+		RPCName:   "EventSubWebSocketForwardEvent",
+		Body:      string(payload),
+		Variables: variables,
+	}
+
+	err := client.Call("RPCHandler.ExecuteGenericRPC", args, &reply)
+
+	// Error checking for RPC internals
+	if err != nil {
+		return reply, errors.New("Failed to send via RPC to WebSocket server: " + err.Error())
+	}
+
+	return reply, nil
+}
+
+func storeChatMessage(db database.CLIDatabase, payload []byte) error {
+	var body models.ChatMessageEventSubResponse
+	err := json.Unmarshal(payload, &body)
+	if err != nil {
+		return err
+	}
+
+	return db.NewQuery(nil, 100).InsertChatMessage(database.ChatMessage{
+		ID:            body.Event.MessageID,
+		BroadcasterID: body.Event.BroadcasterUserID,
+		UserID:        body.Event.ChatterUserID,
+		UserLogin:     body.Event.ChatterUserLogin,
+		UserName:      body.Event.ChatterUserName,
+		Text:          body.Event.Message.Text,
+		MessageType:   body.Event.MessageType,
+		CreatedAt:     body.Subscription.CreatedAt,
+	})
+}
+
+func storeAutomodMessage(db database.CLIDatabase, payload []byte) error {
+	var body models.AutomodMessageHoldEventSubResponse
+	err := json.Unmarshal(payload, &body)
+	if err != nil {
+		return err
+	}
+
+	return db.NewQuery(nil, 100).InsertAutomodMessage(database.AutomodMessage{
+		ID:            body.Event.MessageID,
+		BroadcasterID: body.Event.BroadcasterUserID,
+		UserID:        body.Event.UserID,
+		UserLogin:     body.Event.UserLogin,
+		UserName:      body.Event.UserName,
+		Text:          body.Event.Message.Text,
+		Category:      body.Event.Category,
+		Level:         int(body.Event.Level),
+		HeldAt:        body.Event.HeldAt,
+	})
+}
@@ -3,11 +3,18 @@
 package trigger
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/twitchdev/twitch-cli/internal/events/payload"
 	"github.com/twitchdev/twitch-cli/internal/models"
 	"github.com/twitchdev/twitch-cli/test_setup"
 )
@@ -175,3 +182,132 @@ func TestFire(t *testing.T) {
 	_, err = Fire(params)
 	a.NotNil(err)
 }
+
+func TestFireOverrides(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var body []byte
+	var signature, messageID, timestamp string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+
+		var err error
+		body, err = io.ReadAll(r.Body)
+		a.Nil(err)
+		signature = r.Header.Get("Twitch-Eventsub-Message-Signature")
+		messageID = r.Header.Get("Twitch-Eventsub-Message-Id")
+		timestamp = r.Header.Get("Twitch-Eventsub-Message-Timestamp")
+	}))
+	defer ts.Close()
+
+	overrides, err := payload.NewOverrides([]string{"event.bits=1234"}, nil, []string{`.event.message = "overridden"`})
+	a.Nil(err)
+
+	res, err := Fire(TriggerParameters{
+		Event:          "cheer",
+		Transport:      models.TransportWebhook,
+		ForwardAddress: ts.URL,
+		Secret:         "potatopotato",
+		Overrides:      overrides,
+	})
+	a.Nil(err)
+	a.Equal(res, string(body))
+
+	var cheer models.CheerEventSubResponse
+	err = json.Unmarshal(body, &cheer)
+	a.Nil(err)
+	a.Equal(int64(1234), cheer.Event.Bits)
+	a.Equal("overridden", cheer.Event.Message)
+
+	// The signature must cover the overridden payload
+	mac := hmac.New(sha256.New, []byte("potatopotato"))
+	mac.Write([]byte(messageID + timestamp))
+	mac.Write(body)
+	a.Equal(fmt.Sprintf("sha256=%x", mac.Sum(nil)), signature)
+}
+
+func TestFireRetries(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var mu sync.Mutex
+	retries, messageIDs, timestamps := []string{}, []string{}, []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+
+		mu.Lock()
+		defer mu.Unlock()
+		retries = append(retries, r.Header.Get("Twitch-Eventsub-Message-Retry"))
+		messageIDs = append(messageIDs, r.Header.Get("Twitch-Eventsub-Message-Id"))
+		timestamps = append(timestamps, r.Header.Get("Twitch-Eventsub-Message-Timestamp"))
+	}))
+	defer ts.Close()
+
+	_, err := Fire(TriggerParameters{
+		Event:          "cheer",
+		Transport:      models.TransportWebhook,
+		ForwardAddress: ts.URL,
+		Secret:         "potatopotato",
+		Retries:        2,
+		TimestampSkew:  -11 * time.Minute,
+	})
+	a.Nil(err)
+
+	// Every delivery is the same message, so they share an ID and timestamp
+	a.Equal([]string{"0", "1", "2"}, retries)
+	a.Equal(messageIDs[0], messageIDs[1])
+	a.Equal(messageIDs[0], messageIDs[2])
+	a.Equal(timestamps[0], timestamps[2])
+
+	sent, err := time.Parse(time.RFC3339Nano, timestamps[0])
+	a.Nil(err)
+	a.WithinDuration(time.Now().Add(-11*time.Minute), sent, time.Minute)
+
+	// Redelivering a stored event as a retry keeps its original timestamp
+	retries, timestamps = []string{}, []string{}
+	_, err = RefireEvent(messageIDs[0], TriggerParameters{
+		ForwardAddress: ts.URL,
+		Secret:         "potatopotato",
+		Timestamp:      time.Now().Format(time.RFC3339Nano),
+		Retries:        1,
+	})
+	a.Nil(err)
+	a.Equal([]string{"1"}, retries)
+	a.Equal([]string{timestamps[0]}, timestamps)
+	a.Equal(sent.Format(time.RFC3339Nano), timestamps[0])
+}
+
+func TestFireOutOfOrder(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var mu sync.Mutex
+	received := map[string]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.Header.Get("Twitch-Eventsub-Message-Id")] = true
+	}))
+	defer ts.Close()
+
+	payloads, err := FireOutOfOrder(TriggerParameters{
+		Event:          "subscribe",
+		Transport:      models.TransportWebhook,
+		ForwardAddress: ts.URL,
+	}, 5)
+	a.Nil(err)
+	a.Len(payloads, 5)
+	a.Len(received, 5)
+
+	// Payloads are returned in the order they were generated, regardless of the order they were delivered in
+	for i := 1; i < len(payloads); i++ {
+		var previous, current models.SubEventSubResponse
+		a.Nil(json.Unmarshal([]byte(payloads[i-1]), &previous))
+		a.Nil(json.Unmarshal([]byte(payloads[i]), &current))
+		previousAt, err := time.Parse(time.RFC3339Nano, previous.Subscription.CreatedAt)
+		a.Nil(err)
+		currentAt, err := time.Parse(time.RFC3339Nano, current.Subscription.CreatedAt)
+		a.Nil(err)
+		a.False(currentAt.Before(previousAt))
+	}
+}
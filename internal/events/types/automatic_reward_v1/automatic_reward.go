@@ -0,0 +1,283 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automatic_reward_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"add-automatic-redemption"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"add-automatic-redemption": "channel.channel_points_automatic_reward_redemption.add",
+	},
+	models.TransportWebSocket: {
+		"add-automatic-redemption": "channel.channel_points_automatic_reward_redemption.add",
+	},
+}
+
+// RewardTypes are the values accepted by --reward-type for version 1 of automatic reward redemption events
+var RewardTypes = []string{
+	"single_message_bypass_sub_mode",
+	"send_highlighted_message",
+	"random_sub_emote_unlock",
+	"chosen_sub_emote_unlock",
+	"chosen_modified_sub_emote_unlock",
+	"message_effect",
+	"gigantify_an_emote",
+	"celebration",
+}
+
+// Default channel point cost of each reward, used when --cost isn't set
+var defaultCosts = map[string]int64{
+	"single_message_bypass_sub_mode":   200,
+	"send_highlighted_message":         100,
+	"random_sub_emote_unlock":          240,
+	"chosen_sub_emote_unlock":          960,
+	"chosen_modified_sub_emote_unlock": 1280,
+	"message_effect":                   30,
+	"gigantify_an_emote":               40,
+	"celebration":                      80,
+}
+
+// Rewards that have the redeeming user send a chat message
+var messageRewards = map[string]bool{
+	"single_message_bypass_sub_mode": true,
+	"send_highlighted_message":       true,
+	"message_effect":                 true,
+	"gigantify_an_emote":             true,
+}
+
+// Rewards that unlock a subscriber emote for the redeeming user
+var unlockRewards = map[string]bool{
+	"random_sub_emote_unlock":          true,
+	"chosen_sub_emote_unlock":          true,
+	"chosen_modified_sub_emote_unlock": true,
+}
+
+// Redemption is the part of an automatic reward redemption shared by every version of the event.
+type Redemption struct {
+	ID         string
+	Type       string
+	Cost       int64
+	Emote      *models.AutomaticRewardEmote // The unlocked emote, if any
+	Text       string                       // Empty for rewards without a message
+	Fragments  []models.ChatMessageFragment
+	RedeemedAt string
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		r, err := BuildRedemption(params, RewardTypes)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		body := models.AutomaticRewardRedemptionEventSubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: models.AutomaticRewardRedemptionEventSubEvent{
+				BroadcasterUserID:    params.ToUserID,
+				BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+				BroadcasterUserName:  params.ToUserName,
+				UserID:               params.FromUserID,
+				UserLogin:            strings.ToLower(params.FromUserName),
+				UserName:             params.FromUserName,
+				ID:                   r.ID,
+				Reward: models.AutomaticReward{
+					Type:          r.Type,
+					Cost:          r.Cost,
+					UnlockedEmote: r.Emote,
+				},
+				Message: models.AutomaticRewardMessage{
+					Text:   r.Text,
+					Emotes: emotePositions(r.Fragments),
+				},
+				UserInput:  r.Text,
+				RedeemedAt: r.RedeemedAt,
+			},
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// BuildRedemption creates the redemption of the reward in params.RewardType, which must be one of validTypes.
+// Rewards with a message use params.MessageText, and unlocked emotes are set with params.ItemID and params.ItemName.
+func BuildRedemption(params events.MockEventParameters, validTypes []string) (Redemption, error) {
+	rewardType := params.RewardType
+	if rewardType == "" {
+		rewardType = "send_highlighted_message"
+	}
+
+	valid := false
+	for _, t := range validTypes {
+		if t == rewardType {
+			valid = true
+		}
+	}
+	if !valid {
+		return Redemption{}, fmt.Errorf("Invalid reward type provided.\nValid values are: %v", strings.Join(validTypes, ", "))
+	}
+
+	r := Redemption{
+		ID:         util.RandomGUID(),
+		Type:       rewardType,
+		Cost:       params.Cost,
+		Fragments:  []models.ChatMessageFragment{},
+		RedeemedAt: params.Timestamp,
+	}
+	if r.Cost <= 0 {
+		r.Cost = defaultCosts[rewardType]
+	}
+
+	if messageRewards[rewardType] {
+		r.Text = params.MessageText
+		if r.Text == "" {
+			r.Text = "Hello World! This is a test chat message. Kappa"
+		}
+		r.Fragments = chat.MessageFragments(r.Text)
+
+		if rewardType == "gigantify_an_emote" && !hasEmote(r.Fragments) {
+			return Redemption{}, fmt.Errorf("The message of a gigantify_an_emote reward must contain an emote, such as Kappa")
+		}
+	}
+
+	if unlockRewards[rewardType] {
+		r.Emote = &models.AutomaticRewardEmote{
+			ID:   params.ItemID,
+			Name: params.ItemName,
+		}
+		if r.Emote.ID == "" {
+			r.Emote.ID = "emotesv2_" + strings.ReplaceAll(util.RandomGUID(), "-", "")
+		}
+		if r.Emote.Name == "" {
+			r.Emote.Name = "cliHype"
+		}
+	}
+
+	return r, nil
+}
+
+func hasEmote(fragments []models.ChatMessageFragment) bool {
+	for _, f := range fragments {
+		if f.Emote != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// emotePositions returns the inclusive character range of each emote in a message, as version 1 of the event describes them.
+func emotePositions(fragments []models.ChatMessageFragment) []models.AutomaticRewardMessageEmote {
+	emotes := []models.AutomaticRewardMessageEmote{}
+	position := 0
+	for _, f := range fragments {
+		length := len([]rune(f.Text))
+		if f.Emote != nil {
+			emotes = append(emotes, models.AutomaticRewardMessageEmote{
+				ID:    f.Emote.ID,
+				Begin: position,
+				End:   position + length - 1,
+			})
+		}
+		position += length
+	}
+	return emotes
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
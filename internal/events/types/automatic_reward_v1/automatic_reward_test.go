@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automatic_reward_v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "add-automatic-redemption",
+		SubscriptionStatus: "enabled",
+		MessageText:        "Hello Kappa world LUL",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.AutomaticRewardRedemptionEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("1", body.Subscription.Version)
+	a.Equal("send_highlighted_message", body.Event.Reward.Type)
+	a.Equal(int64(100), body.Event.Reward.Cost)
+	a.Nil(body.Event.Reward.UnlockedEmote)
+	a.Equal("Hello Kappa world LUL", body.Event.Message.Text)
+	a.Equal("Hello Kappa world LUL", body.Event.UserInput)
+	a.Equal([]models.AutomaticRewardMessageEmote{{ID: "25", Begin: 6, End: 10}, {ID: "425618", Begin: 18, End: 20}}, body.Event.Message.Emotes)
+
+	params.RewardType = "chosen_sub_emote_unlock"
+	params.ItemName = "cliWave"
+	params.Cost = 500
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.AutomaticRewardRedemptionEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal(int64(500), body.Event.Reward.Cost)
+	a.Equal("cliWave", body.Event.Reward.UnlockedEmote.Name)
+	a.NotEmpty(body.Event.Reward.UnlockedEmote.ID)
+	a.Empty(body.Event.Message.Text)
+
+	params.RewardType = "gigantify_an_emote"
+	params.MessageText = "No emotes here"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+
+	params.RewardType = "not_a_reward"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "add-automatic-redemption",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("add-automatic-redemption")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "add-automatic-redemption")
+	a.Equal("channel.channel_points_automatic_reward_redemption.add", r)
+}
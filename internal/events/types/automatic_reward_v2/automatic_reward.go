@@ -0,0 +1,179 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automatic_reward_v2
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v1"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"add-automatic-redemption"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"add-automatic-redemption": "channel.channel_points_automatic_reward_redemption.add",
+	},
+	models.TransportWebSocket: {
+		"add-automatic-redemption": "channel.channel_points_automatic_reward_redemption.add",
+	},
+}
+
+// RewardTypes are the values accepted by --reward-type for version 2 of automatic reward redemption events, which
+// leaves out rewards paid for with Bits
+var RewardTypes = append([]string{}, automatic_reward_v1.RewardTypes[:5]...)
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		r, err := automatic_reward_v1.BuildRedemption(params, RewardTypes)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		var message *models.AutomaticRewardV2Message
+		if r.Text != "" {
+			message = &models.AutomaticRewardV2Message{
+				Text:      r.Text,
+				Fragments: messageFragments(r.Fragments),
+			}
+		}
+
+		body := models.AutomaticRewardRedemptionV2EventSubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: models.AutomaticRewardRedemptionV2EventSubEvent{
+				BroadcasterUserID:    params.ToUserID,
+				BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+				BroadcasterUserName:  params.ToUserName,
+				UserID:               params.FromUserID,
+				UserLogin:            strings.ToLower(params.FromUserName),
+				UserName:             params.FromUserName,
+				ID:                   r.ID,
+				Reward: models.AutomaticRewardV2{
+					Type:          r.Type,
+					ChannelPoints: r.Cost,
+					Emote:         r.Emote,
+				},
+				Message:    message,
+				RedeemedAt: r.RedeemedAt,
+			},
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// messageFragments converts chat message fragments to the text and emote fragments of version 2 events. Cheermotes
+// and mentions aren't parsed in redemption messages, so they're merged into the surrounding text.
+func messageFragments(fragments []models.ChatMessageFragment) []models.AutomaticRewardMessageFragment {
+	converted := []models.AutomaticRewardMessageFragment{}
+	for _, f := range fragments {
+		if f.Emote != nil {
+			converted = append(converted, models.AutomaticRewardMessageFragment{
+				Type:  "emote",
+				Text:  f.Text,
+				Emote: &models.AutomaticRewardMessageFragmentEmote{ID: f.Emote.ID},
+			})
+			continue
+		}
+
+		last := len(converted) - 1
+		if last >= 0 && converted[last].Type == "text" {
+			converted[last].Text += f.Text
+			continue
+		}
+		converted = append(converted, models.AutomaticRewardMessageFragment{Type: "text", Text: f.Text})
+	}
+	return converted
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "2"
+}
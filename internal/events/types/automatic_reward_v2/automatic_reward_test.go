@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automatic_reward_v2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "add-automatic-redemption",
+		SubscriptionStatus: "enabled",
+		RewardType:         "single_message_bypass_sub_mode",
+		MessageText:        "Hi @friend Kappa",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.AutomaticRewardRedemptionV2EventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("2", body.Subscription.Version)
+	a.Equal("single_message_bypass_sub_mode", body.Event.Reward.Type)
+	a.NotZero(body.Event.Reward.ChannelPoints)
+	a.Equal("Hi @friend Kappa", body.Event.Message.Text)
+	a.Equal([]models.AutomaticRewardMessageFragment{
+		{Type: "text", Text: "Hi @friend "},
+		{Type: "emote", Text: "Kappa", Emote: &models.AutomaticRewardMessageFragmentEmote{ID: "25"}},
+	}, body.Event.Message.Fragments)
+
+	params.RewardType = "random_sub_emote_unlock"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.AutomaticRewardRedemptionV2EventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.NotNil(body.Event.Reward.Emote)
+	a.Nil(body.Event.Message)
+
+	// Rewards paid for with Bits aren't part of version 2
+	params.RewardType = "celebration"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "add-automatic-redemption",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("add-automatic-redemption")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "add-automatic-redemption")
+	a.Equal("channel.channel_points_automatic_reward_redemption.add", r)
+}
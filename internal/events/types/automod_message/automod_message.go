@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automod_message
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+var triggers = []string{"automod-message-hold", "automod-message-update"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"automod-message-hold":   "automod.message.hold",
+		"automod-message-update": "automod.message.update",
+	},
+	models.TransportWebSocket: {
+		"automod-message-hold":   "automod.message.hold",
+		"automod-message-update": "automod.message.update",
+	},
+}
+
+// Statuses are the values accepted by --event-status for automod.message.update events
+var Statuses = []string{"Approved", "Denied", "Expired"}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	messageID := params.ItemID
+	if messageID == "" {
+		messageID = util.RandomGUID()
+	}
+
+	text := params.MessageText
+	if text == "" {
+		text = "This is a test message that AutoMod held for review."
+	}
+
+	category := params.ItemName
+	if category == "" {
+		category = "swearing"
+	}
+
+	level := params.Cost
+	if level < 1 || level > 4 {
+		level = int64(util.RandomInt(4) + 1)
+	}
+
+	held := models.AutomodMessageHoldEventSubEvent{
+		BroadcasterUserID:    params.ToUserID,
+		BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+		BroadcasterUserName:  params.ToUserName,
+		UserID:               params.FromUserID,
+		UserLogin:            strings.ToLower(params.FromUserName),
+		UserName:             params.FromUserName,
+		MessageID:            messageID,
+		Message: models.ChatMessageBody{
+			Text:      text,
+			Fragments: chat.MessageFragments(text),
+		},
+		Category: category,
+		Level:    level,
+		HeldAt:   params.Timestamp,
+	}
+
+	var automodEvent interface{} = held
+
+	if params.Trigger == "automod-message-update" {
+		status := ""
+		for _, s := range Statuses {
+			if params.EventStatus == "" || strings.EqualFold(params.EventStatus, s) {
+				status = s
+				break
+			}
+		}
+		if status == "" {
+			return events.MockEventResponse{}, fmt.Errorf("Invalid status provided.\nValid values are: %v", strings.Join(Statuses, ", "))
+		}
+
+		moderatorUserID := params.ModeratorUserID
+		moderatorUserName := params.ModeratorUserName
+		if moderatorUserID == "" {
+			moderatorUserID = util.RandomUserID()
+			moderatorUserName = "CLIModerator"
+		}
+
+		automodEvent = models.AutomodMessageUpdateEventSubEvent{
+			AutomodMessageHoldEventSubEvent: held,
+			ModeratorUserID:                 moderatorUserID,
+			ModeratorUserLogin:              strings.ToLower(moderatorUserName),
+			ModeratorUserName:               moderatorUserName,
+			Status:                          status,
+		}
+	}
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		body := models.EventsubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Status:  params.SubscriptionStatus,
+				Cost:    0,
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+					ModeratorUserID:   params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				CreatedAt: params.Timestamp,
+			},
+			Event: automodEvent,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+func (e Event) ValidTransport(transport string) bool {
+	return transportsSupported[transport]
+}
+
+func (e Event) ValidTrigger(trigger string) bool {
+	for _, t := range triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automod_message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "automod-message-hold",
+		SubscriptionStatus: "enabled",
+		ItemID:             "held-message-id",
+		MessageText:        "hello Kappa",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.AutomodMessageHoldEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("automod.message.hold", body.Subscription.Type)
+	a.Equal("held-message-id", body.Event.MessageID)
+	a.Equal(fromUser, body.Event.UserID)
+	a.Equal("hello Kappa", body.Event.Message.Text)
+	a.Len(body.Event.Message.Fragments, 2)
+
+	params.Trigger = "automod-message-update"
+	params.EventStatus = "denied"
+	params.ModeratorUserID = "999"
+	params.ModeratorUserName = "SomeModerator"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var update models.AutomodMessageUpdateEventSubResponse
+	err = json.Unmarshal(r.JSON, &update)
+	a.Nil(err)
+
+	a.Equal("automod.message.update", update.Subscription.Type)
+	a.Equal("Denied", update.Event.Status)
+	a.Equal("999", update.Event.ModeratorUserID)
+	a.Equal("somemoderator", update.Event.ModeratorUserLogin)
+	a.Equal("held-message-id", update.Event.MessageID)
+
+	params.EventStatus = "potato"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "automod-message-hold",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("automod-message-hold")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebhook)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "automod-message-hold")
+	a.Equal("automod.message.hold", r)
+}
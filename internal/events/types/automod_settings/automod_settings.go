@@ -0,0 +1,155 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package automod_settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+var triggers = []string{"automod-settings-update"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"automod-settings-update": "automod.settings.update",
+	},
+	models.TransportWebSocket: {
+		"automod-settings-update": "automod.settings.update",
+	},
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	moderatorUserID := params.ModeratorUserID
+	moderatorUserName := params.ModeratorUserName
+	if moderatorUserID == "" {
+		moderatorUserID = util.RandomUserID()
+		moderatorUserName = "CLIModerator"
+	}
+
+	// Every category is set to the same level, the way the simple AutoMod settings do, so the overall level is set too
+	level := params.Cost
+	if level < 0 || level > 4 {
+		return events.MockEventResponse{}, fmt.Errorf("Invalid AutoMod level provided.\nLevels must be between 0 and 4")
+	}
+
+	automodEvent := models.AutomodSettingsUpdateEventSubEvent{
+		BroadcasterUserID:       params.ToUserID,
+		BroadcasterUserLogin:    strings.ToLower(params.ToUserName),
+		BroadcasterUserName:     params.ToUserName,
+		ModeratorUserID:         moderatorUserID,
+		ModeratorUserLogin:      strings.ToLower(moderatorUserName),
+		ModeratorUserName:       moderatorUserName,
+		Bits:                    level,
+		Chat:                    level,
+		Disability:              level,
+		Misogyny:                level,
+		RaceEthnicityOrReligion: level,
+		SexBasedTerms:           level,
+		SexualitySexOrGender:    level,
+		Swearing:                level,
+		OverallLevel:            &level,
+	}
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		body := models.EventsubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Status:  params.SubscriptionStatus,
+				Cost:    0,
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+					ModeratorUserID:   params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				CreatedAt: params.Timestamp,
+			},
+			Event: automodEvent,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+func (e Event) ValidTransport(transport string) bool {
+	return transportsSupported[transport]
+}
+
+func (e Event) ValidTrigger(trigger string) bool {
+	for _, t := range triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
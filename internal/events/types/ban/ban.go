@@ -58,50 +58,8 @@ func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEven
 			bannedAt = params.BanStartTimestamp
 		}
 
-		var endsAt *string = nil
-		var isPermanent bool
-
-		if params.BanEndTimestamp == "" {
-			// Default to perma ban
-			isPermanent = true
-		} else {
-			r1 := regexp.MustCompile("^[0-9]+$")
-			r2 := regexp.MustCompile("^(?:(?P<Days>[0-9]+)[dD])?(?:(?P<Hours>[0-9]+)[hH])?(?:(?P<Minutes>[0-9]+)[mM])?(?:(?P<Seconds>[0-9]+)[sS])?$")
-
-			if r1.MatchString(params.BanEndTimestamp) {
-				// Similar format to /timeout <user> <seconds>
-				// twitch event trigger channel.ban --ban-end=600
-				seconds, _ := strconv.Atoi(r1.FindAllString(params.BanEndTimestamp, -1)[0])
-				tNow, _ := time.Parse(time.RFC3339Nano, params.Timestamp)
-				tLater := tNow.Add(time.Duration(seconds) * time.Second).Format(time.RFC3339Nano)
-				endsAt = &tLater
-				isPermanent = false
-
-			} else if r2.MatchString(params.BanEndTimestamp) {
-				// Relative time specified by shorthands. e.g. 90d10h30m45s
-				// Can include or exclude any of those, but they have to be in the same order as above
-				values := r2.FindStringSubmatch(params.BanEndTimestamp)
-				days, _ := strconv.Atoi(values[r2.SubexpIndex("Days")])
-				hours, _ := strconv.Atoi(values[r2.SubexpIndex("Hours")])
-				minutes, _ := strconv.Atoi(values[r2.SubexpIndex("Minutes")])
-				seconds, _ := strconv.Atoi(values[r2.SubexpIndex("Seconds")])
-
-				tNow, _ := time.Parse(time.RFC3339Nano, params.Timestamp)
-				tLater := tNow.Add(time.Duration(days*24) * time.Hour).
-					Add(time.Duration(hours) * time.Hour).
-					Add(time.Duration(minutes) * time.Minute).
-					Add(time.Duration(seconds) * time.Second).
-					Format(time.RFC3339Nano)
-				endsAt = &tLater
-				isPermanent = false
-
-			} else {
-				// Timeout with user provided timestamp
-				endsAt = &params.BanEndTimestamp
-				isPermanent = false
-
-			}
-		}
+		endsAt := EndsAt(params.Timestamp, params.BanEndTimestamp)
+		isPermanent := endsAt == nil
 
 		ban.Reason = reason
 		ban.BannedAt = bannedAt
@@ -159,6 +117,47 @@ func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEven
 	}, nil
 }
 
+// EndsAt returns when a ban given at timestamp ends, or nil if it's permanent. banEnd is either a number of seconds,
+// a relative time such as 10d4h12m55s, or a timestamp.
+func EndsAt(timestamp string, banEnd string) *string {
+	if banEnd == "" {
+		// Default to perma ban
+		return nil
+	}
+
+	r1 := regexp.MustCompile("^[0-9]+$")
+	r2 := regexp.MustCompile("^(?:(?P<Days>[0-9]+)[dD])?(?:(?P<Hours>[0-9]+)[hH])?(?:(?P<Minutes>[0-9]+)[mM])?(?:(?P<Seconds>[0-9]+)[sS])?$")
+
+	if r1.MatchString(banEnd) {
+		// Similar format to /timeout <user> <seconds>
+		// twitch event trigger channel.ban --ban-end=600
+		seconds, _ := strconv.Atoi(r1.FindAllString(banEnd, -1)[0])
+		tNow, _ := time.Parse(time.RFC3339Nano, timestamp)
+		tLater := tNow.Add(time.Duration(seconds) * time.Second).Format(time.RFC3339Nano)
+		return &tLater
+
+	} else if r2.MatchString(banEnd) {
+		// Relative time specified by shorthands. e.g. 90d10h30m45s
+		// Can include or exclude any of those, but they have to be in the same order as above
+		values := r2.FindStringSubmatch(banEnd)
+		days, _ := strconv.Atoi(values[r2.SubexpIndex("Days")])
+		hours, _ := strconv.Atoi(values[r2.SubexpIndex("Hours")])
+		minutes, _ := strconv.Atoi(values[r2.SubexpIndex("Minutes")])
+		seconds, _ := strconv.Atoi(values[r2.SubexpIndex("Seconds")])
+
+		tNow, _ := time.Parse(time.RFC3339Nano, timestamp)
+		tLater := tNow.Add(time.Duration(days*24) * time.Hour).
+			Add(time.Duration(hours) * time.Hour).
+			Add(time.Duration(minutes) * time.Minute).
+			Add(time.Duration(seconds) * time.Second).
+			Format(time.RFC3339Nano)
+		return &tLater
+	}
+
+	// Timeout with user provided timestamp
+	return &banEnd
+}
+
 func (e Event) ValidTransport(t string) bool {
 	return transportsSupported[t]
 }
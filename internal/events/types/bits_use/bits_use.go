@@ -0,0 +1,248 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bits_use
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"bits-use"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"bits-use": "channel.bits.use",
+	},
+	models.TransportWebSocket: {
+		"bits-use": "channel.bits.use",
+	},
+}
+
+// PowerUpTypes are the Power-ups that can be bought with Bits
+var PowerUpTypes = []string{"message_effect", "celebration", "gigantify_an_emote"}
+
+// Types are the values accepted by --reward-type for channel.bits.use events. Power-up types set the event type to power_up.
+var Types = append([]string{"cheer", "combo"}, PowerUpTypes...)
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		bitsEvent, err := buildEvent(params)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		body := models.BitsUseEventSubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: bitsEvent,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+func buildEvent(params events.MockEventParameters) (models.BitsUseEventSubEvent, error) {
+	useType := params.RewardType
+	if useType == "" {
+		useType = "cheer"
+	}
+
+	valid := false
+	for _, t := range Types {
+		if t == useType {
+			valid = true
+		}
+	}
+	if !valid {
+		return models.BitsUseEventSubEvent{}, fmt.Errorf("Invalid reward type provided.\nValid values are: %v", strings.Join(Types, ", "))
+	}
+
+	bits := params.Cost
+	if bits <= 0 {
+		bits = 100
+	}
+
+	bitsEvent := models.BitsUseEventSubEvent{
+		BroadcasterUserID:    params.ToUserID,
+		BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+		BroadcasterUserName:  params.ToUserName,
+		UserID:               params.FromUserID,
+		UserLogin:            strings.ToLower(params.FromUserName),
+		UserName:             params.FromUserName,
+		Bits:                 bits,
+		Type:                 useType,
+	}
+
+	// Combos and celebrations are sent without a message
+	switch useType {
+	case "combo":
+		return bitsEvent, nil
+	case "celebration":
+		bitsEvent.Type = "power_up"
+		bitsEvent.PowerUp = &models.BitsUsePowerUp{Type: useType}
+		return bitsEvent, nil
+	}
+
+	text := params.MessageText
+	if text == "" {
+		text = "Hello World! This is a test chat message. Kappa"
+	}
+
+	// Cheers are sent as regular chat messages with a cheermote in them
+	if useType == "cheer" && !strings.Contains(strings.ToLower(text), "cheer") {
+		text = fmt.Sprintf("Cheer%v %v", bits, text)
+	}
+
+	fragments := messageFragments(chat.MessageFragments(text))
+	bitsEvent.Message = &models.BitsUseMessage{Text: text, Fragments: fragments}
+
+	switch useType {
+	case "cheer":
+		var cheered int64
+		for _, f := range fragments {
+			if f.Cheermote != nil {
+				cheered += f.Cheermote.Bits
+			}
+		}
+		if cheered > 0 {
+			bitsEvent.Bits = cheered
+		}
+	case "message_effect":
+		effect := "cosmic_abyss"
+		bitsEvent.Type = "power_up"
+		bitsEvent.PowerUp = &models.BitsUsePowerUp{Type: useType, MessageEffectID: &effect}
+	case "gigantify_an_emote":
+		// The last emote in the message is the one made bigger
+		var emote *models.AutomaticRewardEmote
+		for _, f := range fragments {
+			if f.Emote != nil {
+				emote = &models.AutomaticRewardEmote{ID: f.Emote.ID, Name: f.Text}
+			}
+		}
+		if emote == nil {
+			return models.BitsUseEventSubEvent{}, fmt.Errorf("The message of a gigantify_an_emote Power-up must contain an emote, such as Kappa")
+		}
+		bitsEvent.Type = "power_up"
+		bitsEvent.PowerUp = &models.BitsUsePowerUp{Type: useType, Emote: emote}
+	}
+
+	return bitsEvent, nil
+}
+
+// messageFragments converts chat message fragments to the text, cheermote, and emote fragments of Bits events.
+// Mentions aren't parsed in these messages, so they're merged into the surrounding text.
+func messageFragments(fragments []models.ChatMessageFragment) []models.BitsUseMessageFragment {
+	converted := []models.BitsUseMessageFragment{}
+	for _, f := range fragments {
+		if f.Cheermote != nil || f.Emote != nil {
+			converted = append(converted, models.BitsUseMessageFragment{
+				Type:      f.Type,
+				Text:      f.Text,
+				Cheermote: f.Cheermote,
+				Emote:     f.Emote,
+			})
+			continue
+		}
+
+		last := len(converted) - 1
+		if last >= 0 && converted[last].Type == "text" {
+			converted[last].Text += f.Text
+			continue
+		}
+		converted = append(converted, models.BitsUseMessageFragment{Type: "text", Text: f.Text})
+	}
+	return converted
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
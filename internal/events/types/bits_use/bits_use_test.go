@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bits_use
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "bits-use",
+		SubscriptionStatus: "enabled",
+		MessageText:        "Great stream!",
+		Cost:               250,
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.BitsUseEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("channel.bits.use", body.Subscription.Type)
+	a.Equal("cheer", body.Event.Type)
+	a.Equal(int64(250), body.Event.Bits)
+	a.Equal("Cheer250 Great stream!", body.Event.Message.Text)
+	a.Equal("cheermote", body.Event.Message.Fragments[0].Type)
+	a.Nil(body.Event.PowerUp)
+
+	// Bits cheered in the message take precedence
+	params.MessageText = "Cheer10 Cheer15 nice"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.BitsUseEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal(int64(25), body.Event.Bits)
+
+	params.RewardType = "gigantify_an_emote"
+	params.MessageText = "Kappa so big LUL"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.BitsUseEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("power_up", body.Event.Type)
+	a.Equal("gigantify_an_emote", body.Event.PowerUp.Type)
+	a.Equal(&models.AutomaticRewardEmote{ID: "425618", Name: "LUL"}, body.Event.PowerUp.Emote)
+	a.Nil(body.Event.PowerUp.MessageEffectID)
+
+	params.RewardType = "combo"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.BitsUseEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("combo", body.Event.Type)
+	a.Nil(body.Event.Message)
+	a.Nil(body.Event.PowerUp)
+
+	params.RewardType = "not_a_type"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "bits-use",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("bits-use")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "bits-use")
+	a.Equal("channel.bits.use", r)
+}
@@ -0,0 +1,401 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"chat-message", "chat-notification", "chat-clear", "chat-message-delete"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"chat-message":        "channel.chat.message",
+		"chat-notification":   "channel.chat.notification",
+		"chat-clear":          "channel.chat.clear",
+		"chat-message-delete": "channel.chat.message_delete",
+	},
+	models.TransportWebSocket: {
+		"chat-message":        "channel.chat.message",
+		"chat-notification":   "channel.chat.notification",
+		"chat-clear":          "channel.chat.clear",
+		"chat-message-delete": "channel.chat.message_delete",
+	},
+}
+
+// NoticeTypes are the values accepted by --notice-type for chat-notification events
+var NoticeTypes = []string{
+	"sub",
+	"resub",
+	"sub_gift",
+	"community_sub_gift",
+	"gift_paid_upgrade",
+	"prime_paid_upgrade",
+	"raid",
+	"unraid",
+	"pay_it_forward",
+	"announcement",
+	"bits_badge_tier",
+	"charity_donation",
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		messageID := params.ItemID
+		if messageID == "" {
+			messageID = util.RandomGUID()
+		}
+
+		subscription := models.EventsubSubscription{
+			ID:      params.SubscriptionID,
+			Status:  params.SubscriptionStatus,
+			Type:    triggerMapping[params.Transport][params.Trigger],
+			Version: e.SubscriptionVersion(),
+			Condition: models.EventsubCondition{
+				BroadcasterUserID: params.ToUserID,
+				UserID:            params.ToUserID,
+			},
+			Transport: models.EventsubTransport{
+				Method:   "webhook",
+				Callback: "null",
+			},
+			Cost:      0,
+			CreatedAt: params.Timestamp,
+		}
+
+		var body interface{}
+
+		switch params.Trigger {
+		case "chat-message":
+			body, err = generateMessage(params, subscription, messageID)
+		case "chat-notification":
+			body, err = generateNotification(params, subscription, messageID)
+		case "chat-clear":
+			body = models.ChatClearEventSubResponse{
+				Subscription: subscription,
+				Event: models.ChatClearEventSubEvent{
+					BroadcasterUserID:    params.ToUserID,
+					BroadcasterUserLogin: params.ToUserName,
+					BroadcasterUserName:  params.ToUserName,
+				},
+			}
+		case "chat-message-delete":
+			body = models.ChatMessageDeleteEventSubResponse{
+				Subscription: subscription,
+				Event: models.ChatMessageDeleteEventSubEvent{
+					BroadcasterUserID:    params.ToUserID,
+					BroadcasterUserLogin: params.ToUserName,
+					BroadcasterUserName:  params.ToUserName,
+					TargetUserID:         params.FromUserID,
+					TargetUserLogin:      params.FromUserName,
+					TargetUserName:       params.FromUserName,
+					MessageID:            messageID,
+				},
+			}
+		}
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+func generateMessage(params events.MockEventParameters, subscription models.EventsubSubscription, messageID string) (models.ChatMessageEventSubResponse, error) {
+	text := params.MessageText
+	if text == "" {
+		text = "Hello World! This is a test chat message. Kappa"
+	}
+
+	// Cheers are sent as regular chat messages with a cheermote in them
+	if params.Cost > 0 && !strings.Contains(strings.ToLower(text), "cheer") {
+		text = fmt.Sprintf("Cheer%v %v", params.Cost, text)
+	}
+
+	fragments := MessageFragments(text)
+
+	var cheer *models.ChatMessageCheer
+	var bits int64
+	for _, f := range fragments {
+		if f.Cheermote != nil {
+			bits += f.Cheermote.Bits
+		}
+	}
+	if bits > 0 {
+		cheer = &models.ChatMessageCheer{Bits: bits}
+	}
+
+	color := params.Color
+	if color == "" {
+		color = "#9146FF"
+	}
+
+	return models.ChatMessageEventSubResponse{
+		Subscription: subscription,
+		Event: models.ChatMessageEventSubEvent{
+			BroadcasterUserID:    params.ToUserID,
+			BroadcasterUserLogin: params.ToUserName,
+			BroadcasterUserName:  params.ToUserName,
+			ChatterUserID:        params.FromUserID,
+			ChatterUserLogin:     params.FromUserName,
+			ChatterUserName:      params.FromUserName,
+			MessageID:            messageID,
+			Message: models.ChatMessageBody{
+				Text:      text,
+				Fragments: fragments,
+			},
+			Color:       color,
+			Badges:      badges(params),
+			MessageType: "text",
+			Cheer:       cheer,
+		},
+	}, nil
+}
+
+func generateNotification(params events.MockEventParameters, subscription models.EventsubSubscription, messageID string) (models.ChatNotificationEventSubResponse, error) {
+	noticeType := params.NoticeType
+	if noticeType == "" {
+		noticeType = "announcement"
+	}
+
+	validNoticeType := false
+	for _, n := range NoticeTypes {
+		if n == noticeType {
+			validNoticeType = true
+		}
+	}
+	if !validNoticeType {
+		return models.ChatNotificationEventSubResponse{}, fmt.Errorf("Invalid notice type provided.\nValid values are: %v", strings.Join(NoticeTypes, ", "))
+	}
+
+	text := params.MessageText
+	if noticeType == "announcement" && text == "" {
+		text = "This is a test announcement."
+	}
+
+	tier := params.Tier
+	if tier == "" {
+		tier = "1000"
+	}
+
+	notification := models.ChatNotificationEventSubEvent{
+		BroadcasterUserID:    params.ToUserID,
+		BroadcasterUserLogin: params.ToUserName,
+		BroadcasterUserName:  params.ToUserName,
+		ChatterUserID:        params.FromUserID,
+		ChatterUserLogin:     params.FromUserName,
+		ChatterUserName:      params.FromUserName,
+		ChatterIsAnonymous:   params.IsAnonymous,
+		Color:                "#9146FF",
+		Badges:               badges(params),
+		MessageID:            messageID,
+		Message: models.ChatMessageBody{
+			Text:      text,
+			Fragments: MessageFragments(text),
+		},
+		NoticeType: noticeType,
+	}
+
+	switch noticeType {
+	case "sub":
+		notification.SystemMessage = fmt.Sprintf("%v subscribed at Tier %v.", params.FromUserName, tier[:1])
+		notification.Sub = &models.ChatNotificationSub{
+			SubTier:        tier,
+			IsPrime:        false,
+			DurationMonths: 1,
+		}
+	case "resub":
+		cumulative := util.RandomInt(48) + 2
+		streak := int(cumulative)
+		notification.SystemMessage = fmt.Sprintf("%v subscribed at Tier %v. They've subscribed for %v months!", params.FromUserName, tier[:1], cumulative)
+		notification.Resub = &models.ChatNotificationResub{
+			CumulativeMonths: int(cumulative),
+			DurationMonths:   1,
+			StreakMonths:     &streak,
+			SubTier:          tier,
+			IsPrime:          false,
+			IsGift:           false,
+		}
+	case "sub_gift":
+		recipient := "testRecipient"
+		notification.SystemMessage = fmt.Sprintf("%v gifted a Tier %v sub to %v!", params.FromUserName, tier[:1], recipient)
+		notification.SubGift = &models.ChatNotificationSubGift{
+			DurationMonths:     1,
+			RecipientUserID:    util.RandomUserID(),
+			RecipientUserName:  recipient,
+			RecipientUserLogin: strings.ToLower(recipient),
+			SubTier:            tier,
+		}
+	case "community_sub_gift":
+		total := int(params.Cost)
+		if total <= 0 {
+			total = 5
+		}
+		notification.SystemMessage = fmt.Sprintf("%v is gifting %v Tier %v Subs to %v's community!", params.FromUserName, total, tier[:1], params.ToUserName)
+		notification.CommunitySubGift = &models.ChatNotificationCommunitySubGift{
+			ID:      util.RandomGUID(),
+			Total:   total,
+			SubTier: tier,
+		}
+	case "gift_paid_upgrade":
+		notification.SystemMessage = fmt.Sprintf("%v is continuing the Gift Sub they got from an anonymous user!", params.FromUserName)
+		notification.GiftPaidUpgrade = &models.ChatNotificationGiftPaidUpgrade{
+			GifterIsAnonymous: true,
+		}
+	case "prime_paid_upgrade":
+		notification.SystemMessage = fmt.Sprintf("%v converted from a Prime sub to a Tier %v sub!", params.FromUserName, tier[:1])
+		notification.PrimePaidUpgrade = &models.ChatNotificationPrimePaidUpgrade{
+			SubTier: tier,
+		}
+	case "pay_it_forward":
+		notification.SystemMessage = fmt.Sprintf("%v is paying forward the Gift they got from an anonymous user!", params.FromUserName)
+		notification.PayItForward = &models.ChatNotificationPayItForward{
+			GifterIsAnonymous: true,
+		}
+	case "raid":
+		viewers := params.Cost
+		if viewers <= 0 {
+			viewers = util.RandomInt(1000)
+		}
+		notification.SystemMessage = fmt.Sprintf("%v raiders from %v have joined!", viewers, params.FromUserName)
+		notification.Raid = &models.ChatNotificationRaid{
+			UserID:          params.FromUserID,
+			UserName:        params.FromUserName,
+			UserLogin:       params.FromUserName,
+			ViewerCount:     viewers,
+			ProfileImageURL: "https://static-cdn.jtvnw.net/jtv_user_pictures/8a6381c7-d0c0-4576-b179-38bd5ce1d6af-profile_image-300x300.png",
+		}
+	case "unraid":
+		notification.SystemMessage = "The raid has been canceled."
+		notification.Unraid = &struct{}{}
+	case "announcement":
+		color := strings.ToUpper(params.Color)
+		if color == "" {
+			color = "PRIMARY"
+		}
+		notification.Announcement = &models.ChatNotificationAnnouncement{
+			Color: color,
+		}
+	case "bits_badge_tier":
+		tier := params.Cost
+		if tier <= 0 {
+			tier = 1000
+		}
+		notification.SystemMessage = fmt.Sprintf("%v just earned a new %v Bits badge!", params.FromUserName, tier)
+		notification.BitsBadgeTier = &models.ChatNotificationBitsBadgeTier{
+			Tier: tier,
+		}
+	case "charity_donation":
+		amount := int(params.Cost)
+		if amount <= 0 {
+			amount = 500
+		}
+		notification.SystemMessage = fmt.Sprintf("%v: Donated USD %v.%02d to support Example Charity", params.FromUserName, amount/100, amount%100)
+		notification.CharityDonation = &models.ChatNotificationCharityDonation{
+			CharityName: "Example Charity",
+			Amount: models.CharityEventSubEventAmount{
+				Value:         amount,
+				DecimalPlaces: 2,
+				Currency:      "USD",
+			},
+		}
+	}
+
+	return models.ChatNotificationEventSubResponse{
+		Subscription: subscription,
+		Event:        notification,
+	}, nil
+}
+
+func badges(params events.MockEventParameters) []models.ChatMessageBadge {
+	if params.FromUserID == params.ToUserID {
+		return []models.ChatMessageBadge{
+			{SetID: "broadcaster", ID: "1", Info: ""},
+		}
+	}
+	return []models.ChatMessageBadge{}
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
@@ -0,0 +1,170 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSubChatMessage(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "chat-message",
+		SubscriptionStatus: "enabled",
+		ItemID:             "abc-123",
+		MessageText:        "hi @someone Kappa cheer100",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err, "Error generating body.")
+
+	var body models.ChatMessageEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err, "Error unmarshalling JSON")
+
+	a.Equal(toUser, body.Event.BroadcasterUserID)
+	a.Equal(fromUser, body.Event.ChatterUserID)
+	a.Equal("abc-123", body.Event.MessageID)
+	a.Equal(params.MessageText, body.Event.Message.Text)
+	a.NotNil(body.Event.Cheer)
+	a.Equal(int64(100), body.Event.Cheer.Bits)
+
+	fragmentTypes := []string{}
+	for _, f := range body.Event.Message.Fragments {
+		fragmentTypes = append(fragmentTypes, f.Type)
+	}
+	a.Contains(fragmentTypes, "mention")
+	a.Contains(fragmentTypes, "emote")
+	a.Contains(fragmentTypes, "cheermote")
+}
+
+func TestEventSubChatNotification(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "chat-notification",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err, "Error generating body.")
+
+	var body models.ChatNotificationEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err, "Error unmarshalling JSON")
+
+	a.Equal("announcement", body.Event.NoticeType)
+	a.NotNil(body.Event.Announcement)
+	a.Equal("PRIMARY", body.Event.Announcement.Color)
+
+	params.NoticeType = "raid"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err, "Error generating body.")
+
+	body = models.ChatNotificationEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err, "Error unmarshalling JSON")
+	a.Equal("raid", body.Event.NoticeType)
+	a.NotNil(body.Event.Raid)
+	a.Nil(body.Event.Announcement)
+
+	params.NoticeType = "not_a_notice"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestEventSubChatClear(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "chat-clear",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err, "Error generating body.")
+
+	var body models.ChatClearEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err, "Error unmarshalling JSON")
+	a.Equal(toUser, body.Event.BroadcasterUserID)
+}
+
+func TestEventSubChatMessageDelete(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "chat-message-delete",
+		SubscriptionStatus: "enabled",
+		ItemID:             "abc-123",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err, "Error generating body.")
+
+	var body models.ChatMessageDeleteEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err, "Error unmarshalling JSON")
+	a.Equal(toUser, body.Event.BroadcasterUserID)
+	a.Equal(fromUser, body.Event.TargetUserID)
+	a.Equal("abc-123", body.Event.MessageID)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID: fromUser,
+		ToUserID:   toUser,
+		Transport:  "fake_transport",
+		Trigger:    "chat-message",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("chat-message")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("notchat")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebhook)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "chat-message")
+	a.Equal("channel.chat.message", r)
+}
@@ -0,0 +1,137 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package chat
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// A small set of global emotes recognized in mock chat messages, keyed by emote name
+var globalEmotes = map[string]string{
+	"4Head":           "354",
+	"BibleThump":      "86",
+	"CoolStoryBob":    "123171",
+	"DansGame":        "33",
+	"HeyGuys":         "30259",
+	"Jebaited":        "114836",
+	"Kappa":           "25",
+	"Kreygasm":        "41",
+	"LUL":             "425618",
+	"NotLikeThis":     "58765",
+	"PogChamp":        "305954156",
+	"ResidentSleeper": "245",
+	"SeemsGood":       "64138",
+	"TwitchUnity":     "196892",
+	"VoHiYo":          "81274",
+}
+
+var cheermoteRegex = regexp.MustCompile(`^(?i)(cheer)([0-9]+)$`)
+
+// MessageFragments splits a chat message into text, emote, cheermote, and mention fragments the same way EventSub chat events do.
+func MessageFragments(text string) []models.ChatMessageFragment {
+	fragments := []models.ChatMessageFragment{}
+	if text == "" {
+		return fragments
+	}
+
+	current := ""
+	words := strings.Split(text, " ")
+	for i, word := range words {
+		fragment := specialFragment(word)
+		if fragment == nil {
+			current += word
+			if i < len(words)-1 {
+				current += " "
+			}
+			continue
+		}
+
+		if current != "" {
+			fragments = append(fragments, models.ChatMessageFragment{Type: "text", Text: current})
+		}
+		fragments = append(fragments, *fragment)
+
+		current = ""
+		if i < len(words)-1 {
+			current = " "
+		}
+	}
+	if current != "" {
+		fragments = append(fragments, models.ChatMessageFragment{Type: "text", Text: current})
+	}
+
+	return fragments
+}
+
+// IsEmoteOnly returns whether a message consists solely of emotes, as required by emote-only chat mode.
+func IsEmoteOnly(text string) bool {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if _, ok := globalEmotes[word]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func specialFragment(word string) *models.ChatMessageFragment {
+	if id, ok := globalEmotes[word]; ok {
+		return &models.ChatMessageFragment{
+			Type: "emote",
+			Text: word,
+			Emote: &models.ChatMessageEmote{
+				ID:         id,
+				EmoteSetID: "0",
+				OwnerID:    "0",
+				Format:     []string{"static"},
+			},
+		}
+	}
+
+	if matches := cheermoteRegex.FindStringSubmatch(word); matches != nil {
+		bits, _ := strconv.ParseInt(matches[2], 10, 64)
+		if bits > 0 {
+			return &models.ChatMessageFragment{
+				Type: "cheermote",
+				Text: word,
+				Cheermote: &models.ChatMessageCheermote{
+					Prefix: strings.ToLower(matches[1]),
+					Bits:   bits,
+					Tier:   cheermoteTier(bits),
+				},
+			}
+		}
+	}
+
+	if len(word) > 1 && strings.HasPrefix(word, "@") {
+		login := strings.ToLower(word[1:])
+		return &models.ChatMessageFragment{
+			Type: "mention",
+			Text: word,
+			Mention: &models.ChatMessageMention{
+				UserID:    util.RandomUserID(),
+				UserName:  word[1:],
+				UserLogin: login,
+			},
+		}
+	}
+
+	return nil
+}
+
+func cheermoteTier(bits int64) int64 {
+	for _, tier := range []int64{10000, 5000, 1000, 100} {
+		if bits >= tier {
+			return tier
+		}
+	}
+	return 1
+}
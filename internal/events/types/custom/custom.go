@@ -0,0 +1,322 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package custom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// TemplateDirName is the folder within the application directory that event templates are loaded from
+const TemplateDirName = "event-templates"
+
+// Definition is the contents of an event template file.
+type Definition struct {
+	Type       string                 `json:"type"`
+	Version    string                 `json:"version"`
+	Aliases    []string               `json:"aliases"`
+	Transports []string               `json:"transports"`
+	Condition  map[string]interface{} `json:"condition"`
+	Event      map[string]interface{} `json:"event"`
+}
+
+// Event is an EventSub event defined by a template file rather than a Go package.
+type Event struct {
+	Definition Definition
+	Path       string // File the definition was loaded from
+}
+
+// response mirrors models.EventsubResponse, but with a condition that can hold any fields
+type response struct {
+	Subscription subscription `json:"subscription"`
+	Event        interface{}  `json:"event,omitempty"`
+}
+
+type subscription struct {
+	ID        string                   `json:"id"`
+	Status    string                   `json:"status"`
+	Type      string                   `json:"type"`
+	Version   string                   `json:"version"`
+	Condition interface{}              `json:"condition"`
+	Transport models.EventsubTransport `json:"transport"`
+	CreatedAt string                   `json:"created_at"`
+	Cost      int                      `json:"cost"`
+}
+
+var defaultCondition = map[string]interface{}{
+	"broadcaster_user_id": "{{.ToUserID}}",
+}
+
+// A string made up of only a single placeholder is replaced by the value itself, so numbers and booleans keep their type
+var wholePlaceholderRegex = regexp.MustCompile(`^\{\{\s*\.(\w+)\s*\}\}$`)
+
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// TemplateDir returns the directory event templates are loaded from.
+func TemplateDir() (string, error) {
+	home, err := util.GetApplicationDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, TemplateDirName), nil
+}
+
+// Load reads every *.json event template in dir. Templates that can't be used are skipped, and the reason is returned as an error.
+// A missing directory isn't an error, as templates are optional.
+func Load(dir string) ([]Event, []error) {
+	loaded := []Event{}
+	errs := []error{}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return loaded, []error{err}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		e, err := LoadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Skipping event template %v: %v", path, err))
+			continue
+		}
+		loaded = append(loaded, e)
+	}
+
+	return loaded, errs
+}
+
+// LoadFile reads and validates a single event template.
+func LoadFile(path string) (Event, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var d Definition
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	err = decoder.Decode(&d)
+	if err != nil {
+		return Event{}, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	if d.Type == "" {
+		return Event{}, fmt.Errorf("type is required")
+	}
+	if d.Version == "" {
+		d.Version = "1"
+	}
+	if len(d.Transports) == 0 {
+		d.Transports = []string{models.TransportWebhook, models.TransportWebSocket}
+	}
+	for _, t := range d.Transports {
+		if t != models.TransportWebhook && t != models.TransportWebSocket {
+			return Event{}, fmt.Errorf("unsupported transport %q; must be %v or %v", t, models.TransportWebhook, models.TransportWebSocket)
+		}
+	}
+	if d.Condition == nil {
+		d.Condition = defaultCondition
+	}
+	if d.Event == nil {
+		return Event{}, fmt.Errorf("event is required")
+	}
+
+	e := Event{Definition: d, Path: path}
+
+	// Render once with empty parameters so unknown placeholders are reported when loading, rather than when triggering
+	_, err = e.render(events.MockEventParameters{})
+	if err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	if !e.ValidTransport(params.Transport) {
+		return events.MockEventResponse{}, nil
+	}
+
+	rendered, err := e.render(params)
+	if err != nil {
+		return events.MockEventResponse{}, err
+	}
+
+	body := response{
+		Subscription: subscription{
+			ID:        params.SubscriptionID,
+			Status:    params.SubscriptionStatus,
+			Type:      e.Definition.Type,
+			Version:   e.SubscriptionVersion(),
+			Condition: rendered["condition"],
+			Transport: models.EventsubTransport{
+				Method:   "webhook",
+				Callback: "null",
+			},
+			CreatedAt: params.Timestamp,
+			Cost:      0,
+		},
+	}
+
+	// Event info is only included if Subscription.Status is set to "enabled"
+	if strings.EqualFold(params.SubscriptionStatus, "enabled") {
+		body.Event = rendered["event"]
+	}
+
+	event, err := json.Marshal(body)
+	if err != nil {
+		return events.MockEventResponse{}, err
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// render fills in the placeholders of the condition and event templates.
+func (e Event) render(params events.MockEventParameters) (map[string]interface{}, error) {
+	data := placeholderValues(params)
+
+	condition, err := renderValue(e.Definition.Condition, data)
+	if err != nil {
+		return nil, fmt.Errorf("condition: %v", err)
+	}
+	event, err := renderValue(e.Definition.Event, data)
+	if err != nil {
+		return nil, fmt.Errorf("event: %v", err)
+	}
+
+	return map[string]interface{}{"condition": condition, "event": event}, nil
+}
+
+// placeholderValues returns every value usable in templates: each MockEventParameters field by name, along with a few helpers.
+func placeholderValues(params events.MockEventParameters) map[string]interface{} {
+	data := map[string]interface{}{}
+
+	v := reflect.ValueOf(params)
+	for i := 0; i < v.NumField(); i++ {
+		data[v.Type().Field(i).Name] = v.Field(i).Interface()
+	}
+
+	data["FromUserLogin"] = strings.ToLower(params.FromUserName)
+	data["ToUserLogin"] = strings.ToLower(params.ToUserName)
+	data["RandomGUID"] = util.RandomGUID()
+	data["RandomUserID"] = util.RandomUserID()
+
+	return data
+}
+
+func renderValue(v interface{}, data map[string]interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case string:
+		if m := wholePlaceholderRegex.FindStringSubmatch(value); m != nil {
+			if field, ok := data[m[1]]; ok {
+				return field, nil
+			}
+			return nil, fmt.Errorf("unknown placeholder %v", value)
+		}
+
+		if !strings.Contains(value, "{{") {
+			return value, nil
+		}
+
+		t, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		err = t.Execute(&out, data)
+		if err != nil {
+			return nil, err
+		}
+		return out.String(), nil
+
+	case map[string]interface{}:
+		rendered := map[string]interface{}{}
+		for k, item := range value {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered[k] = r
+		}
+		return rendered, nil
+
+	case []interface{}:
+		rendered := []interface{}{}
+		for _, item := range value {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, r)
+		}
+		return rendered, nil
+	}
+
+	return v, nil
+}
+
+func (e Event) ValidTransport(t string) bool {
+	for _, transport := range e.Definition.Transports {
+		if transport == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	if t == e.Definition.Type {
+		return true
+	}
+	for _, alias := range e.Definition.Aliases {
+		if alias == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	if !e.ValidTransport(transport) || !e.ValidTrigger(trigger) {
+		return ""
+	}
+	return e.Definition.Type
+}
+
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	if !e.ValidTransport(transport) {
+		return []string{}
+	}
+	return []string{e.Definition.Type}
+}
+
+func (e Event) GetEventSubAlias(t string) string {
+	if t == e.Definition.Type && len(e.Definition.Aliases) != 0 {
+		return e.Definition.Aliases[0]
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return e.Definition.Version
+}
@@ -0,0 +1,150 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package custom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+const testTemplate = `{
+	"type": "channel.example.update",
+	"version": "2",
+	"aliases": ["example-update"],
+	"transports": ["webhook"],
+	"condition": {"broadcaster_user_id": "{{.ToUserID}}"},
+	"event": {
+		"broadcaster_user_id": "{{.ToUserID}}",
+		"broadcaster_user_login": "{{.ToUserLogin}}",
+		"user_id": "{{.FromUserID}}",
+		"amount": "{{.Cost}}",
+		"title": "Spent {{.Cost}} on {{lower .ItemName}}",
+		"tags": ["{{.Tier}}", "static"],
+		"is_test": true
+	}
+}`
+
+func writeTemplate(t *testing.T, dir string, name string, contents string) {
+	err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	dir := t.TempDir()
+
+	writeTemplate(t, dir, "example.json", testTemplate)
+	writeTemplate(t, dir, "bad_placeholder.json", `{"type": "channel.bad", "event": {"user_id": "{{.NotAField}}"}}`)
+	writeTemplate(t, dir, "no_type.json", `{"event": {}}`)
+	writeTemplate(t, dir, "not_a_template.txt", `ignored`)
+
+	loaded, errs := Load(dir)
+	a.Len(loaded, 1)
+	a.Len(errs, 2)
+	a.Equal("channel.example.update", loaded[0].Definition.Type)
+
+	// missing directories have no templates
+	loaded, errs = Load(filepath.Join(dir, "missing"))
+	a.Len(loaded, 0)
+	a.Len(errs, 0)
+}
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	dir := t.TempDir()
+	writeTemplate(t, dir, "example.json", testTemplate)
+
+	e, err := LoadFile(filepath.Join(dir, "example.json"))
+	a.Nil(err)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		ToUserName:         "TestBroadcaster",
+		Transport:          models.TransportWebhook,
+		Trigger:            "example-update",
+		SubscriptionStatus: "enabled",
+		Cost:               100,
+		ItemName:           "Hats",
+		Tier:               "1000",
+	}
+
+	r, err := e.GenerateEvent(params)
+	a.Nil(err)
+
+	var body struct {
+		Subscription models.EventsubSubscription `json:"subscription"`
+		Event        map[string]interface{}      `json:"event"`
+	}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("channel.example.update", body.Subscription.Type)
+	a.Equal("2", body.Subscription.Version)
+	a.Equal(toUser, body.Subscription.Condition.BroadcasterUserID)
+	a.Equal(toUser, body.Event["broadcaster_user_id"])
+	a.Equal("testbroadcaster", body.Event["broadcaster_user_login"])
+	a.Equal(float64(100), body.Event["amount"])
+	a.Equal("Spent 100 on hats", body.Event["title"])
+	a.Equal([]interface{}{"1000", "static"}, body.Event["tags"])
+	a.Equal(true, body.Event["is_test"])
+
+	// event info is removed for revocations
+	params.SubscriptionStatus = "authorization_revoked"
+	r, err = e.GenerateEvent(params)
+	a.Nil(err)
+	body.Event = nil
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Nil(body.Event)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	dir := t.TempDir()
+	writeTemplate(t, dir, "example.json", testTemplate)
+
+	e, err := LoadFile(filepath.Join(dir, "example.json"))
+	a.Nil(err)
+
+	r, err := e.GenerateEvent(events.MockEventParameters{Transport: models.TransportWebSocket, Trigger: "example-update"})
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	e := Event{Definition: Definition{Type: "channel.example.update", Aliases: []string{"example-update"}}}
+
+	a.True(e.ValidTrigger("example-update"))
+	a.True(e.ValidTrigger("channel.example.update"))
+	a.False(e.ValidTrigger("not_trigger_keyword"))
+	a.Equal("example-update", e.GetEventSubAlias("channel.example.update"))
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	e := Event{Definition: Definition{Type: "channel.example.update", Transports: []string{models.TransportWebhook}}}
+
+	a.True(e.ValidTransport(models.TransportWebhook))
+	a.False(e.ValidTransport(models.TransportWebSocket))
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	e := Event{Definition: Definition{Type: "channel.example.update", Aliases: []string{"example-update"}, Transports: []string{models.TransportWebhook}}}
+
+	a.Equal("channel.example.update", e.GetTopic(models.TransportWebhook, "example-update"))
+	a.Equal("", e.GetTopic(models.TransportWebSocket, "example-update"))
+}
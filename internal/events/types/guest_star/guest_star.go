@@ -0,0 +1,232 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package guest_star
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"guest-star-session-begin", "guest-star-session-end", "guest-star-guest-update", "guest-star-settings-update"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"guest-star-session-begin":   "channel.guest_star_session.begin",
+		"guest-star-session-end":     "channel.guest_star_session.end",
+		"guest-star-guest-update":    "channel.guest_star_guest.update",
+		"guest-star-settings-update": "channel.guest_star_settings.update",
+	},
+	models.TransportWebSocket: {
+		"guest-star-session-begin":   "channel.guest_star_session.begin",
+		"guest-star-session-end":     "channel.guest_star_session.end",
+		"guest-star-guest-update":    "channel.guest_star_guest.update",
+		"guest-star-settings-update": "channel.guest_star_settings.update",
+	},
+}
+
+// GuestStates are the values accepted by --event-status for channel.guest_star_guest.update events
+var GuestStates = []string{"invited", "accepted", "ready", "backstage", "live", "removed"}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		sessionID := params.ItemID
+		if sessionID == "" {
+			sessionID = util.RandomGUID()
+		}
+		broadcasterLogin := strings.ToLower(params.ToUserName)
+
+		var eventBody interface{}
+		switch params.Trigger {
+		case "guest-star-session-begin", "guest-star-session-end":
+			session := models.GuestStarSessionEventSubEvent{
+				BroadcasterUserID:    params.ToUserID,
+				BroadcasterUserLogin: broadcasterLogin,
+				BroadcasterUserName:  params.ToUserName,
+				SessionID:            sessionID,
+				StartedAt:            util.GetTimestamp().Format(time.RFC3339Nano),
+				HostUserID:           params.ToUserID,
+				HostUserLogin:        broadcasterLogin,
+				HostUserName:         params.ToUserName,
+			}
+			if params.Trigger == "guest-star-session-end" {
+				endedAt := session.StartedAt
+				session.StartedAt = util.GetTimestamp().Add(-1 * time.Hour).Format(time.RFC3339Nano)
+				session.EndedAt = &endedAt
+			}
+			eventBody = session
+		case "guest-star-guest-update":
+			eventBody, err = guestUpdate(params, sessionID)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		case "guest-star-settings-update":
+			eventBody = models.GuestStarSettingsUpdateEventSubEvent{
+				BroadcasterUserID:           params.ToUserID,
+				BroadcasterUserLogin:        broadcasterLogin,
+				BroadcasterUserName:         params.ToUserName,
+				IsModeratorSendLiveEnabled:  true,
+				SlotCount:                   4,
+				IsBrowserSourceAudioEnabled: true,
+				GroupLayout:                 "tiled",
+			}
+		}
+
+		body := models.EventsubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+					ModeratorUserID:   params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: eventBody,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// guestUpdate creates an update for the from user, who is the guest, moving to the state in params.EventStatus.
+// The broadcaster invites and removes guests, while guests accept invites and get ready themselves.
+func guestUpdate(params events.MockEventParameters, sessionID string) (models.GuestStarGuestUpdateEventSubEvent, error) {
+	state := params.EventStatus
+	if state == "" {
+		state = "live"
+	}
+
+	valid := false
+	for _, s := range GuestStates {
+		if s == state {
+			valid = true
+		}
+	}
+	if !valid {
+		return models.GuestStarGuestUpdateEventSubEvent{}, fmt.Errorf("Invalid guest state provided.\nValid values are: %v", strings.Join(GuestStates, ", "))
+	}
+
+	broadcasterLogin := strings.ToLower(params.ToUserName)
+	guestLogin := strings.ToLower(params.FromUserName)
+	update := models.GuestStarGuestUpdateEventSubEvent{
+		BroadcasterUserID:    params.ToUserID,
+		BroadcasterUserLogin: broadcasterLogin,
+		BroadcasterUserName:  params.ToUserName,
+		SessionID:            sessionID,
+		GuestUserID:          &params.FromUserID,
+		GuestUserLogin:       &guestLogin,
+		GuestUserName:        &params.FromUserName,
+		State:                state,
+		HostUserID:           params.ToUserID,
+		HostUserLogin:        broadcasterLogin,
+		HostUserName:         params.ToUserName,
+	}
+
+	if state != "accepted" && state != "ready" {
+		update.ModeratorUserID = &params.ToUserID
+		update.ModeratorUserLogin = &broadcasterLogin
+		update.ModeratorUserName = &params.ToUserName
+	}
+
+	// Only guests in a slot have one assigned, along with the host's settings for it
+	if state == "backstage" || state == "live" {
+		slotID := "1"
+		enabled := true
+		volume := 100
+		update.SlotID = &slotID
+		update.HostVideoEnabled = &enabled
+		update.HostAudioEnabled = &enabled
+		update.HostVolume = &volume
+	}
+
+	return update, nil
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "beta"
+}
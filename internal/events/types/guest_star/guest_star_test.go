@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package guest_star
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		FromUserName:       "testGuest",
+		ToUserID:           toUser,
+		ToUserName:         "testBroadcaster",
+		Transport:          models.TransportWebhook,
+		Trigger:            "guest-star-session-end",
+		SubscriptionStatus: "enabled",
+		ItemID:             "session1",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var session models.GuestStarSessionEventSubResponse
+	err = json.Unmarshal(r.JSON, &session)
+	a.Nil(err)
+	a.Equal("channel.guest_star_session.end", session.Subscription.Type)
+	a.Equal("beta", session.Subscription.Version)
+	a.Equal("session1", session.Event.SessionID)
+	a.Equal(toUser, session.Event.HostUserID)
+	a.NotNil(session.Event.EndedAt)
+
+	params.Trigger = "guest-star-guest-update"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var update models.GuestStarGuestUpdateEventSubResponse
+	err = json.Unmarshal(r.JSON, &update)
+	a.Nil(err)
+	a.Equal("live", update.Event.State)
+	a.Equal(fromUser, *update.Event.GuestUserID)
+	a.Equal("testguest", *update.Event.GuestUserLogin)
+	a.Equal(toUser, *update.Event.ModeratorUserID)
+	a.NotNil(update.Event.SlotID)
+	a.NotNil(update.Event.HostVolume)
+
+	// guests accept invites themselves, before they're given a slot
+	params.EventStatus = "accepted"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	update = models.GuestStarGuestUpdateEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &update)
+	a.Nil(err)
+	a.Nil(update.Event.ModeratorUserID)
+	a.Nil(update.Event.SlotID)
+	a.Nil(update.Event.HostVideoEnabled)
+
+	params.EventStatus = "not_a_state"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+
+	params.Trigger = "guest-star-settings-update"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var settings models.GuestStarSettingsUpdateEventSubResponse
+	err = json.Unmarshal(r.JSON, &settings)
+	a.Nil(err)
+	a.Equal("tiled", settings.Event.GroupLayout)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "guest-star-settings-update",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("guest-star-settings-update")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "guest-star-settings-update")
+	a.Equal("channel.guest_star_settings.update", r)
+}
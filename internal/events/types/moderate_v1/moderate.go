@@ -0,0 +1,301 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package moderate_v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/events/types/ban"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"moderate"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"moderate": "channel.moderate",
+	},
+	models.TransportWebSocket: {
+		"moderate": "channel.moderate",
+	},
+}
+
+// Actions are the values accepted by --moderate-action for version 1 of channel.moderate events
+var Actions = []string{
+	"ban",
+	"timeout",
+	"unban",
+	"untimeout",
+	"clear",
+	"emoteonly",
+	"emoteonlyoff",
+	"followers",
+	"followersoff",
+	"uniquechat",
+	"uniquechatoff",
+	"slow",
+	"slowoff",
+	"subscribers",
+	"subscribersoff",
+	"unraid",
+	"delete",
+	"unvip",
+	"vip",
+	"raid",
+	"add_blocked_term",
+	"add_permitted_term",
+	"remove_blocked_term",
+	"remove_permitted_term",
+	"mod",
+	"unmod",
+	"approve_unban_request",
+	"deny_unban_request",
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		moderate, err := BuildEvent(params, Actions)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		body := models.ModerateEventSubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+					ModeratorUserID:   moderate.ModeratorUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: moderate,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// BuildEvent creates the channel.moderate event for the action in params.ModerateAction, which must be one of
+// validActions. Actions that aren't part of version 1, such as warn, are left for the caller to fill in.
+// The target of the action is the from user, and the broadcaster is the to user.
+func BuildEvent(params events.MockEventParameters, validActions []string) (models.ModerateEventSubEvent, error) {
+	action := params.ModerateAction
+	if action == "" {
+		action = "ban"
+	}
+
+	valid := false
+	for _, a := range validActions {
+		if a == action {
+			valid = true
+		}
+	}
+	if !valid {
+		return models.ModerateEventSubEvent{}, fmt.Errorf("Invalid moderate action provided.\nValid values are: %v", strings.Join(validActions, ", "))
+	}
+
+	reason := params.Description
+	if reason == "" {
+		reason = "This is a test event"
+	}
+
+	target := &models.ModerateUser{
+		UserID:    params.FromUserID,
+		UserLogin: strings.ToLower(params.FromUserName),
+		UserName:  params.FromUserName,
+	}
+
+	moderate := models.ModerateEventSubEvent{
+		BroadcasterUserID:    params.ToUserID,
+		BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+		BroadcasterUserName:  params.ToUserName,
+		ModeratorUserID:      util.RandomUserID(),
+		ModeratorUserLogin:   "climoderator",
+		ModeratorUserName:    "CLIModerator",
+		Action:               action,
+	}
+
+	switch action {
+	case "ban":
+		moderate.Ban = &models.ModerateBan{
+			UserID:    target.UserID,
+			UserLogin: target.UserLogin,
+			UserName:  target.UserName,
+			Reason:    reason,
+		}
+	case "timeout":
+		expiresAt := ban.EndsAt(params.Timestamp, params.BanEndTimestamp)
+		if expiresAt == nil {
+			// Same as the default /timeout duration
+			ts, _ := time.Parse(time.RFC3339Nano, params.Timestamp)
+			tLater := ts.Add(10 * time.Minute).Format(time.RFC3339Nano)
+			expiresAt = &tLater
+		}
+		moderate.Timeout = &models.ModerateTimeout{
+			UserID:    target.UserID,
+			UserLogin: target.UserLogin,
+			UserName:  target.UserName,
+			Reason:    reason,
+			ExpiresAt: *expiresAt,
+		}
+	case "unban":
+		moderate.Unban = target
+	case "untimeout":
+		moderate.Untimeout = target
+	case "followers":
+		moderate.Followers = &models.ModerateFollowers{FollowDurationMinutes: params.Cost}
+	case "slow":
+		waitTime := params.Cost
+		if waitTime == 0 {
+			waitTime = 30
+		}
+		moderate.Slow = &models.ModerateSlow{WaitTimeSeconds: waitTime}
+	case "vip":
+		moderate.Vip = target
+	case "unvip":
+		moderate.Unvip = target
+	case "mod":
+		moderate.Mod = target
+	case "unmod":
+		moderate.Unmod = target
+	case "raid":
+		viewers := params.Cost
+		if viewers == 0 {
+			viewers = util.RandomViewerCount()
+		}
+		moderate.Raid = &models.ModerateRaid{
+			UserID:      target.UserID,
+			UserLogin:   target.UserLogin,
+			UserName:    target.UserName,
+			ViewerCount: viewers,
+		}
+	case "unraid":
+		moderate.Unraid = target
+	case "delete":
+		messageID := params.ItemID
+		if messageID == "" {
+			messageID = util.RandomGUID()
+		}
+		messageBody := params.MessageText
+		if messageBody == "" {
+			messageBody = "This is a test chat message."
+		}
+		moderate.Delete = &models.ModerateDelete{
+			UserID:      target.UserID,
+			UserLogin:   target.UserLogin,
+			UserName:    target.UserName,
+			MessageID:   messageID,
+			MessageBody: messageBody,
+		}
+	case "add_blocked_term", "add_permitted_term", "remove_blocked_term", "remove_permitted_term":
+		parts := strings.Split(action, "_")
+		term := params.ItemName
+		if term == "" {
+			term = "testterm"
+		}
+		moderate.AutomodTerms = &models.ModerateAutomodTerms{
+			Action:      parts[0],
+			List:        parts[1],
+			Terms:       []string{term},
+			FromAutomod: false,
+		}
+	case "approve_unban_request", "deny_unban_request":
+		moderate.UnbanRequest = &models.ModerateUnbanRequest{
+			IsApproved:       action == "approve_unban_request",
+			UserID:           target.UserID,
+			UserLogin:        target.UserLogin,
+			UserName:         target.UserName,
+			ModeratorMessage: params.Description,
+		}
+	}
+
+	return moderate, nil
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package moderate_v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "moderate",
+		SubscriptionStatus: "enabled",
+		Timestamp:          "2024-01-01T00:00:00Z",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.ModerateEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("channel.moderate", body.Subscription.Type)
+	a.Equal("1", body.Subscription.Version)
+	a.Equal(toUser, body.Event.BroadcasterUserID)
+	a.Equal("ban", body.Event.Action)
+	a.NotNil(body.Event.Ban)
+	a.Equal(fromUser, body.Event.Ban.UserID)
+	a.Nil(body.Event.Timeout)
+
+	// timeouts reuse the ban end timestamp
+	params.ModerateAction = "timeout"
+	params.BanEndTimestamp = "2024-01-01T01:00:00Z"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.ModerateEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("timeout", body.Event.Action)
+	a.NotNil(body.Event.Timeout)
+	a.Equal("2024-01-01T01:00:00Z", body.Event.Timeout.ExpiresAt)
+	a.Nil(body.Event.Ban)
+
+	params.ModerateAction = "slow"
+	params.Cost = 120
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.ModerateEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal(int64(120), body.Event.Slow.WaitTimeSeconds)
+
+	params.ModerateAction = "add_blocked_term"
+	params.ItemName = "badword"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.ModerateEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("add", body.Event.AutomodTerms.Action)
+	a.Equal("blocked", body.Event.AutomodTerms.List)
+	a.Equal([]string{"badword"}, body.Event.AutomodTerms.Terms)
+
+	// warnings were added in version 2
+	params.ModerateAction = "warn"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "moderate",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("moderate")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebhook)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "moderate")
+	a.Equal("channel.moderate", r)
+}
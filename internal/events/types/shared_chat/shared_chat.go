@@ -0,0 +1,172 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package shared_chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+
+var triggerSupported = []string{"shared-chat-begin", "shared-chat-update", "shared-chat-end"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"shared-chat-begin":  "channel.shared_chat.begin",
+		"shared-chat-update": "channel.shared_chat.update",
+		"shared-chat-end":    "channel.shared_chat.end",
+	},
+	models.TransportWebSocket: {
+		"shared-chat-begin":  "channel.shared_chat.begin",
+		"shared-chat-update": "channel.shared_chat.update",
+		"shared-chat-end":    "channel.shared_chat.end",
+	},
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		sessionID := params.ItemID
+		if sessionID == "" {
+			sessionID = util.RandomGUID()
+		}
+
+		// The from user hosts the session that the broadcaster is part of
+		eventBody := models.SharedChatEventSubEvent{
+			SessionID:                sessionID,
+			BroadcasterUserID:        params.ToUserID,
+			BroadcasterUserLogin:     strings.ToLower(params.ToUserName),
+			BroadcasterUserName:      params.ToUserName,
+			HostBroadcasterUserID:    params.FromUserID,
+			HostBroadcasterUserLogin: strings.ToLower(params.FromUserName),
+			HostBroadcasterUserName:  params.FromUserName,
+		}
+		if params.Trigger != "shared-chat-end" {
+			eventBody.Participants = participants(params)
+		}
+
+		body := models.SharedChatEventSubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Status:  params.SubscriptionStatus,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				Cost:      0,
+				CreatedAt: params.Timestamp,
+			},
+			Event: eventBody,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+// participants returns the host and the broadcaster, followed by any other broadcasters in params.ParticipantIDs.
+func participants(params events.MockEventParameters) []models.SharedChatParticipant {
+	list := []models.SharedChatParticipant{}
+	seen := map[string]bool{}
+	add := func(id string, name string) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		list = append(list, models.SharedChatParticipant{
+			BroadcasterUserID:    id,
+			BroadcasterUserLogin: strings.ToLower(name),
+			BroadcasterUserName:  name,
+		})
+	}
+
+	add(params.FromUserID, params.FromUserName)
+	add(params.ToUserID, params.ToUserName)
+	for i, id := range params.ParticipantIDs {
+		add(id, fmt.Sprintf("testParticipant%v", i+1))
+	}
+	return list
+}
+
+func (e Event) ValidTransport(t string) bool {
+	return transportsSupported[t]
+}
+
+func (e Event) ValidTrigger(t string) bool {
+	for _, ts := range triggerSupported {
+		if ts == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package shared_chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		FromUserName:       "testHost",
+		ToUserID:           toUser,
+		ToUserName:         "testBroadcaster",
+		Transport:          models.TransportWebhook,
+		Trigger:            "shared-chat-begin",
+		SubscriptionStatus: "enabled",
+		ParticipantIDs:     []string{"7890", toUser},
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.SharedChatEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("channel.shared_chat.begin", body.Subscription.Type)
+	a.Equal(toUser, body.Subscription.Condition.BroadcasterUserID)
+	a.Equal(fromUser, body.Event.HostBroadcasterUserID)
+	a.Equal("testhost", body.Event.HostBroadcasterUserLogin)
+
+	// the host and broadcaster are always participants, and nobody is listed twice
+	ids := []string{}
+	for _, p := range body.Event.Participants {
+		ids = append(ids, p.BroadcasterUserID)
+	}
+	a.Equal([]string{fromUser, toUser, "7890"}, ids)
+
+	params.Trigger = "shared-chat-end"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.SharedChatEventSubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("channel.shared_chat.end", body.Subscription.Type)
+	a.Empty(body.Event.Participants)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "shared-chat-update",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("shared-chat-update")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "shared-chat-update")
+	a.Equal("channel.shared_chat.update", r)
+}
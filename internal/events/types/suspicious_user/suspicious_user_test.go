@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package suspicious_user
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "suspicious-user-message",
+		SubscriptionStatus: "enabled",
+		MessageText:        "hello",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.SuspiciousUserMessageEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+
+	a.Equal("channel.suspicious_user.message", body.Subscription.Type)
+	a.Equal(fromUser, body.Event.UserID)
+	a.Equal("active_monitoring", body.Event.LowTrustStatus)
+	a.Equal("hello", body.Event.Message.Text)
+
+	params.Trigger = "suspicious-user-update"
+	params.EventStatus = "restricted"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var update models.SuspiciousUserUpdateEventSubResponse
+	err = json.Unmarshal(r.JSON, &update)
+	a.Nil(err)
+
+	a.Equal("channel.suspicious_user.update", update.Subscription.Type)
+	a.Equal("restricted", update.Event.LowTrustStatus)
+	a.NotEmpty(update.Event.ModeratorUserID)
+
+	params.EventStatus = "potato"
+	_, err = Event{}.GenerateEvent(params)
+	a.NotNil(err)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "suspicious-user-message",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("suspicious-user-message")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebhook)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "suspicious-user-message")
+	a.Equal("channel.suspicious_user.message", r)
+}
@@ -5,62 +5,95 @@ package types
 import (
 	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/twitchdev/twitch-cli/internal/events"
 	"github.com/twitchdev/twitch-cli/internal/events/types/ad_break"
 	"github.com/twitchdev/twitch-cli/internal/events/types/authorization_grant"
 	"github.com/twitchdev/twitch-cli/internal/events/types/authorization_revoke"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v1"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automatic_reward_v2"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automod_message"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automod_settings"
+	"github.com/twitchdev/twitch-cli/internal/events/types/automod_terms"
 	"github.com/twitchdev/twitch-cli/internal/events/types/ban"
+	"github.com/twitchdev/twitch-cli/internal/events/types/bits_use"
 	"github.com/twitchdev/twitch-cli/internal/events/types/channel_points_redemption"
 	"github.com/twitchdev/twitch-cli/internal/events/types/channel_points_reward"
 	"github.com/twitchdev/twitch-cli/internal/events/types/channel_update_v1"
 	"github.com/twitchdev/twitch-cli/internal/events/types/channel_update_v2"
 	"github.com/twitchdev/twitch-cli/internal/events/types/charity"
+	"github.com/twitchdev/twitch-cli/internal/events/types/chat"
 	"github.com/twitchdev/twitch-cli/internal/events/types/cheer"
+	"github.com/twitchdev/twitch-cli/internal/events/types/custom"
 	"github.com/twitchdev/twitch-cli/internal/events/types/drop"
 	"github.com/twitchdev/twitch-cli/internal/events/types/extension_transaction"
 	"github.com/twitchdev/twitch-cli/internal/events/types/follow"
 	"github.com/twitchdev/twitch-cli/internal/events/types/gift"
 	"github.com/twitchdev/twitch-cli/internal/events/types/goal"
+	"github.com/twitchdev/twitch-cli/internal/events/types/guest_star"
 	"github.com/twitchdev/twitch-cli/internal/events/types/hype_train"
+	"github.com/twitchdev/twitch-cli/internal/events/types/moderate_v1"
+	"github.com/twitchdev/twitch-cli/internal/events/types/moderate_v2"
 	"github.com/twitchdev/twitch-cli/internal/events/types/moderator_change"
 	"github.com/twitchdev/twitch-cli/internal/events/types/poll"
 	"github.com/twitchdev/twitch-cli/internal/events/types/prediction"
 	"github.com/twitchdev/twitch-cli/internal/events/types/raid"
+	"github.com/twitchdev/twitch-cli/internal/events/types/shared_chat"
 	"github.com/twitchdev/twitch-cli/internal/events/types/shield_mode"
 	"github.com/twitchdev/twitch-cli/internal/events/types/shoutout"
 	"github.com/twitchdev/twitch-cli/internal/events/types/streamdown"
 	"github.com/twitchdev/twitch-cli/internal/events/types/streamup"
 	"github.com/twitchdev/twitch-cli/internal/events/types/subscribe"
 	"github.com/twitchdev/twitch-cli/internal/events/types/subscription_message"
+	"github.com/twitchdev/twitch-cli/internal/events/types/suspicious_user"
 	"github.com/twitchdev/twitch-cli/internal/events/types/unban"
 	"github.com/twitchdev/twitch-cli/internal/events/types/unban_requests"
 	user_update "github.com/twitchdev/twitch-cli/internal/events/types/user"
+	"github.com/twitchdev/twitch-cli/internal/events/types/vip"
+	"github.com/twitchdev/twitch-cli/internal/events/types/warning"
+	"github.com/twitchdev/twitch-cli/internal/events/types/whisper"
 	"github.com/twitchdev/twitch-cli/internal/models"
 )
 
 func AllEvents() []events.MockEvent {
+	return append(builtInEvents(), customEvents()...)
+}
+
+func builtInEvents() []events.MockEvent {
 	return []events.MockEvent{
 		ad_break.Event{},
 		authorization_grant.Event{},
 		authorization_revoke.Event{},
+		automatic_reward_v1.Event{},
+		automatic_reward_v2.Event{},
+		automod_message.Event{},
+		automod_settings.Event{},
+		automod_terms.Event{},
 		ban.Event{},
+		bits_use.Event{},
 		channel_points_redemption.Event{},
 		channel_points_reward.Event{},
 		charity.Event{},
+		chat.Event{},
 		cheer.Event{},
 		drop.Event{},
 		extension_transaction.Event{},
 		follow.Event{},
 		gift.Event{},
 		goal.Event{},
+		guest_star.Event{},
 		hype_train.Event{},
+		moderate_v1.Event{},
+		moderate_v2.Event{},
 		moderator_change.Event{},
 		poll.Event{},
 		prediction.Event{},
 		raid.Event{},
+		shared_chat.Event{},
 		shield_mode.Event{},
 		shoutout.Event{},
 		channel_update_v1.Event{},
@@ -69,12 +102,55 @@ func AllEvents() []events.MockEvent {
 		streamdown.Event{},
 		subscribe.Event{},
 		subscription_message.Event{},
+		suspicious_user.Event{},
 		unban.Event{},
 		unban_requests.Event{},
 		user_update.Event{},
+		vip.Event{},
+		warning.Event{},
+		whisper.Event{},
 	}
 }
 
+var loadCustomEvents sync.Once
+var loadedCustomEvents []events.MockEvent
+
+// customEvents returns the events defined by template files in the application directory. They're only read once per run.
+// Templates for a subscription type and version the CLI already supports are skipped, so built-in events always take priority.
+func customEvents() []events.MockEvent {
+	loadCustomEvents.Do(func() {
+		dir, err := custom.TemplateDir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+
+		templates, errs := custom.Load(dir)
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		builtIn := map[string]bool{}
+		for _, e := range builtInEvents() {
+			for _, transport := range []string{models.TransportWebhook, models.TransportWebSocket} {
+				for _, topic := range e.GetAllTopicsByTransport(transport) {
+					builtIn[topic+" v"+e.SubscriptionVersion()] = true
+				}
+			}
+		}
+
+		for _, t := range templates {
+			if builtIn[t.Definition.Type+" v"+t.SubscriptionVersion()] {
+				fmt.Fprintf(os.Stderr, "Skipping event template %v: %v version %v is already supported by the CLI\n", t.Path, t.Definition.Type, t.SubscriptionVersion())
+				continue
+			}
+			loadedCustomEvents = append(loadedCustomEvents, t)
+		}
+	})
+
+	return loadedCustomEvents
+}
+
 func AllWebhookTopics() []string {
 	allEvents := []string{}
 	allEventsMap := make(map[string]int)
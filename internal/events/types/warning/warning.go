@@ -0,0 +1,161 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package warning
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var transportsSupported = map[string]bool{
+	models.TransportWebhook:   true,
+	models.TransportWebSocket: true,
+}
+var triggers = []string{"warning-send", "warning-acknowledge"}
+
+var triggerMapping = map[string]map[string]string{
+	models.TransportWebhook: {
+		"warning-send":        "channel.warning.send",
+		"warning-acknowledge": "channel.warning.acknowledge",
+	},
+	models.TransportWebSocket: {
+		"warning-send":        "channel.warning.send",
+		"warning-acknowledge": "channel.warning.acknowledge",
+	},
+}
+
+type Event struct{}
+
+func (e Event) GenerateEvent(params events.MockEventParameters) (events.MockEventResponse, error) {
+	var event []byte
+	var err error
+
+	moderatorUserID := util.RandomUserID()
+
+	var warningEvent interface{}
+
+	if params.Trigger == "warning-send" {
+		reason := params.Description
+		if reason == "" {
+			reason = "This is a test event"
+		}
+
+		warningEvent = models.WarningSendEventSubEvent{
+			BroadcasterUserID:    params.ToUserID,
+			BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+			BroadcasterUserName:  params.ToUserName,
+			ModeratorUserID:      moderatorUserID,
+			ModeratorUserLogin:   "climoderator",
+			ModeratorUserName:    "CLIModerator",
+			UserID:               params.FromUserID,
+			UserLogin:            strings.ToLower(params.FromUserName),
+			UserName:             params.FromUserName,
+			Reason:               &reason,
+			ChatRulesCited:       nil,
+		}
+	}
+
+	if params.Trigger == "warning-acknowledge" {
+		warningEvent = models.WarningAcknowledgeEventSubEvent{
+			BroadcasterUserID:    params.ToUserID,
+			BroadcasterUserLogin: strings.ToLower(params.ToUserName),
+			BroadcasterUserName:  params.ToUserName,
+			UserID:               params.FromUserID,
+			UserLogin:            strings.ToLower(params.FromUserName),
+			UserName:             params.FromUserName,
+		}
+	}
+
+	switch params.Transport {
+	case models.TransportWebhook, models.TransportWebSocket:
+		body := models.EventsubResponse{
+			Subscription: models.EventsubSubscription{
+				ID:      params.SubscriptionID,
+				Type:    triggerMapping[params.Transport][params.Trigger],
+				Version: e.SubscriptionVersion(),
+				Status:  params.SubscriptionStatus,
+				Cost:    0,
+				Condition: models.EventsubCondition{
+					BroadcasterUserID: params.ToUserID,
+					ModeratorUserID:   moderatorUserID,
+				},
+				Transport: models.EventsubTransport{
+					Method:   "webhook",
+					Callback: "null",
+				},
+				CreatedAt: params.Timestamp,
+			},
+			Event: warningEvent,
+		}
+
+		event, err = json.Marshal(body)
+		if err != nil {
+			return events.MockEventResponse{}, err
+		}
+
+		// Delete event info if Subscription.Status is not set to "enabled"
+		if !strings.EqualFold(params.SubscriptionStatus, "enabled") {
+			var i interface{}
+			if err := json.Unmarshal([]byte(event), &i); err != nil {
+				return events.MockEventResponse{}, err
+			}
+			if m, ok := i.(map[string]interface{}); ok {
+				delete(m, "event") // Matches JSON key defined in body variable above
+			}
+
+			event, err = json.Marshal(i)
+			if err != nil {
+				return events.MockEventResponse{}, err
+			}
+		}
+	default:
+		return events.MockEventResponse{}, nil
+	}
+
+	return events.MockEventResponse{
+		ID:       params.EventMessageID,
+		JSON:     event,
+		FromUser: params.FromUserID,
+		ToUser:   params.ToUserID,
+	}, nil
+}
+
+func (e Event) ValidTransport(transport string) bool {
+	return transportsSupported[transport]
+}
+
+func (e Event) ValidTrigger(trigger string) bool {
+	for _, t := range triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+func (e Event) GetTopic(transport string, trigger string) string {
+	return triggerMapping[transport][trigger]
+}
+func (e Event) GetAllTopicsByTransport(transport string) []string {
+	allTopics := []string{}
+	for _, topic := range triggerMapping[transport] {
+		allTopics = append(allTopics, topic)
+	}
+	return allTopics
+}
+func (e Event) GetEventSubAlias(t string) string {
+	// check for aliases
+	for trigger, topic := range triggerMapping[models.TransportWebhook] {
+		if topic == t {
+			return trigger
+		}
+	}
+	return ""
+}
+
+func (e Event) SubscriptionVersion() string {
+	return "1"
+}
@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package warning
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          models.TransportWebhook,
+		Trigger:            "warning-send",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.EventsubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("channel.warning.send", body.Subscription.Type)
+
+	var send models.WarningSendEventSubEvent
+	b, _ := json.Marshal(body.Event)
+	err = json.Unmarshal(b, &send)
+	a.Nil(err)
+	a.Equal(fromUser, send.UserID)
+	a.Equal(toUser, send.BroadcasterUserID)
+	a.Equal(body.Subscription.Condition.ModeratorUserID, send.ModeratorUserID)
+	a.NotNil(send.Reason)
+
+	params.Trigger = "warning-acknowledge"
+	r, err = Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	body = models.EventsubResponse{}
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("channel.warning.acknowledge", body.Subscription.Type)
+
+	var acknowledge models.WarningAcknowledgeEventSubEvent
+	b, _ = json.Marshal(body.Event)
+	err = json.Unmarshal(b, &acknowledge)
+	a.Nil(err)
+	a.Equal(fromUser, acknowledge.UserID)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "warning-send",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("warning-acknowledge")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebhook)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "warning-send")
+	a.Equal("channel.warning.send", r)
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package whisper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var fromUser = "1234"
+var toUser = "4567"
+
+func TestEventSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		FromUserName:       "testSender",
+		ToUserID:           toUser,
+		ToUserName:         "testRecipient",
+		Transport:          models.TransportWebhook,
+		Trigger:            "whisper-message",
+		SubscriptionStatus: "enabled",
+		MessageText:        "psst",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+
+	var body models.WhisperMessageEventSubResponse
+	err = json.Unmarshal(r.JSON, &body)
+	a.Nil(err)
+	a.Equal("user.whisper.message", body.Subscription.Type)
+	a.Equal(toUser, body.Subscription.Condition.UserID)
+	a.Equal(fromUser, body.Event.FromUserID)
+	a.Equal("testsender", body.Event.FromUserLogin)
+	a.Equal("testrecipient", body.Event.ToUserLogin)
+	a.Equal("psst", body.Event.Whisper.Text)
+	a.NotEmpty(body.Event.WhisperID)
+}
+
+func TestFakeTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := events.MockEventParameters{
+		FromUserID:         fromUser,
+		ToUserID:           toUser,
+		Transport:          "fake_transport",
+		Trigger:            "whisper-message",
+		SubscriptionStatus: "enabled",
+	}
+
+	r, err := Event{}.GenerateEvent(params)
+	a.Nil(err)
+	a.Empty(r)
+}
+
+func TestValidTrigger(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTrigger("whisper-message")
+	a.Equal(true, r)
+
+	r = Event{}.ValidTrigger("not_trigger_keyword")
+	a.Equal(false, r)
+}
+
+func TestValidTransport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.ValidTransport(models.TransportWebSocket)
+	a.Equal(true, r)
+
+	r = Event{}.ValidTransport("noteventsub")
+	a.Equal(false, r)
+}
+
+func TestGetTopic(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	r := Event{}.GetTopic(models.TransportWebhook, "whisper-message")
+	a.Equal("user.whisper.message", r)
+}
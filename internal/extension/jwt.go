@@ -0,0 +1,190 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extension
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	RoleBroadcaster = "broadcaster"
+	RoleModerator   = "moderator"
+	RoleViewer      = "viewer"
+	RoleExternal    = "external" // Used by Extension backends (EBS) to call the API
+)
+
+var Roles = []string{RoleBroadcaster, RoleModerator, RoleViewer, RoleExternal}
+
+// PubSubPerms are the Extension PubSub targets a JWT may listen to and send to. "*" allows every target.
+type PubSubPerms struct {
+	Listen []string `json:"listen,omitempty"`
+	Send   []string `json:"send,omitempty"`
+}
+
+// Claims are the fields of an Extension JWT, as described at https://dev.twitch.tv/docs/extensions/reference/#jwt-schema
+type Claims struct {
+	Exp          int64        `json:"exp"`
+	OpaqueUserID string       `json:"opaque_user_id,omitempty"`
+	UserID       string       `json:"user_id,omitempty"`
+	ChannelID    string       `json:"channel_id,omitempty"`
+	Role         string       `json:"role"`
+	IsUnlinked   bool         `json:"is_unlinked,omitempty"`
+	PubSubPerms  *PubSubPerms `json:"pubsub_perms,omitempty"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// NewClaims returns the claims Twitch would give a user with the given role on a channel, valid for ttl. Viewers without
+// a user ID are anonymous. Backends (the external role) use the channel ID "all" when no channel is given, which allows global broadcasts.
+func NewClaims(role string, channelID string, userID string, ttl time.Duration) (Claims, error) {
+	c := Claims{
+		Exp:       util.GetTimestamp().Add(ttl).Unix(),
+		UserID:    userID,
+		ChannelID: channelID,
+		Role:      role,
+	}
+
+	switch role {
+	case RoleBroadcaster, RoleModerator, RoleViewer:
+		if channelID == "" {
+			return c, fmt.Errorf("A channel is required for the %v role", role)
+		}
+		if role == RoleBroadcaster && c.UserID == "" {
+			c.UserID = channelID
+		}
+
+		if c.UserID != "" {
+			c.OpaqueUserID = "U" + c.UserID
+		} else {
+			c.OpaqueUserID = "A" + util.RandomUserID()
+			c.IsUnlinked = true
+		}
+
+		c.PubSubPerms = &PubSubPerms{Listen: []string{"broadcast", "global", "whisper-" + c.OpaqueUserID}}
+		if role == RoleBroadcaster {
+			c.PubSubPerms.Send = []string{"broadcast"}
+		}
+	case RoleExternal:
+		if c.ChannelID == "" {
+			c.ChannelID = "all"
+		}
+		c.PubSubPerms = &PubSubPerms{Send: []string{"*"}}
+	default:
+		return c, fmt.Errorf("Invalid role %v. Valid roles: %v", role, strings.Join(Roles, ", "))
+	}
+
+	return c, nil
+}
+
+// Sign creates a JWT for the claims, signed with HS256 using the Extension secret. Secrets are base64 encoded, as
+// shown in the Extension's settings on the developer console.
+func Sign(c Claims, secret string) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature(unsigned, key)), nil
+}
+
+// Verify checks that the JWT was signed with the Extension secret and hasn't expired, and returns its claims.
+func Verify(token string, secret string) (Claims, error) {
+	var c Claims
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return c, err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return c, errors.New("JWT is malformed")
+	}
+
+	var header jwtHeader
+	err = decodeSegment(parts[0], &header)
+	if err != nil {
+		return c, errors.New("JWT header is malformed")
+	}
+	if header.Alg != "HS256" {
+		return c, fmt.Errorf("JWT must be signed with HS256, not %v", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, signature(parts[0]+"."+parts[1], key)) {
+		return c, errors.New("JWT signature is invalid")
+	}
+
+	err = decodeSegment(parts[1], &c)
+	if err != nil {
+		return c, errors.New("JWT payload is malformed")
+	}
+	if c.Exp <= util.GetTimestamp().Unix() {
+		return c, errors.New("JWT has expired")
+	}
+
+	return c, nil
+}
+
+// CanSend returns whether the claims allow sending Extension PubSub messages to target.
+func (c Claims) CanSend(target string) bool {
+	return c.PubSubPerms != nil && allows(c.PubSubPerms.Send, target)
+}
+
+// CanListen returns whether the claims allow listening to Extension PubSub messages sent to target.
+func (c Claims) CanListen(target string) bool {
+	return c.PubSubPerms != nil && allows(c.PubSubPerms.Listen, target)
+}
+
+func allows(perms []string, target string) bool {
+	for _, p := range perms {
+		if p == "*" || p == target {
+			return true
+		}
+	}
+	return false
+}
+
+func signature(unsigned string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, errors.New("Extension secrets must be base64 encoded")
+	}
+	return key, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
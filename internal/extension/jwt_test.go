@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extension
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var secret = base64.StdEncoding.EncodeToString([]byte("potatopotatopotato"))
+
+func TestSignAndVerify(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	c, err := NewClaims(RoleBroadcaster, "1234", "", time.Hour)
+	a.Nil(err)
+	a.Equal("1234", c.UserID)
+	a.Equal("U1234", c.OpaqueUserID)
+	a.True(c.CanSend("broadcast"))
+	a.True(c.CanListen("whisper-U1234"))
+	a.False(c.CanListen("whisper-U5678"))
+
+	token, err := Sign(c, secret)
+	a.Nil(err)
+
+	verified, err := Verify(token, secret)
+	a.Nil(err)
+	a.Equal(c, verified)
+
+	// A different secret or a changed payload must not verify
+	_, err = Verify(token, base64.StdEncoding.EncodeToString([]byte("tomatotomatotomato")))
+	a.NotNil(err)
+
+	other, err := Sign(Claims{Exp: c.Exp, ChannelID: "1234", Role: RoleExternal}, secret)
+	a.Nil(err)
+	parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+	_, err = Verify(parts[0]+"."+otherParts[1]+"."+parts[2], secret)
+	a.NotNil(err)
+
+	expired, err := NewClaims(RoleViewer, "1234", "5678", -time.Minute)
+	a.Nil(err)
+	token, err = Sign(expired, secret)
+	a.Nil(err)
+	_, err = Verify(token, secret)
+	a.NotNil(err)
+
+	_, err = Sign(c, "not base64!")
+	a.NotNil(err)
+}
+
+func TestNewClaims(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	c, err := NewClaims(RoleViewer, "1234", "", time.Hour)
+	a.Nil(err)
+	a.True(c.IsUnlinked)
+	a.Equal("A", c.OpaqueUserID[:1])
+	a.False(c.CanSend("broadcast"))
+
+	c, err = NewClaims(RoleExternal, "", "", time.Hour)
+	a.Nil(err)
+	a.Equal("all", c.ChannelID)
+	a.True(c.CanSend("global"))
+	a.False(c.CanListen("broadcast"))
+
+	_, err = NewClaims(RoleViewer, "", "", time.Hour)
+	a.NotNil(err)
+
+	_, err = NewClaims("potato", "1234", "", time.Hour)
+	a.NotNil(err)
+}
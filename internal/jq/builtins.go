@@ -0,0 +1,242 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func identity(v any) ([]any, error) { return []any{v}, nil }
+
+func pipe(left, right Filter) Filter {
+	return func(v any) ([]any, error) {
+		in, err := left(v)
+		if err != nil {
+			return nil, err
+		}
+		out := []any{}
+		for _, i := range in {
+			o, err := right(i)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, o...)
+		}
+		return out, nil
+	}
+}
+
+// binary evaluates both sides against the same input, combining every pair of results like jq does
+func binary(left, right Filter, op func(a, b any) (any, error)) Filter {
+	return func(v any) ([]any, error) {
+		l, err := left(v)
+		if err != nil {
+			return nil, err
+		}
+		r, err := right(v)
+		if err != nil {
+			return nil, err
+		}
+		out := []any{}
+		for _, a := range l {
+			for _, b := range r {
+				o, err := op(a, b)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, o)
+			}
+		}
+		return out, nil
+	}
+}
+
+func field(name string) Filter {
+	return func(v any) ([]any, error) {
+		if v != nil && !isObject(v) {
+			return nil, fmt.Errorf("Cannot index %v with %q", typeName(v), name)
+		}
+		return []any{getKey(v, name)}, nil
+	}
+}
+
+func iterate(v any) ([]any, error) {
+	switch c := v.(type) {
+	case []any:
+		return c, nil
+	case *Object, map[string]any:
+		return values(c), nil
+	}
+	return nil, fmt.Errorf("Cannot iterate over %v", typeName(v))
+}
+
+func selectFilter(cond Filter) Filter {
+	return func(v any) ([]any, error) {
+		results, err := cond(v)
+		if err != nil {
+			return nil, err
+		}
+		out := []any{}
+		for _, r := range results {
+			if Truthy(r) {
+				out = append(out, v)
+			}
+		}
+		return out, nil
+	}
+}
+
+// assign sets path to each value of the right-hand side, which is evaluated against the input, such as .a = .b.
+// The input isn't changed; each result is a copy.
+func assign(path Path, op string, value Filter) Filter {
+	return func(v any) ([]any, error) {
+		values, err := value(v)
+		if err != nil {
+			return nil, err
+		}
+		out := []any{}
+		for _, n := range values {
+			root := Copy(v)
+			n = Copy(n)
+			switch op {
+			case "+=":
+				n, err = add(path.Get(root), n, 1)
+			case "-=":
+				n, err = add(path.Get(root), n, -1)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%v %v : %v", path, op, err)
+			}
+			root, err = path.Set(root, n)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to set %v: %v", path, err)
+			}
+			out = append(out, root)
+		}
+		return out, nil
+	}
+}
+
+func deleteFilter(path Path) Filter {
+	return func(v any) ([]any, error) {
+		return []any{path.Delete(Copy(v))}, nil
+	}
+}
+
+func lengthFilter(v any) ([]any, error) {
+	switch c := v.(type) {
+	case nil:
+		return []any{float64(0)}, nil
+	case string:
+		return []any{float64(len([]rune(c)))}, nil
+	case []any:
+		return []any{float64(len(c))}, nil
+	case *Object, map[string]any:
+		return []any{float64(len(keys(c)))}, nil
+	}
+	return nil, fmt.Errorf("%v has no length", typeName(v))
+}
+
+func keysFilter(v any) ([]any, error) {
+	if !isObject(v) {
+		return nil, fmt.Errorf("%v has no keys", typeName(v))
+	}
+	out := []any{}
+	for _, k := range keys(v) {
+		out = append(out, k)
+	}
+	return []any{out}, nil
+}
+
+func compare(op string, a, b any) bool {
+	switch op {
+	case "==":
+		return Equal(a, b)
+	case "!=":
+		return !Equal(a, b)
+	}
+
+	var c int
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		if !ok {
+			return false
+		}
+		if x < y {
+			c = -1
+		} else if x > y {
+			c = 1
+		}
+	} else if x, ok := a.(string); ok {
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(x, y)
+	} else {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// add adds or subtracts numbers, or appends strings and arrays when adding. A null current value is treated like jq does, as nothing.
+// Numbers decoded as json.Number stay json.Numbers, so integers are written back without a decimal point or exponent.
+func add(current any, value any, sign int64) (any, error) {
+	if current == nil {
+		if sign < 0 {
+			if _, ok := number(value); ok {
+				return add(json.Number("0"), value, sign)
+			}
+		}
+		return value, nil
+	}
+
+	if a, ok := number(current); ok {
+		b, ok := number(value)
+		if !ok {
+			return nil, fmt.Errorf("can't combine a number with %v", typeName(value))
+		}
+		c, cok := current.(json.Number)
+		n, nok := value.(json.Number)
+		if cok && nok {
+			if x, err := c.Int64(); err == nil {
+				if y, err := n.Int64(); err == nil {
+					return json.Number(fmt.Sprint(x + sign*y)), nil
+				}
+			}
+			return json.Number(fmt.Sprint(a + float64(sign)*b)), nil
+		}
+		return a + float64(sign)*b, nil
+	}
+
+	switch c := current.(type) {
+	case string:
+		s, ok := value.(string)
+		if !ok || sign < 0 {
+			return nil, fmt.Errorf("strings can only have strings added to them")
+		}
+		return c + s, nil
+
+	case []any:
+		a, ok := value.([]any)
+		if !ok || sign < 0 {
+			return nil, fmt.Errorf("arrays can only have arrays added to them")
+		}
+		return append(c, a...), nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %v", typeName(current))
+}
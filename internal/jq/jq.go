@@ -0,0 +1,428 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jq implements the subset of jq shared by every command that takes an expression, such as the --filter of api
+// commands and the --jq of event trigger, so the same expression means the same thing everywhere.
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a compiled expression. Like jq, each filter takes one value and produces a stream of values.
+type Filter func(v any) ([]any, error)
+
+// Compile compiles an expression using the supported subset of jq:
+//
+//   - paths, such as ., .user_login, .data[0], .[-1], .[] and .["key"]
+//   - pipes, such as .[] | .user_login
+//   - comparisons with ==, !=, <, <=, > and >=, joined with and/or
+//   - JSON literals, such as "text", 10, true, null, [1, 2] and {"a": 1}
+//   - assignments to a path with =, += and -=, such as .event.bits += 10 or .a = .b. += adds numbers and appends
+//     strings and arrays.
+//   - select(cond), del(path), length, keys, not, and parentheses
+func Compile(expr string) (Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	f, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("Invalid filter %q: unexpected %q", expr, p.peek())
+	}
+	return f, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(t string) error {
+	if p.peek() != t {
+		return fmt.Errorf("Invalid filter: expected %q but found %q", t, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) parsePipe() (Filter, error) {
+	left, err := p.parseAssignment()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "|" {
+		p.next()
+		right, err := p.parseAssignment()
+		if err != nil {
+			return nil, err
+		}
+		left = pipe(left, right)
+	}
+	return left, nil
+}
+
+func (p *parser) parseAssignment() (Filter, error) {
+	start := p.pos
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op := p.peek(); op {
+	case "=", "+=", "-=":
+		path, err := p.path(start)
+		if err != nil {
+			return nil, err
+		}
+		p.next()
+		value, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return assign(path, op, value), nil
+	case "|=":
+		return nil, fmt.Errorf("Invalid filter: |= isn't supported; use =, += or -=")
+	}
+	return left, nil
+}
+
+// path reads the tokens from start to the current position as a path, for the left side of an assignment or del(path)
+func (p *parser) path(start int) (Path, error) {
+	tokens := p.tokens[start:p.pos]
+	var b strings.Builder
+	for i, t := range tokens {
+		if (i == 0 && t != ".") || (t != "." && t != "[" && t != "]" && !isIdent(t) && !isNumber(t) && !strings.HasPrefix(t, `"`)) {
+			return nil, fmt.Errorf("Invalid filter: only paths such as .event.title can be assigned to or deleted")
+		}
+		b.WriteString(t)
+	}
+	path, err := ParsePath(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid filter: %v", err)
+	}
+	return path, nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(left, right, func(a, b any) (any, error) { return Truthy(a) || Truthy(b), nil })
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "and" {
+		p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = binary(left, right, func(a, b any) (any, error) { return Truthy(a) && Truthy(b), nil })
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (Filter, error) {
+	left, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		return binary(left, right, func(a, b any) (any, error) { return compare(op, a, b), nil }), nil
+	}
+	return left, nil
+}
+
+func (p *parser) parsePostfix() (Filter, error) {
+	f, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case ".":
+			p.next()
+			if isIdent(p.peek()) {
+				f = pipe(f, field(p.next()))
+			} else if p.peek() != "[" {
+				return nil, fmt.Errorf("Invalid filter: expected a field name after \".\"")
+			}
+		case "[":
+			index, err := p.parseIndex()
+			if err != nil {
+				return nil, err
+			}
+			f = pipe(f, index)
+		default:
+			return f, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (Filter, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("Invalid filter: unexpected end of expression")
+	case t == ".":
+		// .field is handled here so ".field" and ". field" aren't confused with postfix access
+		if isIdent(p.peek()) {
+			return field(p.next()), nil
+		}
+		return identity, nil
+	case t == "(":
+		f, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+	case t == "select":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		cond, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return selectFilter(cond), p.expect(")")
+	case t == "del":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		start := p.pos
+		if _, err := p.parsePostfix(); err != nil {
+			return nil, err
+		}
+		path, err := p.path(start)
+		if err != nil {
+			return nil, err
+		}
+		return deleteFilter(path), p.expect(")")
+	case t == "length":
+		return lengthFilter, nil
+	case t == "keys":
+		return keysFilter, nil
+	case t == "not":
+		return func(v any) ([]any, error) { return []any{!Truthy(v)}, nil }, nil
+	case t == "true", t == "false", t == "null", strings.HasPrefix(t, `"`), isNumber(t), isCompositeLiteral(t):
+		literal, err := Decode([]byte(t))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid filter literal %v", t)
+		}
+		return func(any) ([]any, error) { return []any{Copy(literal)}, nil }, nil
+	}
+	return nil, fmt.Errorf("Invalid filter: unexpected %q", t)
+}
+
+// parseIndex parses [], [n] and ["key"]
+func (p *parser) parseIndex() (Filter, error) {
+	p.next()
+	if p.peek() == "]" {
+		p.next()
+		return iterate, nil
+	}
+
+	t := p.next()
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(t, `"`) {
+		var key string
+		if err := json.Unmarshal([]byte(t), &key); err != nil {
+			return nil, fmt.Errorf("Invalid filter key %v", t)
+		}
+		return field(key), nil
+	}
+	i, err := strconv.Atoi(t)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid filter index %v", t)
+	}
+	return func(v any) ([]any, error) {
+		if _, ok := v.([]any); !ok && v != nil {
+			return nil, fmt.Errorf("Cannot index %v with a number", typeName(v))
+		}
+		return []any{Path{i}.Get(v)}, nil
+	}, nil
+}
+
+// valueStart are the tokens after which a value is expected, so a [ there starts an array literal rather than an index
+var valueStart = map[string]bool{
+	"": true, "|": true, "(": true, "=": true, "+=": true, "-=": true,
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "and": true, "or": true,
+}
+
+func tokenize(expr string) ([]string, error) {
+	var tokens []string
+	previous := func() string {
+		if len(tokens) == 0 {
+			return ""
+		}
+		return tokens[len(tokens)-1]
+	}
+
+	r := []rune(expr)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '{' || (c == '[' && valueStart[previous()]):
+			end, err := literalEnd(r, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, string(r[i:end]))
+			i = end
+		case strings.ContainsRune("=!<>|+-", c) && i+1 < len(r) && r[i+1] == '=':
+			tokens = append(tokens, string(r[i:i+2]))
+			i += 2
+		case strings.ContainsRune(".[]|()<>=", c):
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(r) && r[j] != '"'; j++ {
+				if r[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(r) {
+				return nil, fmt.Errorf("Invalid filter: unterminated string")
+			}
+			tokens = append(tokens, string(r[i:j+1]))
+			i = j + 1
+		case c == '-' || unicode.IsDigit(c):
+			j := i + 1
+			for j < len(r) && (unicode.IsDigit(r[j]) || r[j] == '.' || r[j] == 'e' || r[j] == 'E') {
+				j++
+			}
+			tokens = append(tokens, string(r[i:j]))
+			i = j
+		case c == '_' || unicode.IsLetter(c):
+			j := i + 1
+			for j < len(r) && (r[j] == '_' || unicode.IsLetter(r[j]) || unicode.IsDigit(r[j])) {
+				j++
+			}
+			tokens = append(tokens, string(r[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("Invalid filter: unexpected %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+// literalEnd returns the end of the JSON object or array literal starting at start
+func literalEnd(r []rune, start int) (int, error) {
+	depth := 0
+	inString := false
+	for i := start; i < len(r); i++ {
+		switch {
+		case inString && r[i] == '\\':
+			i++
+		case r[i] == '"':
+			inString = !inString
+		case inString:
+		case r[i] == '{' || r[i] == '[':
+			depth++
+		case r[i] == '}' || r[i] == ']':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("Invalid filter: unterminated %c", r[start])
+}
+
+func isIdent(t string) bool {
+	if t == "" {
+		return false
+	}
+	c := []rune(t)[0]
+	return c == '_' || unicode.IsLetter(c)
+}
+
+func isNumber(t string) bool {
+	_, err := strconv.ParseFloat(t, 64)
+	return err == nil
+}
+
+func isCompositeLiteral(t string) bool {
+	return len(t) > 1 && (t[0] == '{' || t[0] == '[')
+}
+
+// Streams returns whether expr works on each item of an array on its own, such as .[] | select(.viewer_count > 100), so
+// applying it to each part of an array, such as each page of a response, gives the same results as applying it to the
+// whole array. Filters such as length, .[0] and keys don't.
+func Streams(expr string) bool {
+	tokens, err := tokenize(expr)
+	if err != nil || len(tokens) < 3 || tokens[0] != "." || tokens[1] != "[" || tokens[2] != "]" {
+		return false
+	}
+
+	// field and index access on each item may follow, such as .[].user_login or .[].tags[0]
+	i := 3
+	for i < len(tokens) {
+		switch tokens[i] {
+		case ".":
+			i++
+		case "[":
+			for i < len(tokens) && tokens[i] != "]" {
+				i++
+			}
+			i++
+		case "|":
+			return true
+		default:
+			if !isIdent(tokens[i]) || tokens[i-1] != "." {
+				return false
+			}
+			i++
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package jq
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestFilter(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var data any
+	json.Unmarshal([]byte(`[
+		{"id":"1","user_login":"a","viewer_count":50,"tags":["English"],"game":{"name":"Chess"}},
+		{"id":"2","user_login":"b","viewer_count":150,"tags":[],"game":null},
+		{"id":"3","user_login":"c","viewer_count":500,"tags":["Spanish"],"game":{"name":"Art"}}
+	]`), &data)
+
+	tests := []struct {
+		filter   string
+		expected string
+	}{
+		{`.`, `[` + `{"game":{"name":"Chess"},"id":"1","tags":["English"],"user_login":"a","viewer_count":50},` + `{"game":null,"id":"2","tags":[],"user_login":"b","viewer_count":150},` + `{"game":{"name":"Art"},"id":"3","tags":["Spanish"],"user_login":"c","viewer_count":500}]`},
+		{`.[] | .user_login`, `["a","b","c"]`},
+		{`.[].id`, `["1","2","3"]`},
+		{`.[1].user_login`, `["b"]`},
+		{`.[-1]["user_login"]`, `["c"]`},
+		{`.[] | select(.viewer_count > 100) | .id`, `["2","3"]`},
+		{`.[] | select(.viewer_count >= 150 and .user_login != "c") | .id`, `["2"]`},
+		{`.[] | select(.user_login == "a" or .viewer_count == 500) | .id`, `["1","3"]`},
+		{`.[] | select(.game.name == "Art") | .id`, `["3"]`},
+		{`.[] | select(.tags | length > 0) | .id`, `["1","3"]`},
+		{`.[] | select(.game | not) | .id`, `["2"]`},
+		{`length`, `[3]`},
+		{`.[0] | keys`, `[["game","id","tags","user_login","viewer_count"]]`},
+		{`.[] | select(.tags == []) | .id`, `["2"]`},
+		{`.[0].viewer_count += 5 | .[0].viewer_count`, `[55]`},
+		{`.[1].game = {"name": "Go"} | .[1].game.name`, `["Go"]`},
+		{`del(.[0]) | .[0].id`, `["2"]`},
+		{`.[] | .name = .user_login | .name`, `["a","b","c"]`},
+	}
+
+	for _, test := range tests {
+		f, err := Compile(test.filter)
+		a.Nil(err, test.filter)
+
+		results, err := f(data)
+		a.Nil(err, test.filter)
+
+		b, _ := json.Marshal(results)
+		if test.filter == "." {
+			b, _ = json.Marshal(results[0])
+		}
+		a.Equal(test.expected, string(b), test.filter)
+	}
+
+	for _, invalid := range []string{`.[`, `select(.id`, `.id ==`, `.id |= 1`, `.[] = 1`, `length = 1`, `"unterminated`, `potato`, `.[] )`, `{"a":`} {
+		_, err := Compile(invalid)
+		a.NotNil(err, invalid)
+	}
+
+	f, err := Compile(`.id`)
+	a.Nil(err)
+	_, err = f(data)
+	a.NotNil(err)
+}
+
+func TestFilterOrdered(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	payload := `{"event":{"bits":100,"message":"Cheer100","fragments":[{"text":"a"}]}}`
+	root, err := Decode([]byte(payload))
+	a.Nil(err)
+
+	f, err := Compile(`select(.event.bits == 100) | .event.bits += 50 | .event.message = "a | b" | del(.event.fragments[0]) | .event.copy = .event.message`)
+	a.Nil(err)
+	results, err := f(root)
+	a.Nil(err)
+	a.Len(results, 1)
+
+	// keys keep their order and integers stay integers
+	b, _ := json.Marshal(results[0])
+	a.Equal(`{"event":{"bits":150,"message":"a | b","fragments":[],"copy":"a | b"}}`, string(b))
+
+	// the input isn't changed
+	b, _ = json.Marshal(root)
+	a.Equal(payload, string(b))
+
+	f, err = Compile(`.event.reward.title = "new" | .event.fragments[1] = {"text":"b"} | .event.bits -= 1.5`)
+	a.Nil(err)
+	results, err = f(root)
+	a.Nil(err)
+	b, _ = json.Marshal(results[0])
+	a.Equal(`{"event":{"bits":98.5,"message":"Cheer100","fragments":[{"text":"a"},{"text":"b"}],"reward":{"title":"new"}}}`, string(b))
+
+	for _, invalid := range []string{`.event.message -= "x"`, `.event.fragments[5] = 1`, `.event.bits.value = 1`} {
+		f, err = Compile(invalid)
+		a.Nil(err, invalid)
+		_, err = f(root)
+		a.NotNil(err, invalid)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	for _, expr := range []string{`.[]`, `.[] | select(.viewer_count > 100)`, `.[].user_login`, `.[].tags[0] | length`, `.[] | .a or length > 1`} {
+		a.True(Streams(expr), expr)
+	}
+	for _, expr := range []string{`.`, `length`, `.[0]`, `keys`, `select(.a)`, `.[] == 1 or length > 2`, `.[] and true`, `.[].a = 1`, `.[`} {
+		a.False(Streams(expr), expr)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a location within a value. Each element is either an object key (string) or an array index (int).
+type Path []any
+
+// ParsePath reads paths such as event.reward.title, .event.fragments[0].text or event["key.with.dots"]. The leading dot is optional.
+func ParsePath(s string) (Path, error) {
+	s = strings.TrimSpace(s)
+	original := s
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, fmt.Errorf("empty path %q", original)
+	}
+
+	p := Path{}
+	expectKey := true
+	for len(s) > 0 {
+		switch {
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("missing ] in path %q", original)
+			}
+			inner := s[1:end]
+			if strings.HasPrefix(inner, `"`) {
+				var key string
+				if err := json.Unmarshal([]byte(inner), &key); err != nil {
+					return nil, fmt.Errorf("invalid key %v in path %q", inner, original)
+				}
+				p = append(p, key)
+			} else {
+				i, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid array index %v in path %q", inner, original)
+				}
+				p = append(p, i)
+			}
+			s = s[end+1:]
+			expectKey = false
+
+		case s[0] == '.':
+			if expectKey {
+				return nil, fmt.Errorf("empty key in path %q", original)
+			}
+			s = s[1:]
+			expectKey = true
+
+		default:
+			if !expectKey {
+				return nil, fmt.Errorf("expected . or [ in path %q", original)
+			}
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			p = append(p, s[:end])
+			s = s[end:]
+			expectKey = false
+		}
+	}
+	if expectKey {
+		return nil, fmt.Errorf("path %q can't end with .", original)
+	}
+
+	return p, nil
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	for _, segment := range p {
+		switch s := segment.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%v]", s)
+		case string:
+			b.WriteString(".")
+			b.WriteString(s)
+		}
+	}
+	if b.Len() == 0 {
+		return "."
+	}
+	return b.String()
+}
+
+// Get returns the value at p, or nil if any part of it doesn't exist. Negative indexes count from the end of an array.
+func (p Path) Get(root any) any {
+	current := root
+	for _, segment := range p {
+		switch s := segment.(type) {
+		case string:
+			current = getKey(current, s)
+		case int:
+			a, ok := current.([]any)
+			if !ok {
+				return nil
+			}
+			if s < 0 {
+				s += len(a)
+			}
+			if s < 0 || s >= len(a) {
+				return nil
+			}
+			current = a[s]
+		}
+	}
+	return current
+}
+
+// Set stores value at p and returns the new root. Missing objects along the way are created, and an index one past the
+// end of an array appends to it. Objects and arrays along the path are changed in place.
+func (p Path) Set(root any, value any) (any, error) {
+	_, isMap := root.(map[string]any)
+	return p.set(root, value, !isMap)
+}
+
+// set creates missing objects as Objects when ordered, or otherwise as maps, following the kind of object they're added to
+func (p Path) set(root any, value any, ordered bool) (any, error) {
+	if len(p) == 0 {
+		return value, nil
+	}
+
+	switch s := p[0].(type) {
+	case string:
+		if root == nil {
+			if ordered {
+				root = NewObject()
+			} else {
+				root = map[string]any{}
+			}
+		}
+		switch o := root.(type) {
+		case *Object:
+			child, _ := o.Get(s)
+			v, err := p[1:].set(child, value, true)
+			if err != nil {
+				return nil, err
+			}
+			o.Set(s, v)
+			return o, nil
+		case map[string]any:
+			v, err := p[1:].set(o[s], value, false)
+			if err != nil {
+				return nil, err
+			}
+			o[s] = v
+			return o, nil
+		}
+		return nil, fmt.Errorf("can't set key %q on a non-object value", s)
+
+	case int:
+		if root == nil {
+			root = []any{}
+		}
+		a, ok := root.([]any)
+		if !ok {
+			return nil, fmt.Errorf("can't set index %v on a non-array value", s)
+		}
+		if s < 0 {
+			s += len(a)
+		}
+		if s < 0 || s > len(a) {
+			return nil, fmt.Errorf("index %v is out of range for an array of length %v", p[0], len(a))
+		}
+		var child any
+		if s < len(a) {
+			child = a[s]
+		}
+		v, err := p[1:].set(child, value, ordered)
+		if err != nil {
+			return nil, err
+		}
+		if s == len(a) {
+			a = append(a, v)
+		} else {
+			a[s] = v
+		}
+		return a, nil
+	}
+
+	return root, nil
+}
+
+// Delete removes the value at p and returns the new root. Deleting something that doesn't exist does nothing.
+func (p Path) Delete(root any) any {
+	if len(p) == 0 {
+		return nil
+	}
+
+	switch s := p[0].(type) {
+	case string:
+		switch o := root.(type) {
+		case *Object:
+			if len(p) == 1 {
+				o.Delete(s)
+			} else if child, ok := o.Get(s); ok {
+				o.Set(s, p[1:].Delete(child))
+			}
+		case map[string]any:
+			if len(p) == 1 {
+				delete(o, s)
+			} else if child, ok := o[s]; ok {
+				o[s] = p[1:].Delete(child)
+			}
+		}
+	case int:
+		a, ok := root.([]any)
+		if !ok {
+			return root
+		}
+		if s < 0 {
+			s += len(a)
+		}
+		if s < 0 || s >= len(a) {
+			return root
+		}
+		if len(p) == 1 {
+			return append(a[:s], a[s+1:]...)
+		}
+		a[s] = p[1:].Delete(a[s])
+	}
+
+	return root
+}
@@ -0,0 +1,283 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package jq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Object is a JSON object that remembers the order of its keys, so changed payloads keep the same layout as the
+// originals. Filters accept both Objects and the map[string]any of encoding/json.
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewObject returns an empty Object.
+func NewObject() *Object {
+	return &Object{values: map[string]any{}}
+}
+
+// Keys returns the keys of the object in order.
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// Get returns the value of key, and whether it's set.
+func (o *Object) Get(key string) (any, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// Set sets the value of key, adding it after the existing keys if it's new.
+func (o *Object) Set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Delete removes key from the object.
+func (o *Object) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Decode parses a single JSON value. Objects are decoded as *Object, and numbers as json.Number so they're written back unchanged.
+func Decode(b []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	v, err := decodeValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func decodeValue(decoder *json.Decoder) (any, error) {
+	t, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		o := NewObject()
+		for decoder.More() {
+			k, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			o.Set(k.(string), v)
+		}
+		_, err = decoder.Token() // closing }
+		return o, err
+
+	case json.Delim('['):
+		a := []any{}
+		for decoder.More() {
+			v, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err = decoder.Token() // closing ]
+		return a, err
+	}
+
+	return t, nil
+}
+
+// Copy deep copies objects and arrays, so a value assigned in several places isn't shared between them.
+func Copy(v any) any {
+	switch value := v.(type) {
+	case *Object:
+		o := NewObject()
+		for _, k := range value.keys {
+			o.Set(k, Copy(value.values[k]))
+		}
+		return o
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for k, item := range value {
+			m[k] = Copy(item)
+		}
+		return m
+	case []any:
+		a := make([]any, len(value))
+		for i, item := range value {
+			a[i] = Copy(item)
+		}
+		return a
+	}
+	return v
+}
+
+// Truthy returns whether v counts as true in a condition. Like jq, everything but false and null does.
+func Truthy(v any) bool {
+	return v != nil && v != false
+}
+
+// Equal compares two values, treating Objects like maps and json.Numbers like float64s.
+func Equal(a, b any) bool {
+	return reflect.DeepEqual(plain(a), plain(b))
+}
+
+// plain converts Objects to maps and json.Numbers to float64s, as encoding/json decodes them by default
+func plain(v any) any {
+	switch value := v.(type) {
+	case *Object:
+		m := make(map[string]any, len(value.keys))
+		for _, k := range value.keys {
+			m[k] = plain(value.values[k])
+		}
+		return m
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for k, item := range value {
+			m[k] = plain(item)
+		}
+		return m
+	case []any:
+		a := make([]any, len(value))
+		for i, item := range value {
+			a[i] = plain(item)
+		}
+		return a
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return value
+		}
+		return f
+	}
+	return v
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func isObject(v any) bool {
+	switch v.(type) {
+	case *Object, map[string]any:
+		return true
+	}
+	return false
+}
+
+func getKey(v any, key string) any {
+	switch o := v.(type) {
+	case *Object:
+		value, _ := o.Get(key)
+		return value
+	case map[string]any:
+		return o[key]
+	}
+	return nil
+}
+
+// keys returns the keys of an object sorted by name, as jq's keys does
+func keys(v any) []string {
+	var keys []string
+	switch o := v.(type) {
+	case *Object:
+		keys = append(keys, o.keys...)
+	case map[string]any:
+		for k := range o {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// values returns the values of an object, in order for an Object and by key for a map
+func values(v any) []any {
+	out := []any{}
+	switch o := v.(type) {
+	case *Object:
+		for _, k := range o.keys {
+			out = append(out, o.values[k])
+		}
+	case map[string]any:
+		for _, k := range keys(o) {
+			out = append(out, o[k])
+		}
+	}
+	return out
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, int, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case *Object, map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
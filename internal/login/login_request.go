@@ -6,7 +6,6 @@ import (
 	"bytes"
 	"io"
 	"mime/multipart"
-	"net/http"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/request"
@@ -37,9 +36,7 @@ func loginRequestWithHeaders(method string, url string, payload io.Reader, heade
 		req.Header.Add(header.Key, header.Value)
 	}
 
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
+	client := request.NewClient(time.Second * 10)
 	resp, err := client.Do(req)
 	if err != nil {
 		return loginRequestResponse{}, err
@@ -97,9 +94,7 @@ func sendMultipartPostRequest(url string, formData map[string]string) (loginRequ
 	// Add Content-Type header, generated with the boundary associated with the form
 	req.Header.Add("Content-Type", mp.FormDataContentType())
 
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
+	client := request.NewClient(time.Second * 10)
 	resp, err := client.Do(req)
 	if err != nil {
 		return loginRequestResponse{}, err
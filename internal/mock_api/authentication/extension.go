@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/extension"
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+// ExtensionAuthentication is passed to endpoints authorized with an Extension JWT, under the "extension_auth" context key.
+type ExtensionAuthentication struct {
+	ClientID string
+	Claims   extension.Claims
+}
+
+// ExtensionJWTMiddleware authorizes requests with a JWT signed with the secret of the Extension in the Client-Id header,
+// as Twitch does for the Extension endpoints, instead of an OAuth token.
+func ExtensionJWTMiddleware(next mock_api.MockEndpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		db := r.Context().Value("db").(database.CLIDatabase)
+
+		if next.ValidMethod(r.Method) == false {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		clientID := r.Header.Get("Client-ID")
+		bearerToken := r.Header.Get("Authorization")
+		if clientID == "" || len(bearerToken) < 7 || strings.ToLower(bearerToken[:6]) != "bearer" {
+			mock_errors.WriteUnauthorized(w, "Missing Client ID or JWT")
+			return
+		}
+
+		dbr, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: clientID})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		clients := dbr.Data.([]database.AuthenticationClient)
+		if len(clients) == 0 || !clients[0].IsExtension {
+			mock_errors.WriteUnauthorized(w, "Client ID is not an Extension")
+			return
+		}
+
+		claims, err := extension.Verify(bearerToken[7:], clients[0].Secret)
+		if err != nil {
+			mock_errors.WriteUnauthorized(w, err.Error())
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), "extension_auth", ExtensionAuthentication{ClientID: clientID, Claims: claims}))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -37,7 +37,9 @@ func (e Endpoint) ValidMethod(method string) bool {
 }
 
 func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
+	// Read the database from the request rather than storing it in the package, since each server has its own
+	db := r.Context().Value("db").(database.CLIDatabase)
+	_ = db
 
 	w.WriteHeader(200)
 }
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -85,8 +84,6 @@ func (e Cheermotes) ValidMethod(method string) bool {
 }
 
 func (e Cheermotes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getCheermotes(w, r)
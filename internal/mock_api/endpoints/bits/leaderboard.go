@@ -62,8 +62,6 @@ func (e BitsLeaderboard) ValidMethod(method string) bool {
 }
 
 func (e BitsLeaderboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getBitsLeaderboard(w, r)
@@ -74,6 +72,7 @@ func (e BitsLeaderboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getBitsLeaderboard(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	period := r.URL.Query().Get("period")
 	startedAt := r.URL.Query().Get("started_at")
 	userID := r.URL.Query().Get("user_id")
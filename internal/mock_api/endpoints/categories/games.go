@@ -42,8 +42,6 @@ func (e Games) ValidMethod(method string) bool {
 }
 
 func (e Games) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getGames(w, r)
@@ -54,6 +52,7 @@ func (e Games) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getGames(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	games := []database.Category{}
 	ids := r.URL.Query()["id"]
 	names := r.URL.Query()["name"]
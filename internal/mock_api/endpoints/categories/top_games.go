@@ -42,8 +42,6 @@ func (e TopGames) ValidMethod(method string) bool {
 }
 
 func (e TopGames) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getTopGames(w, r)
@@ -54,6 +52,7 @@ func (e TopGames) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getTopGames(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	dbr, err := db.NewQuery(r, 100).GetTopGames()
 	if err != nil {
 		mock_errors.WriteServerError(w, "error fetching entitlements")
@@ -46,8 +46,6 @@ func (e Redemption) ValidMethod(method string) bool {
 }
 
 func (e Redemption) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getRedemptions(w, r)
@@ -61,6 +59,7 @@ func (e Redemption) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getRedemptions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	reward_id := r.URL.Query().Get("reward_id")
 
@@ -124,6 +123,7 @@ func getRedemptions(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchRedemptions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	reward_id := r.URL.Query().Get("reward_id")
 
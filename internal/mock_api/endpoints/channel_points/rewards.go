@@ -60,8 +60,6 @@ func (e Reward) ValidMethod(method string) bool {
 }
 
 func (e Reward) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getRewards(w, r)
@@ -81,6 +79,7 @@ func (e Reward) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getRewards(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	id := r.URL.Query().Get("id")
@@ -130,6 +129,7 @@ func getRewards(w http.ResponseWriter, r *http.Request) {
 }
 
 func postRewards(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	if !userCtx.MatchesBroadcasterIDParam(r) {
@@ -237,6 +237,7 @@ func postRewards(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchRewards(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	id := r.URL.Query().Get("id")
 
@@ -348,6 +349,7 @@ func patchRewards(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteRewards(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	id := r.URL.Query().Get("id")
 
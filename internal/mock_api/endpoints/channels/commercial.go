@@ -57,8 +57,6 @@ func (e CommercialEndpoint) ValidMethod(method string) bool {
 }
 
 func (e CommercialEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postCommercial(w, r)
@@ -70,6 +68,7 @@ func (e CommercialEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postCommercial(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	var body CommercialEndpointRequest
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
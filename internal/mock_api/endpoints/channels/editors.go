@@ -41,7 +41,6 @@ func (e Editors) ValidMethod(method string) bool {
 }
 
 func (e Editors) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
 	switch r.Method {
 	case http.MethodGet:
 		getEditors(w, r)
@@ -54,6 +53,7 @@ func (e Editors) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getEditors(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
@@ -48,8 +48,6 @@ func (e FollowedEndpoint) ValidMethod(method string) bool {
 }
 
 func (e FollowedEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getFollowed(w, r)
@@ -60,6 +58,7 @@ func (e FollowedEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getFollowed(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	user_id := r.URL.Query().Get("user_id")
 	broadcaster_id := r.URL.Query().Get("broadcaster_id")
 
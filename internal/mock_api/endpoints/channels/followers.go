@@ -48,8 +48,6 @@ func (e FollowersEndpoint) ValidMethod(method string) bool {
 }
 
 func (e FollowersEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getFollowers(w, r)
@@ -60,6 +58,7 @@ func (e FollowersEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getFollowers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	user_id := r.URL.Query().Get("user_id")
 	broadcaster_id := r.URL.Query().Get("broadcaster_id")
 
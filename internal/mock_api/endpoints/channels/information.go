@@ -74,8 +74,6 @@ func (e InformationEndpoint) ValidMethod(method string) bool {
 }
 
 func (e InformationEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getInformation(w, r)
@@ -90,6 +88,7 @@ func (e InformationEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getInformation(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 
 	if broadcasterID == "" {
@@ -114,6 +113,7 @@ func getInformation(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchInformation(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
@@ -47,8 +47,6 @@ func (e Vips) ValidMethod(method string) bool {
 }
 
 func (e Vips) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getVIPs(w, r)
@@ -65,6 +63,7 @@ func (e Vips) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getVIPs(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	if broadcasterID == "" {
 		mock_errors.WriteBadRequest(w, "Missing required parameter broadcaster_id")
@@ -113,6 +112,7 @@ func getVIPs(w http.ResponseWriter, r *http.Request) {
 }
 
 func postVIPs(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "broadcaster_id does not match token")
@@ -186,6 +186,7 @@ func postVIPs(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteVIPs(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "broadcaster_id does not match token")
@@ -57,7 +57,6 @@ func (e CharityCampaign) ValidMethod(method string) bool {
 }
 
 func (e CharityCampaign) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
 	switch r.Method {
 	case http.MethodGet:
 		getCharityCampaign(w, r)
@@ -68,6 +67,7 @@ func (e CharityCampaign) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getCharityCampaign(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Broadcaster ID does not match token.")
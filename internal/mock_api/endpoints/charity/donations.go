@@ -53,7 +53,6 @@ func (e CharityDonations) ValidMethod(method string) bool {
 }
 
 func (e CharityDonations) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
 	switch r.Method {
 	case http.MethodGet:
 		getCharityDonations(w, r)
@@ -64,6 +63,7 @@ func (e CharityDonations) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getCharityDonations(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	first := 20
 	if !userCtx.MatchesBroadcasterIDParam(r) {
@@ -2,10 +2,6 @@
 // SPDX-License-Identifier: Apache-2.0
 package charity
 
-import "github.com/twitchdev/twitch-cli/internal/database"
-
-var db database.CLIDatabase
-
 type CharityAmount struct {
 	Value         int    `json:"value"`
 	DecimalPlaces int    `json:"decimal_places"`
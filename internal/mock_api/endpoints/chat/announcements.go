@@ -6,10 +6,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
 var announcementsMethodsSupported = map[string]bool{
@@ -46,8 +50,6 @@ func (e Announcements) ValidMethod(method string) bool {
 }
 
 func (e Announcements) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postAnnouncements(w, r)
@@ -58,6 +60,7 @@ func (e Announcements) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postAnnouncements(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -125,7 +128,39 @@ func postAnnouncements(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// No connection to chat on here, and no way to GET or PATCH announcements via API
-	// For the time being, we just ingest it and pretend it worked (HTTP 204)
+	moderator, err := db.NewQuery(r, 100).GetUser(database.User{ID: moderatorID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching moderator")
+		return
+	}
+
+	message := database.ChatMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: broadcaster.ID,
+		UserID:        moderator.ID,
+		UserLogin:     moderator.UserLogin,
+		UserName:      moderator.DisplayName,
+		Text:          body.Message,
+		MessageType:   "announcement",
+		CreatedAt:     util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err = db.NewQuery(r, 100).InsertChatMessage(message)
+	if err != nil {
+		mock_errors.WriteServerError(w, "error inserting announcement")
+		return
+	}
+
+	mock_events.Emit(r, trigger.TriggerParameters{
+		Event:        "channel.chat.notification",
+		FromUser:     moderator.ID,
+		FromUserName: moderator.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		ItemID:       message.ID,
+		MessageText:  message.Text,
+		NoticeType:   "announcement",
+		Color:        colorLowerCase,
+	})
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/twitchdev/twitch-cli/test_setup"
@@ -122,6 +123,67 @@ func TestAnnouncements(t *testing.T) {
 	a.Equal(204, resp.StatusCode)
 }
 
+func TestMessages(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(Messages{})
+
+	// post
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer([]byte("{}")))
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	body := PostMessagesRequestBody{
+		BroadcasterID: "1",
+		SenderID:      "2",
+		Message:       "Hello chat!",
+	}
+	b, _ := json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	body.SenderID = "1"
+	b, _ = json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var response struct {
+		Data []PostMessagesResponse `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	a.Nil(err)
+	a.Len(response.Data, 1)
+	a.True(response.Data[0].IsSent)
+	a.NotEmpty(response.Data[0].MessageID)
+
+	// the limit is 500 characters, not bytes
+	body.Message = strings.Repeat("é", 500)
+	b, _ = json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	body.Message = strings.Repeat("é", 501)
+	b, _ = json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(422, resp.StatusCode)
+
+	body.Message = "Hello chat!"
+	body.ReplyParentMessageID = "not-a-real-message"
+	b, _ = json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+Messages{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+}
+
 func TestChatters(t *testing.T) {
 	a := test_setup.SetupTestEnv(t)
 	ts := test_server.SetupTestServer(Chatters{})
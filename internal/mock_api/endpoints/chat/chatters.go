@@ -47,8 +47,6 @@ func (e Chatters) ValidMethod(method string) bool {
 }
 
 func (e Chatters) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getChatters(w, r)
@@ -59,6 +57,7 @@ func (e Chatters) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getChatters(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
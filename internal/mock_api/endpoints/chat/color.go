@@ -51,8 +51,6 @@ func (e Color) ValidMethod(method string) bool {
 }
 
 func (e Color) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getColor(w, r)
@@ -84,6 +82,7 @@ var validNamedColorsLower = map[string]string{
 }
 
 func getColor(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	q := r.URL.Query()
 	userIDs := q["user_id"]
 	results := []GetColorRequestBody{}
@@ -137,6 +136,7 @@ func getColor(w http.ResponseWriter, r *http.Request) {
 }
 
 func putColor(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesUserIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "User ID does not match token.")
@@ -0,0 +1,304 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	chat_event "github.com/twitchdev/twitch-cli/internal/events/types/chat"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var messagesMethodsSupported = map[string]bool{
+	http.MethodGet:    false,
+	http.MethodPost:   true,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var messagesScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {"user:write:chat"},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+type PostMessagesRequestBody struct {
+	BroadcasterID        string `json:"broadcaster_id"`
+	SenderID             string `json:"sender_id"`
+	Message              string `json:"message"`
+	ReplyParentMessageID string `json:"reply_parent_message_id"`
+}
+
+type PostMessagesResponse struct {
+	MessageID  string              `json:"message_id"`
+	IsSent     bool                `json:"is_sent"`
+	DropReason *MessagesDropReason `json:"drop_reason"`
+}
+
+type MessagesDropReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Messages struct{}
+
+func (e Messages) Path() string { return "/chat/messages" }
+
+func (e Messages) GetRequiredScopes(method string) []string {
+	return messagesScopesByMethod[method]
+}
+
+func (e Messages) ValidMethod(method string) bool {
+	return messagesMethodsSupported[method]
+}
+
+func (e Messages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		postMessages(w, r)
+		break
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func postMessages(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
+
+	var body PostMessagesRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "Body unable to be parsed")
+		return
+	}
+
+	if body.BroadcasterID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field broadcaster_id")
+		return
+	}
+
+	if body.SenderID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field sender_id")
+		return
+	}
+
+	if body.SenderID != userCtx.UserID {
+		mock_errors.WriteUnauthorized(w, "The sender_id in the body does not match the user ID in the access token")
+		return
+	}
+
+	if body.Message == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field message")
+		return
+	}
+
+	if utf8.RuneCountInString(body.Message) > 500 {
+		mock_errors.WriteUnprocessableEntity(w, "The message is too large")
+		return
+	}
+
+	broadcaster, err := db.NewQuery(r, 100).GetUser(database.User{ID: body.BroadcasterID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching broadcaster")
+		return
+	}
+	if broadcaster.ID == "" {
+		mock_errors.WriteBadRequest(w, "The broadcaster specified in broadcaster_id doesn't exist")
+		return
+	}
+
+	sender, err := db.NewQuery(r, 100).GetUser(database.User{ID: body.SenderID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching sender")
+		return
+	}
+	if sender.ID == "" {
+		mock_errors.WriteBadRequest(w, "The user specified in sender_id doesn't exist")
+		return
+	}
+
+	var replyParentMessageID *string
+	if body.ReplyParentMessageID != "" {
+		dbr, err := db.NewQuery(r, 100).GetChatMessages(database.ChatMessage{ID: body.ReplyParentMessageID, BroadcasterID: body.BroadcasterID})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		if len(dbr.Data.([]database.ChatMessage)) == 0 {
+			mock_errors.WriteBadRequest(w, "The message specified in reply_parent_message_id doesn't exist")
+			return
+		}
+		replyParentMessageID = &body.ReplyParentMessageID
+	}
+
+	dropReason, err := MessageDropReason(db, body.BroadcasterID, body.SenderID, body.Message)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	if dropReason != nil {
+		bytes, _ := json.Marshal(models.APIResponse{
+			Data: []PostMessagesResponse{
+				{
+					MessageID:  "",
+					IsSent:     false,
+					DropReason: dropReason,
+				},
+			},
+		})
+		w.Write(bytes)
+		return
+	}
+
+	message := database.ChatMessage{
+		ID:                   util.RandomGUID(),
+		BroadcasterID:        broadcaster.ID,
+		UserID:               sender.ID,
+		UserLogin:            sender.UserLogin,
+		UserName:             sender.DisplayName,
+		Text:                 body.Message,
+		MessageType:          "text",
+		ReplyParentMessageID: replyParentMessageID,
+		CreatedAt:            util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err = db.NewQuery(r, 100).InsertChatMessage(message)
+	if err != nil {
+		mock_errors.WriteServerError(w, "error inserting chat message")
+		return
+	}
+
+	mock_events.Emit(r, trigger.TriggerParameters{
+		Event:        "channel.chat.message",
+		FromUser:     sender.ID,
+		FromUserName: sender.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		ItemID:       message.ID,
+		MessageText:  message.Text,
+		Color:        sender.ChatColor,
+	})
+
+	bytes, _ := json.Marshal(models.APIResponse{
+		Data: []PostMessagesResponse{
+			{
+				MessageID: message.ID,
+				IsSent:    true,
+			},
+		},
+	})
+	w.Write(bytes)
+}
+
+// MessageDropReason checks a message against the broadcaster's bans and chat settings. The broadcaster and moderators are exempt from chat settings.
+// This is shared with the mock IRC server, which drops messages for the same reasons.
+func MessageDropReason(cliDB database.CLIDatabase, broadcasterID string, senderID string, message string) (*MessagesDropReason, error) {
+	if senderID == broadcasterID {
+		return nil, nil
+	}
+
+	dbr, err := cliDB.NewQuery(nil, 100).GetBans(database.UserRequestParams{BroadcasterID: broadcasterID, UserID: senderID})
+	if err != nil {
+		return nil, err
+	}
+	if len(dbr.Data.([]database.Ban)) != 0 {
+		return &MessagesDropReason{Code: "msg_banned", Message: "You are permanently banned from talking in this channel."}, nil
+	}
+
+	dbr, err = cliDB.NewQuery(nil, 1000).GetModeratorsForBroadcaster(broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mod := range dbr.Data.([]database.Moderator) {
+		if mod.UserID == senderID {
+			return nil, nil
+		}
+	}
+
+	dbr, err = cliDB.NewQuery(nil, 100).GetChatSettingsByBroadcaster(broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	allSettings := dbr.Data.([]database.ChatSettings)
+	if len(allSettings) == 0 {
+		// No settings stored for this broadcaster, so there's nothing to enforce
+		return nil, nil
+	}
+	settings := allSettings[0]
+
+	if settings.EmoteMode != nil && *settings.EmoteMode && !chat_event.IsEmoteOnly(message) {
+		return &MessagesDropReason{Code: "msg_emoteonly", Message: "This room is in emote-only mode."}, nil
+	}
+
+	if settings.SubscriberMode != nil && *settings.SubscriberMode {
+		dbr, err := cliDB.NewQuery(nil, 100).GetSubscriptions(database.Subscription{BroadcasterID: broadcasterID, UserID: senderID})
+		if err != nil {
+			return nil, err
+		}
+		if len(dbr.Data.([]database.Subscription)) == 0 {
+			return &MessagesDropReason{Code: "msg_subsonly", Message: "This room is in subscribers-only mode."}, nil
+		}
+	}
+
+	if settings.FollowerMode != nil && *settings.FollowerMode {
+		dbr, err := cliDB.NewQuery(nil, 100).GetFollows(database.UserRequestParams{BroadcasterID: broadcasterID, UserID: senderID}, false)
+		if err != nil {
+			return nil, err
+		}
+		follows := dbr.Data.([]database.Follow)
+		if len(follows) == 0 {
+			return &MessagesDropReason{Code: "msg_followersonly", Message: "This room is in followers-only mode. Follow this channel to join the chat!"}, nil
+		}
+
+		followedAt, err := time.Parse(time.RFC3339, follows[0].FollowedAt)
+		if err == nil && settings.FollowerModeDuration != nil {
+			requiredDuration := time.Duration(*settings.FollowerModeDuration) * time.Minute
+			if util.GetTimestamp().Sub(followedAt) < requiredDuration {
+				return &MessagesDropReason{
+					Code:    "msg_followersonly",
+					Message: fmt.Sprintf("This room is in %v minute followers-only mode. Follow this channel to join the chat!", *settings.FollowerModeDuration),
+				}, nil
+			}
+		}
+	}
+
+	dbr, err = cliDB.NewQuery(nil, 100).GetChatMessages(database.ChatMessage{BroadcasterID: broadcasterID, UserID: senderID})
+	if err != nil {
+		return nil, err
+	}
+	previousMessages := dbr.Data.([]database.ChatMessage)
+	if len(previousMessages) == 0 {
+		return nil, nil
+	}
+	lastMessage := previousMessages[0]
+	lastSentAt, err := time.Parse(time.RFC3339Nano, lastMessage.CreatedAt)
+	if err != nil {
+		return nil, nil
+	}
+
+	if settings.SlowMode != nil && *settings.SlowMode && settings.SlowModeWaitTime != nil {
+		if util.GetTimestamp().Sub(lastSentAt) < time.Duration(*settings.SlowModeWaitTime)*time.Second {
+			return &MessagesDropReason{Code: "msg_slowmode", Message: "This room is in slow mode and you are sending messages too quickly."}, nil
+		}
+	}
+
+	if settings.UniqueChatMode != nil && *settings.UniqueChatMode && lastMessage.Text == message {
+		return &MessagesDropReason{Code: "msg_duplicate", Message: "Your message was not sent because it is identical to the previous one you sent."}, nil
+	}
+
+	return nil, nil
+}
@@ -81,8 +81,6 @@ func (e Settings) ValidMethod(method string) bool {
 }
 
 func (e Settings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getSettings(w, r)
@@ -96,6 +94,7 @@ func (e Settings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getSettings(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	if broadcasterID == "" {
 		mock_errors.WriteBadRequest(w, "Missing required parameter broadcaster_id")
@@ -156,6 +155,7 @@ func getSettings(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchSettings(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -279,6 +279,7 @@ func patchSettings(w http.ResponseWriter, r *http.Request) {
 }
 
 func validateModerator(w http.ResponseWriter, r *http.Request, moderatorId string, broadcasterId string) bool {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	// Check if Moderator ID matches user access token
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
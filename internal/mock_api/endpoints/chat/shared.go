@@ -2,9 +2,6 @@
 // SPDX-License-Identifier: Apache-2.0
 package chat
 
-import "github.com/twitchdev/twitch-cli/internal/database"
-
-var db database.CLIDatabase
 var defaultEmoteTypes = []string{"subscription", "bitstier", "follower"}
 
 const templateEmoteURL = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
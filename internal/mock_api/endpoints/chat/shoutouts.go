@@ -50,8 +50,6 @@ func (e Shoutouts) ValidMethod(method string) bool {
 }
 
 func (e Shoutouts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postShoutouts(w, r)
@@ -62,6 +60,7 @@ func (e Shoutouts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postShoutouts(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
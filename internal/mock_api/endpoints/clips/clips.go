@@ -51,7 +51,6 @@ func (e Clips) ValidMethod(method string) bool {
 }
 
 func (e Clips) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
 	switch r.Method {
 	case http.MethodGet:
 		getClips(w, r)
@@ -65,6 +64,7 @@ func (e Clips) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getClips(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	gameID := r.URL.Query().Get("game_id")
 	id := r.URL.Query().Get("id")
@@ -132,6 +132,7 @@ func getClips(w http.ResponseWriter, r *http.Request) {
 }
 
 func postClips(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	// has_delay has no effect in the mock API
@@ -53,8 +53,6 @@ func (e DropsEntitlements) ValidMethod(method string) bool {
 }
 
 func (e DropsEntitlements) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getEntitlements(w, r)
@@ -66,6 +64,7 @@ func (e DropsEntitlements) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getEntitlements(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	id := r.URL.Query().Get("id")
 	userID := r.URL.Query().Get("user_id")
@@ -103,6 +102,7 @@ func getEntitlements(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchEntitlements(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	var body PatchEntitlementsBody
@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/chat"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/clips"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/drops"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/extensions"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/goals"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/hype_train"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/moderation"
@@ -54,10 +55,16 @@ func All() []mock_api.MockEndpoint {
 		chat.EmoteSets{},
 		chat.GlobalBadges{},
 		chat.GlobalEmotes{},
+		chat.Messages{},
 		chat.Settings{},
 		chat.Shoutouts{},
 		clips.Clips{},
 		drops.DropsEntitlements{},
+		extensions.Chat{},
+		extensions.Configurations{},
+		extensions.Live{},
+		extensions.PubSub{},
+		extensions.RequiredConfiguration{},
 		goals.Goals{},
 		hype_train.HypeTrainEvents{},
 		moderation.AutomodHeld{},
@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/json"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+var chatMethodsSupported = map[string]bool{
+	http.MethodGet:    false,
+	http.MethodPost:   true,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var chatScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+type PostChatRequestBody struct {
+	Text             string `json:"text"`
+	ExtensionID      string `json:"extension_id"`
+	ExtensionVersion string `json:"extension_version"`
+}
+
+type Chat struct{}
+
+func (e Chat) Path() string { return "/extensions/chat" }
+
+func (e Chat) GetRequiredScopes(method string) []string {
+	return chatScopesByMethod[method]
+}
+
+func (e Chat) ValidMethod(method string) bool {
+	return chatMethodsSupported[method]
+}
+
+func (e Chat) RequiresExtensionJWT() bool { return true }
+
+func (e Chat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		postChat(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func postChat(w http.ResponseWriter, r *http.Request) {
+	auth, ok := externalAuth(w, r)
+	if !ok {
+		return
+	}
+	// Messages are sent on behalf of the Extension's owner
+	if auth.Claims.UserID == "" {
+		mock_errors.WriteUnauthorized(w, "JWT must include the user_id of the Extension's owner")
+		return
+	}
+
+	if r.URL.Query().Get("broadcaster_id") == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter broadcaster_id")
+		return
+	}
+
+	var body PostChatRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "Body unable to be parsed")
+		return
+	}
+
+	if !matchesClientID(w, auth, body.ExtensionID) {
+		return
+	}
+	if body.ExtensionVersion == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field extension_version")
+		return
+	}
+	if body.Text == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field text")
+		return
+	}
+	if utf8.RuneCountInString(body.Text) > 280 {
+		mock_errors.WriteBadRequest(w, "text must be 280 characters or less")
+		return
+	}
+
+	// The mock chat store only holds messages from users, so Extension messages aren't kept
+	w.WriteHeader(http.StatusNoContent)
+}
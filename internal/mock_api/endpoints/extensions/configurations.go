@@ -0,0 +1,160 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+var configurationsMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    true,
+}
+
+var configurationsScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+var validSegments = map[string]bool{
+	"broadcaster": true,
+	"developer":   true,
+	"global":      true,
+}
+
+type PutConfigurationRequestBody struct {
+	ExtensionID   string `json:"extension_id"`
+	Segment       string `json:"segment"`
+	BroadcasterID string `json:"broadcaster_id"`
+	Content       string `json:"content"`
+	Version       string `json:"version"`
+}
+
+type Configurations struct{}
+
+func (e Configurations) Path() string { return "/extensions/configurations" }
+
+func (e Configurations) GetRequiredScopes(method string) []string {
+	return configurationsScopesByMethod[method]
+}
+
+func (e Configurations) ValidMethod(method string) bool {
+	return configurationsMethodsSupported[method]
+}
+
+func (e Configurations) RequiresExtensionJWT() bool { return true }
+
+func (e Configurations) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		getConfigurations(w, r)
+	case http.MethodPut:
+		putConfiguration(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getConfigurations(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	auth, ok := externalAuth(w, r)
+	if !ok {
+		return
+	}
+	if !matchesClientID(w, auth, r.URL.Query().Get("extension_id")) {
+		return
+	}
+
+	segments := r.URL.Query()["segment"]
+	if len(segments) == 0 {
+		mock_errors.WriteBadRequest(w, "Missing required parameter segment")
+		return
+	}
+
+	broadcasterID := r.URL.Query().Get("broadcaster_id")
+	data := []database.ExtensionConfiguration{}
+	for _, segment := range segments {
+		if !validSegments[segment] {
+			mock_errors.WriteBadRequest(w, "segment must be one of broadcaster, developer, or global")
+			return
+		}
+		id := broadcasterID
+		if segment == "global" {
+			id = ""
+		} else if id == "" {
+			mock_errors.WriteBadRequest(w, "Missing required parameter broadcaster_id for the "+segment+" segment")
+			return
+		}
+
+		c, err := db.NewQuery(nil, 100).GetExtensionConfiguration(auth.ClientID, segment, id)
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		// Segments that were never set aren't returned
+		if c.Segment != "" {
+			data = append(data, c)
+		}
+	}
+
+	bytes, _ := json.Marshal(models.APIResponse{Data: data})
+	w.Write(bytes)
+}
+
+func putConfiguration(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	auth, ok := externalAuth(w, r)
+	if !ok {
+		return
+	}
+
+	var body PutConfigurationRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "Body unable to be parsed")
+		return
+	}
+
+	if !matchesClientID(w, auth, body.ExtensionID) {
+		return
+	}
+	if !validSegments[body.Segment] {
+		mock_errors.WriteBadRequest(w, "segment must be one of broadcaster, developer, or global")
+		return
+	}
+	if body.Segment == "global" {
+		body.BroadcasterID = ""
+	} else if body.BroadcasterID == "" {
+		mock_errors.WriteBadRequest(w, "broadcaster_id is required for the "+body.Segment+" segment")
+		return
+	}
+	if len(body.Content) > maxContentLength {
+		mock_errors.WriteBadRequest(w, "content must be 5 KB or less")
+		return
+	}
+
+	err = db.NewQuery(nil, 100).SetExtensionConfiguration(database.ExtensionConfiguration{
+		ExtensionID:   body.ExtensionID,
+		Segment:       body.Segment,
+		BroadcasterID: body.BroadcasterID,
+		Content:       body.Content,
+		Version:       body.Version,
+	})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,274 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/extension"
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+	"github.com/twitchdev/twitch-cli/test_setup/test_server"
+)
+
+// setupExtensionServer serves the endpoint behind the Extension JWT middleware, and the PubSub WebSocket alongside it
+func setupExtensionServer(next mock_api.MockEndpoint) *httptest.Server {
+	m := http.NewServeMux()
+	m.Handle(next.Path(), authentication.ExtensionJWTMiddleware(next))
+	m.Handle("/listen", PubSubSocket{})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		db, err := database.NewConnection(true)
+		if err != nil {
+			log.Fatalf("Error connecting to database: %v", err.Error())
+			return
+		}
+		defer db.DB.Close()
+
+		m.ServeHTTP(w, r.WithContext(context.WithValue(context.Background(), "db", db)))
+	}))
+}
+
+func createExtension(t *testing.T) database.AuthenticationClient {
+	db, err := database.NewConnection(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.DB.Close()
+
+	client, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{ID: util.RandomClientID(), Name: "test_extension", IsExtension: true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func signJWT(t *testing.T, client database.AuthenticationClient, role string, channelID string, userID string) string {
+	c, err := extension.NewClaims(role, channelID, userID, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := extension.Sign(c, client.Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func newRequest(method string, url string, client database.AuthenticationClient, token string, body any) *http.Request {
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(b))
+	req.Header.Set("Client-Id", client.ID)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestConfigurations(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := setupExtensionServer(Configurations{})
+	client := createExtension(t)
+	external := signJWT(t, client, extension.RoleExternal, "1", "")
+
+	// not signed, or not signed by the Extension's secret
+	req := newRequest(http.MethodGet, ts.URL+Configurations{}.Path(), client, "potato", nil)
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	other := createExtension(t)
+	req = newRequest(http.MethodGet, ts.URL+Configurations{}.Path(), other, external, nil)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	// frontends can't use these endpoints
+	req = newRequest(http.MethodPut, ts.URL+Configurations{}.Path(), client, signJWT(t, client, extension.RoleBroadcaster, "1", ""), nil)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	body := PutConfigurationRequestBody{ExtensionID: client.ID, Segment: "broadcaster", BroadcasterID: "1", Content: `{"theme":"dark"}`, Version: "1"}
+	req = newRequest(http.MethodPut, ts.URL+Configurations{}.Path(), client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	body.Segment = "developer"
+	body.BroadcasterID = ""
+	req = newRequest(http.MethodPut, ts.URL+Configurations{}.Path(), client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	body.ExtensionID = other.ID
+	req = newRequest(http.MethodPut, ts.URL+Configurations{}.Path(), client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	req = newRequest(http.MethodGet, ts.URL+Configurations{}.Path(), client, external, nil)
+	q := req.URL.Query()
+	q.Set("extension_id", client.ID)
+	q.Set("broadcaster_id", "1")
+	q["segment"] = []string{"broadcaster", "global"}
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var configurations struct {
+		Data []database.ExtensionConfiguration `json:"data"`
+	}
+	a.Nil(json.NewDecoder(resp.Body).Decode(&configurations))
+	a.Len(configurations.Data, 1)
+	a.Equal("broadcaster", configurations.Data[0].Segment)
+	a.Equal(`{"theme":"dark"}`, configurations.Data[0].Content)
+}
+
+func TestRequiredConfiguration(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := setupExtensionServer(RequiredConfiguration{})
+	client := createExtension(t)
+	external := signJWT(t, client, extension.RoleExternal, "1", "")
+
+	body := PutRequiredConfigurationRequestBody{ExtensionID: client.ID, ExtensionVersion: "0.0.1", RequiredConfiguration: "done"}
+	req := newRequest(http.MethodPut, ts.URL+RequiredConfiguration{}.Path(), client, external, body)
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	q := req.URL.Query()
+	q.Set("broadcaster_id", "1")
+	req = newRequest(http.MethodPut, ts.URL+RequiredConfiguration{}.Path()+"?"+q.Encode(), client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+}
+
+func TestChat(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := setupExtensionServer(Chat{})
+	client := createExtension(t)
+
+	body := PostChatRequestBody{Text: "Hello from the Extension!", ExtensionID: client.ID, ExtensionVersion: "0.0.1"}
+	url := ts.URL + Chat{}.Path() + "?broadcaster_id=1"
+
+	// messages are sent on behalf of the owner, so the JWT needs a user ID
+	req := newRequest(http.MethodPost, url, client, signJWT(t, client, extension.RoleExternal, "1", ""), body)
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	external := signJWT(t, client, extension.RoleExternal, "1", "2")
+	req = newRequest(http.MethodPost, url, client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	body.Text = strings.Repeat("a", 281)
+	req = newRequest(http.MethodPost, url, client, external, body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+}
+
+func TestLive(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(Live{})
+	client := createExtension(t)
+
+	resp, err := http.Get(ts.URL + Live{}.Path())
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	resp, err = http.Get(ts.URL + Live{}.Path() + "?extension_id=" + client.ID)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var live liveResponse
+	a.Nil(json.NewDecoder(resp.Body).Decode(&live))
+	a.NotNil(live.Data)
+}
+
+func TestPubSub(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := setupExtensionServer(PubSub{})
+	client := createExtension(t)
+
+	listen := func(token string) *websocket.Conn {
+		u := "ws" + strings.TrimPrefix(ts.URL, "http") + "/listen?" + url.Values{"extension_id": {client.ID}, "jwt": {token}}.Encode()
+		conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+		a.Nil(err)
+		return conn
+	}
+	viewer := listen(signJWT(t, client, extension.RoleViewer, "1", "3"))
+	defer viewer.Close()
+	elsewhere := listen(signJWT(t, client, extension.RoleViewer, "2", "3"))
+	defer elsewhere.Close()
+
+	// subscribing needs a valid JWT
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/listen?extension_id="+client.ID+"&jwt=potato", nil)
+	a.NotNil(err)
+	a.Equal(401, resp.StatusCode)
+
+	// viewers can't send, and backends can only send to the channel they signed for
+	body := PostPubSubRequestBody{Target: []string{"broadcast"}, BroadcasterID: "1", Message: `{"hello":"world"}`}
+	req := newRequest(http.MethodPost, ts.URL+PubSub{}.Path(), client, signJWT(t, client, extension.RoleViewer, "1", "3"), body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	req = newRequest(http.MethodPost, ts.URL+PubSub{}.Path(), client, signJWT(t, client, extension.RoleExternal, "2", ""), body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	req = newRequest(http.MethodPost, ts.URL+PubSub{}.Path(), client, signJWT(t, client, extension.RoleExternal, "1", ""), body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	var m PubSubMessage
+	viewer.SetReadDeadline(time.Now().Add(time.Second))
+	a.Nil(viewer.ReadJSON(&m))
+	a.Equal("broadcast", m.Target)
+	a.Equal(`{"hello":"world"}`, m.Message)
+
+	// global broadcasts reach every channel
+	body = PostPubSubRequestBody{Target: []string{"global"}, IsGlobalBroadcast: true, Message: "everyone"}
+	req = newRequest(http.MethodPost, ts.URL+PubSub{}.Path(), client, signJWT(t, client, extension.RoleExternal, "", ""), body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	elsewhere.SetReadDeadline(time.Now().Add(time.Second))
+	a.Nil(elsewhere.ReadJSON(&m))
+	a.Equal("global", m.Target)
+	a.Equal("everyone", m.Message)
+
+	// the broadcast to channel 1 never reached channel 2
+	elsewhere.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	a.NotNil(elsewhere.ReadJSON(&m))
+
+	var errorBody models.APIResponse
+	body = PostPubSubRequestBody{Target: []string{"potato"}, BroadcasterID: "1", Message: "hi"}
+	req = newRequest(http.MethodPost, ts.URL+PubSub{}.Path(), client, signJWT(t, client, extension.RoleExternal, "1", ""), body)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+	a.Nil(json.NewDecoder(resp.Body).Decode(&errorBody))
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+var liveMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var liveScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+type LiveChannel struct {
+	BroadcasterID   string `json:"broadcaster_id"`
+	BroadcasterName string `json:"broadcaster_name"`
+	GameName        string `json:"game_name"`
+	GameID          string `json:"game_id"`
+	Title           string `json:"title"`
+}
+
+// Unlike most endpoints, the cursor is returned as a string rather than a pagination object
+type liveResponse struct {
+	Data       []LiveChannel `json:"data"`
+	Pagination string        `json:"pagination"`
+}
+
+// Live is authorized with an app or user access token, unlike the other Extension endpoints.
+type Live struct{}
+
+func (e Live) Path() string { return "/extensions/live" }
+
+func (e Live) GetRequiredScopes(method string) []string {
+	return liveScopesByMethod[method]
+}
+
+func (e Live) ValidMethod(method string) bool {
+	return liveMethodsSupported[method]
+}
+
+func (e Live) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		getLive(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getLive(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	extensionID := r.URL.Query().Get("extension_id")
+	if extensionID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter extension_id")
+		return
+	}
+
+	response := liveResponse{Data: []LiveChannel{}}
+
+	dbr, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: extensionID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := dbr.Data.([]database.AuthenticationClient)
+
+	// The mock API doesn't track where Extensions are installed, so every live channel is treated as having the Extension active
+	if len(clients) != 0 && clients[0].IsExtension {
+		dbr, err = db.NewQuery(r, 100).GetStream(database.Stream{})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+
+		for _, s := range dbr.Data.([]database.Stream) {
+			response.Data = append(response.Data, LiveChannel{
+				BroadcasterID:   s.UserID,
+				BroadcasterName: s.UserName,
+				GameName:        s.RealCategoryName,
+				GameID:          s.RealCategoryID,
+				Title:           s.Title,
+			})
+		}
+		response.Pagination = dbr.Cursor
+	}
+
+	bytes, _ := json.Marshal(response)
+	w.Write(bytes)
+}
@@ -0,0 +1,256 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/extension"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+var pubSubMethodsSupported = map[string]bool{
+	http.MethodGet:    false,
+	http.MethodPost:   true,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var pubSubScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+type PostPubSubRequestBody struct {
+	Target            []string `json:"target"`
+	BroadcasterID     string   `json:"broadcaster_id"`
+	IsGlobalBroadcast bool     `json:"is_global_broadcast"`
+	Message           string   `json:"message"`
+}
+
+// PubSubMessage is what subscribers connected to PubSubSocket receive, matching the arguments of Twitch.ext.listen callbacks.
+type PubSubMessage struct {
+	Target      string `json:"target"`
+	ContentType string `json:"content_type"`
+	Message     string `json:"message"`
+}
+
+type PubSub struct{}
+
+func (e PubSub) Path() string { return "/extensions/pubsub" }
+
+func (e PubSub) GetRequiredScopes(method string) []string {
+	return pubSubScopesByMethod[method]
+}
+
+func (e PubSub) ValidMethod(method string) bool {
+	return pubSubMethodsSupported[method]
+}
+
+func (e PubSub) RequiresExtensionJWT() bool { return true }
+
+func (e PubSub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		postPubSub(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func postPubSub(w http.ResponseWriter, r *http.Request) {
+	auth := r.Context().Value("extension_auth").(authentication.ExtensionAuthentication)
+
+	var body PostPubSubRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "Body unable to be parsed")
+		return
+	}
+
+	if len(body.Target) == 0 {
+		mock_errors.WriteBadRequest(w, "Missing required field target")
+		return
+	}
+	if body.Message == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field message")
+		return
+	}
+	if len(body.Message) > maxContentLength {
+		mock_errors.WriteBadRequest(w, "message must be 5 KB or less")
+		return
+	}
+
+	for _, target := range body.Target {
+		if target != "broadcast" && target != "global" && !strings.HasPrefix(target, "whisper-") {
+			mock_errors.WriteBadRequest(w, "target must be broadcast, global, or whisper-<opaque_user_id>")
+			return
+		}
+		if (target == "global") != body.IsGlobalBroadcast {
+			mock_errors.WriteBadRequest(w, "The global target must be used with is_global_broadcast, and only on its own")
+			return
+		}
+		if !auth.Claims.CanSend(target) {
+			mock_errors.WriteUnauthorized(w, "JWT pubsub_perms.send does not include "+target)
+			return
+		}
+	}
+
+	if body.IsGlobalBroadcast {
+		if len(body.Target) != 1 {
+			mock_errors.WriteBadRequest(w, "The global target must be used with is_global_broadcast, and only on its own")
+			return
+		}
+		if auth.Claims.ChannelID != "all" {
+			mock_errors.WriteUnauthorized(w, "JWT channel_id must be all for global broadcasts")
+			return
+		}
+	} else {
+		if body.BroadcasterID == "" {
+			mock_errors.WriteBadRequest(w, "Missing required field broadcaster_id")
+			return
+		}
+		if body.BroadcasterID != auth.Claims.ChannelID {
+			mock_errors.WriteUnauthorized(w, "broadcaster_id does not match JWT channel_id")
+			return
+		}
+	}
+
+	for _, target := range body.Target {
+		pubSubSubscribers.publish(auth.ClientID, body.BroadcasterID, PubSubMessage{
+			Target:      target,
+			ContentType: "application/json",
+			Message:     body.Message,
+		})
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type pubSubSubscriber struct {
+	extensionID string
+	claims      extension.Claims
+	messages    chan []byte
+}
+
+type pubSubHub struct {
+	mu          sync.Mutex
+	subscribers map[*pubSubSubscriber]bool
+}
+
+var pubSubSubscribers = &pubSubHub{subscribers: map[*pubSubSubscriber]bool{}}
+
+// publish sends a message to every subscriber of the Extension that can listen to its target. Broadcasts and whispers
+// only go to subscribers on the given channel, while global broadcasts go to every channel.
+func (h *pubSubHub) publish(extensionID string, broadcasterID string, m PubSubMessage) {
+	bytes, _ := json.Marshal(m)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		if s.extensionID != extensionID || !s.claims.CanListen(m.Target) {
+			continue
+		}
+		if m.Target != "global" && s.claims.ChannelID != broadcasterID {
+			continue
+		}
+
+		select {
+		case s.messages <- bytes:
+		default:
+			log.Printf("Dropped Extension PubSub message for slow subscriber %v", s.claims.OpaqueUserID)
+		}
+	}
+}
+
+func (h *pubSubHub) add(s *pubSubSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[s] = true
+}
+
+func (h *pubSubHub) remove(s *pubSubSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[s] {
+		delete(h.subscribers, s)
+		close(s.messages)
+	}
+}
+
+// PubSubSocket is a WebSocket that receives the Extension PubSub messages a frontend would, standing in for Twitch.ext.listen.
+// Subscribers connect with the extension_id and jwt query parameters, and receive every message their JWT's pubsub_perms.listen allows.
+type PubSubSocket struct{}
+
+func (e PubSubSocket) Path() string { return "/extensions/pubsub" }
+
+var pubSubUpgrader = websocket.Upgrader{
+	// Extension frontends are served from their own origin
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+func (e PubSubSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+
+	extensionID := r.URL.Query().Get("extension_id")
+	token := r.URL.Query().Get("jwt")
+	if extensionID == "" || token == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameters extension_id and jwt")
+		return
+	}
+
+	dbr, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: extensionID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := dbr.Data.([]database.AuthenticationClient)
+	if len(clients) == 0 || !clients[0].IsExtension {
+		mock_errors.WriteUnauthorized(w, "extension_id is not an Extension")
+		return
+	}
+
+	claims, err := extension.Verify(token, clients[0].Secret)
+	if err != nil {
+		mock_errors.WriteUnauthorized(w, err.Error())
+		return
+	}
+
+	conn, err := pubSubUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade Extension PubSub connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	s := &pubSubSubscriber{extensionID: extensionID, claims: claims, messages: make(chan []byte, 100)}
+	pubSubSubscribers.add(s)
+
+	// Subscribers don't send anything, so reading only notices when they disconnect
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				pubSubSubscribers.remove(s)
+				return
+			}
+		}
+	}()
+
+	for m := range s.messages {
+		err := conn.WriteMessage(websocket.TextMessage, m)
+		if err != nil {
+			pubSubSubscribers.remove(s)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+var requiredConfigurationMethodsSupported = map[string]bool{
+	http.MethodGet:    false,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    true,
+}
+
+var requiredConfigurationScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+type PutRequiredConfigurationRequestBody struct {
+	ExtensionID           string `json:"extension_id"`
+	ExtensionVersion      string `json:"extension_version"`
+	RequiredConfiguration string `json:"required_configuration"`
+}
+
+type RequiredConfiguration struct{}
+
+func (e RequiredConfiguration) Path() string { return "/extensions/required_configuration" }
+
+func (e RequiredConfiguration) GetRequiredScopes(method string) []string {
+	return requiredConfigurationScopesByMethod[method]
+}
+
+func (e RequiredConfiguration) ValidMethod(method string) bool {
+	return requiredConfigurationMethodsSupported[method]
+}
+
+func (e RequiredConfiguration) RequiresExtensionJWT() bool { return true }
+
+func (e RequiredConfiguration) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		putRequiredConfiguration(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func putRequiredConfiguration(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	auth, ok := externalAuth(w, r)
+	if !ok {
+		return
+	}
+
+	broadcasterID := r.URL.Query().Get("broadcaster_id")
+	if broadcasterID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter broadcaster_id")
+		return
+	}
+
+	var body PutRequiredConfigurationRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "Body unable to be parsed")
+		return
+	}
+
+	if !matchesClientID(w, auth, body.ExtensionID) {
+		return
+	}
+	if body.ExtensionVersion == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field extension_version")
+		return
+	}
+	if body.RequiredConfiguration == "" {
+		mock_errors.WriteBadRequest(w, "Missing required field required_configuration")
+		return
+	}
+
+	err = db.NewQuery(nil, 100).SetExtensionRequiredConfiguration(database.ExtensionRequiredConfiguration{
+		ExtensionID:           body.ExtensionID,
+		BroadcasterID:         broadcasterID,
+		ExtensionVersion:      body.ExtensionVersion,
+		RequiredConfiguration: body.RequiredConfiguration,
+	})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
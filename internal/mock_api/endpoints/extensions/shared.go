@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/extension"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+// Twitch limits configuration segments and PubSub messages to 5 KB
+const maxContentLength = 5 * 1024
+
+// externalAuth returns the JWT of the request if it was signed for an Extension backend, and writes an error if it wasn't.
+func externalAuth(w http.ResponseWriter, r *http.Request) (authentication.ExtensionAuthentication, bool) {
+	auth := r.Context().Value("extension_auth").(authentication.ExtensionAuthentication)
+	if auth.Claims.Role != extension.RoleExternal {
+		mock_errors.WriteUnauthorized(w, "JWT role must be external")
+		return auth, false
+	}
+	return auth, true
+}
+
+// matchesClientID writes an error if the extension_id given doesn't match the Extension the JWT was signed for.
+func matchesClientID(w http.ResponseWriter, auth authentication.ExtensionAuthentication, extensionID string) bool {
+	if extensionID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter extension_id")
+		return false
+	}
+	if extensionID != auth.ClientID {
+		mock_errors.WriteUnauthorized(w, "extension_id does not match Client-Id")
+		return false
+	}
+	return true
+}
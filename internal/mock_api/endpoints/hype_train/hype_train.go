@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
@@ -71,8 +70,6 @@ func (e HypeTrainEvents) ValidMethod(method string) bool {
 }
 
 func (e HypeTrainEvents) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getHypeTrainEvents(w, r)
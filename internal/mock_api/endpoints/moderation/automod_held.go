@@ -7,8 +7,10 @@ import (
 	"net/http"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 )
 
 var automodHeldMethodsSupported = map[string]bool{
@@ -46,8 +48,6 @@ func (e AutomodHeld) ValidMethod(method string) bool {
 }
 
 func (e AutomodHeld) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		getAutomodHeld(w, r)
@@ -58,6 +58,7 @@ func (e AutomodHeld) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getAutomodHeld(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	body := PostAutomodHeldBody{}
 
@@ -82,5 +83,91 @@ func getAutomodHeld(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dbr, err := db.NewQuery(r, 100).GetAutomodMessages(database.AutomodMessage{ID: body.MessageID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching held message")
+		return
+	}
+	held := dbr.Data.([]database.AutomodMessage)
+	if len(held) == 0 {
+		// Messages are held with "twitch event trigger automod-message-hold"
+		mock_errors.WriteNotFound(w, "The message specified in the msg_id field was not found")
+		return
+	}
+	message := held[0]
+
+	if message.Status != database.AutomodStatusPending {
+		mock_errors.WriteBadRequest(w, "The message specified in msg_id was already approved or denied")
+		return
+	}
+
+	isModerator := body.UserID == message.BroadcasterID
+	if !isModerator {
+		moderatorListDbr, err := db.NewQuery(r, 1000).GetModeratorsForBroadcaster(message.BroadcasterID)
+		if err != nil {
+			mock_errors.WriteServerError(w, "error fetching moderators")
+			return
+		}
+		for _, mod := range moderatorListDbr.Data.([]database.Moderator) {
+			if mod.UserID == body.UserID {
+				isModerator = true
+			}
+		}
+	}
+	if !isModerator {
+		mock_errors.WriteForbidden(w, "The user in user_id is not one of the broadcaster's moderators")
+		return
+	}
+
+	status := database.AutomodStatusApproved
+	if body.Action == "DENY" {
+		status = database.AutomodStatusDenied
+	}
+
+	err = db.NewQuery(r, 100).UpdateAutomodMessageStatus(message.ID, status)
+	if err != nil {
+		mock_errors.WriteServerError(w, "error updating held message")
+		return
+	}
+
+	broadcaster, err := db.NewQuery(r, 100).GetUser(database.User{ID: message.BroadcasterID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching broadcaster")
+		return
+	}
+	moderator, err := db.NewQuery(r, 100).GetUser(database.User{ID: body.UserID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching moderator")
+		return
+	}
+
+	mock_events.EmitEventSub(r, trigger.TriggerParameters{
+		Event:             "automod.message.update",
+		FromUser:          message.UserID,
+		FromUserName:      message.UserLogin,
+		ToUser:            message.BroadcasterID,
+		ToUserName:        broadcaster.UserLogin,
+		ModeratorUser:     body.UserID,
+		ModeratorUserName: moderator.UserLogin,
+		ItemID:            message.ID,
+		ItemName:          message.Category,
+		Cost:              int64(message.Level),
+		MessageText:       message.Text,
+		EventStatus:       status,
+	})
+
+	// Approved messages are then delivered to chat
+	if status == database.AutomodStatusApproved {
+		mock_events.Emit(r, trigger.TriggerParameters{
+			Event:        "channel.chat.message",
+			FromUser:     message.UserID,
+			FromUserName: message.UserLogin,
+			ToUser:       message.BroadcasterID,
+			ToUserName:   broadcaster.UserLogin,
+			ItemID:       message.ID,
+			MessageText:  message.Text,
+		})
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -56,8 +56,6 @@ func (e AutomodStatus) ValidMethod(method string) bool {
 }
 
 func (e AutomodStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postAutomodStatus(w, r)
@@ -68,6 +66,7 @@ func (e AutomodStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postAutomodStatus(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	var body PostAutomodStatusBody
 	response := []PostAutomodStatusResponse{}
@@ -88,7 +87,19 @@ func postAutomodStatus(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		shouldPermit := util.RandomInt(2) == 0
+		// Messages held through "twitch event trigger automod-message-hold" are only permitted once approved
+		dbr, err := db.NewQuery(r, 100).GetAutomodMessages(database.AutomodMessage{ID: data.MessageID})
+		if err != nil {
+			mock_errors.WriteServerError(w, "error fetching held messages")
+			return
+		}
+
+		var shouldPermit bool
+		if held := dbr.Data.([]database.AutomodMessage); len(held) != 0 {
+			shouldPermit = held[0].Status == database.AutomodStatusApproved
+		} else {
+			shouldPermit = util.RandomInt(2) == 0
+		}
 
 		response = append(response, PostAutomodStatusResponse{MessageID: data.MessageID, IsPermitted: shouldPermit})
 	}
@@ -42,8 +42,6 @@ func (e Banned) ValidMethod(method string) bool {
 }
 
 func (e Banned) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getBanned(w, r)
@@ -54,6 +52,7 @@ func (e Banned) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getBanned(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	dbr := &database.DBResponse{}
 	var err error
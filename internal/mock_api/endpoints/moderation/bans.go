@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 	"github.com/twitchdev/twitch-cli/internal/models"
 )
 
@@ -62,8 +65,6 @@ func (e Bans) ValidMethod(method string) bool {
 }
 
 func (e Bans) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postBans(w, r)
@@ -77,6 +78,7 @@ func (e Bans) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postBans(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -201,6 +203,19 @@ func postBans(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	banEnd := ""
+	if body.Data.Duration != 0 {
+		banEnd = strconv.Itoa(body.Data.Duration)
+	}
+	mock_events.Emit(r, trigger.TriggerParameters{
+		Event:           "channel.ban",
+		FromUser:        foundUser.ID,
+		FromUserName:    foundUser.UserLogin,
+		ToUser:          broadcaster.ID,
+		ToUserName:      broadcaster.UserLogin,
+		BanEndTimestamp: banEnd,
+	})
+
 	timeNow := time.Now().UTC().Format(time.RFC3339)
 	var timeLater *string
 	if body.Data.Duration != 0 {
@@ -221,6 +236,7 @@ func postBans(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteBans(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -6,8 +6,10 @@ import (
 	"net/http"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 )
 
 var chatMethodsSupported = map[string]bool{
@@ -39,8 +41,6 @@ func (e Chat) ValidMethod(method string) bool {
 }
 
 func (e Chat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodDelete:
 		deleteChat(w, r)
@@ -51,6 +51,7 @@ func (e Chat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteChat(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -100,13 +101,65 @@ func deleteChat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Without a message ID, all messages in the chat room are removed
 	messageID := r.URL.Query().Get("message_id")
 	if messageID == "" {
-		mock_errors.WriteBadRequest(w, "Missing required parameter message_id")
+		err = db.NewQuery(r, 100).ClearChatMessages(broadcasterID)
+		if err != nil {
+			mock_errors.WriteServerError(w, "error clearing chat messages")
+			return
+		}
+
+		mock_events.Emit(r, trigger.TriggerParameters{
+			Event:      "channel.chat.clear",
+			ToUser:     broadcaster.ID,
+			ToUserName: broadcaster.UserLogin,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	dbr, err := db.NewQuery(r, 100).GetChatMessages(database.ChatMessage{ID: messageID, BroadcasterID: broadcasterID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error fetching chat message")
+		return
+	}
+	messages := dbr.Data.([]database.ChatMessage)
+	if len(messages) == 0 {
+		mock_errors.WriteNotFound(w, "The ID in message_id was not found")
+		return
+	}
+	message := messages[0]
 
-	// No connection to chat on here, so we're just gonna pretend it worked (HTTP 204)
+	// Messages from the broadcaster and other moderators can't be deleted
+	if message.UserID == broadcasterID {
+		mock_errors.WriteBadRequest(w, "You may not delete the broadcaster's messages")
+		return
+	}
+	if moderatorListDbr, err := db.NewQuery(r, 1000).GetModeratorsForBroadcaster(broadcasterID); err == nil {
+		for _, mod := range moderatorListDbr.Data.([]database.Moderator) {
+			if mod.UserID == message.UserID {
+				mock_errors.WriteBadRequest(w, "You may not delete another moderator's messages")
+				return
+			}
+		}
+	}
+
+	err = db.NewQuery(r, 100).DeleteChatMessage(broadcasterID, messageID)
+	if err != nil {
+		mock_errors.WriteServerError(w, "error deleting chat message")
+		return
+	}
+
+	mock_events.Emit(r, trigger.TriggerParameters{
+		Event:        "channel.chat.message_delete",
+		FromUser:     message.UserID,
+		FromUserName: message.UserLogin,
+		ToUser:       broadcaster.ID,
+		ToUserName:   broadcaster.UserLogin,
+		ItemID:       message.ID,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
 	"github.com/twitchdev/twitch-cli/test_setup"
 	"github.com/twitchdev/twitch-cli/test_setup/test_server"
 )
@@ -52,7 +55,8 @@ func TestAutoModHeld(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 	resp, err := http.DefaultClient.Do(req)
 	a.Nil(err)
-	a.Equal(204, resp.StatusCode)
+	// the message was never held
+	a.Equal(404, resp.StatusCode)
 
 	body.UserID = "2"
 	b, _ = json.Marshal(body)
@@ -81,6 +85,76 @@ func TestAutoModHeld(t *testing.T) {
 	a.Equal(400, resp.StatusCode)
 }
 
+func TestAutoModHeldMessage(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(AutomodHeld{})
+
+	db, err := database.NewConnection(true)
+	a.Nil(err)
+	defer db.DB.Close()
+
+	message := database.AutomodMessage{
+		ID:            util.RandomGUID(),
+		BroadcasterID: "1",
+		UserID:        "2",
+		Text:          "held message",
+		Category:      "swearing",
+		Level:         2,
+		HeldAt:        util.GetTimestamp().Format(time.RFC3339Nano),
+	}
+	err = db.NewQuery(nil, 100).InsertAutomodMessage(message)
+	a.Nil(err)
+
+	body := PostAutomodHeldBody{
+		UserID:    "1",
+		MessageID: message.ID,
+		Action:    "DENY",
+	}
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+AutomodHeld{}.Path(), bytes.NewBuffer(b))
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	dbr, err := db.NewQuery(nil, 100).GetAutomodMessages(database.AutomodMessage{ID: message.ID})
+	a.Nil(err)
+	a.Equal(database.AutomodStatusDenied, dbr.Data.([]database.AutomodMessage)[0].Status)
+
+	// a message can only be handled once
+	body.Action = "ALLOW"
+	b, _ = json.Marshal(body)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+AutomodHeld{}.Path(), bytes.NewBuffer(b))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// held messages are reported as not permitted until they're approved
+	ts = test_server.SetupTestServer(AutomodStatus{})
+	statusBody := PostAutomodStatusBody{
+		Data: []PostAutomodStatusBodyData{{
+			UserID:      "2",
+			MessageID:   message.ID,
+			MessageText: message.Text,
+		}},
+	}
+	b, _ = json.Marshal(statusBody)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+AutomodStatus{}.Path(), bytes.NewBuffer(b))
+	q := req.URL.Query()
+	q.Set("broadcaster_id", "1")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var statusResponse struct {
+		Data []PostAutomodStatusResponse `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&statusResponse)
+	a.Nil(err)
+	a.Len(statusResponse.Data, 1)
+	a.False(statusResponse.Data[0].IsPermitted)
+}
+
 func TestAutoModStatus(t *testing.T) {
 	a := test_setup.SetupTestEnv(t)
 	ts := test_server.SetupTestServer(AutomodStatus{})
@@ -191,3 +265,30 @@ func TestBans(t *testing.T) {
 	a.Nil(err)
 	a.Equal(204, resp.StatusCode)
 }
+
+func TestChat(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(Chat{})
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+Chat{}.Path(), nil)
+	q := req.URL.Query()
+	req.URL.RawQuery = q.Encode()
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	q.Set("broadcaster_id", "1")
+	q.Set("moderator_id", "1")
+	q.Set("message_id", "not-a-real-message")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(404, resp.StatusCode)
+
+	// clears the whole chat
+	q.Del("message_id")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+}
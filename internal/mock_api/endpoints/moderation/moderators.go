@@ -42,8 +42,6 @@ func (e Moderators) ValidMethod(method string) bool {
 }
 
 func (e Moderators) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getModerators(w, r)
@@ -60,6 +58,7 @@ func (e Moderators) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getModerators(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "broadcaster_id does not match token")
@@ -99,6 +98,7 @@ func getModerators(w http.ResponseWriter, r *http.Request) {
 }
 
 func postModerators(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "broadcaster_id does not match token")
@@ -183,6 +183,7 @@ func postModerators(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteModerators(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "broadcaster_id does not match token")
@@ -57,8 +57,6 @@ func (e ShieldMode) ValidMethod(method string) bool {
 }
 
 func (e ShieldMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getShieldModeStatus(w, r)
@@ -72,6 +70,7 @@ func (e ShieldMode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getShieldModeStatus(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -143,6 +142,7 @@ func getShieldModeStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 func putShieldModeStatus(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesModeratorIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "Moderator ID does not match token.")
@@ -64,8 +64,6 @@ func (e Polls) ValidMethod(method string) bool {
 }
 
 func (e Polls) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getPolls(w, r)
@@ -81,6 +79,7 @@ func (e Polls) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func getPolls(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	var dbr *database.DBResponse
 	var err error
@@ -125,6 +124,7 @@ func getPolls(w http.ResponseWriter, r *http.Request) {
 }
 
 func postPolls(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	u, err := db.NewQuery(r, 100).GetUser(database.User{ID: userCtx.UserID})
@@ -201,6 +201,7 @@ func postPolls(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchPolls(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	var body PatchPollsBody
@@ -62,8 +62,6 @@ func (e Predictions) ValidMethod(method string) bool {
 }
 
 func (e Predictions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getPredictions(w, r)
@@ -80,6 +78,7 @@ func (e Predictions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getPredictions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	predictions := []database.Prediction{}
 	var dbr *database.DBResponse
@@ -125,6 +124,7 @@ func getPredictions(w http.ResponseWriter, r *http.Request) {
 }
 
 func postPredictions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	var body PostPredictionsBody
 
@@ -200,6 +200,7 @@ func postPredictions(w http.ResponseWriter, r *http.Request) {
 }
 
 func patchPredictions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	var body PatchPredictionsBody
 
@@ -49,8 +49,6 @@ func (e Raids) ValidMethod(method string) bool {
 }
 
 func (e Raids) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postRaids(w, r)
@@ -64,6 +62,7 @@ func (e Raids) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postRaids(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesSpecifiedIDParam(r, "from_broadcaster_id") {
 		mock_errors.WriteUnauthorized(w, "from_broadcaster_id does not match token")
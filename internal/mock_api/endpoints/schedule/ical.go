@@ -5,7 +5,6 @@ package schedule
 import (
 	"net/http"
 
-	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 )
 
@@ -38,8 +37,6 @@ func (e ScheduleICal) ValidMethod(method string) bool {
 }
 
 func (e ScheduleICal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		e.getIcal(w, r)
@@ -43,8 +43,6 @@ func (e Schedule) ValidMethod(method string) bool {
 }
 
 func (e Schedule) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		e.getSchedule(w, r)
@@ -54,6 +52,7 @@ func (e Schedule) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e Schedule) getSchedule(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	broadcasterID := r.URL.Query().Get("broadcaster_id")
 	queryTime := r.URL.Query().Get("start_time")
 	offset := r.URL.Query().Get("utc_offset")
@@ -58,8 +58,6 @@ func (e ScheduleSegment) ValidMethod(method string) bool {
 }
 
 func (e ScheduleSegment) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		e.postSegment(w, r)
@@ -73,6 +71,7 @@ func (e ScheduleSegment) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e ScheduleSegment) postSegment(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	duration := 240
 
@@ -194,6 +193,7 @@ func (e ScheduleSegment) postSegment(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e ScheduleSegment) deleteSegment(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	id := r.URL.Query().Get("id")
 	if !userCtx.MatchesBroadcasterIDParam(r) {
@@ -215,6 +215,7 @@ func (e ScheduleSegment) deleteSegment(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e ScheduleSegment) patchSegment(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	id := r.URL.Query().Get("id")
 	if !userCtx.MatchesBroadcasterIDParam(r) {
@@ -51,8 +51,6 @@ func (e ScheduleSettings) ValidMethod(method string) bool {
 }
 
 func (e ScheduleSettings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPatch:
 		e.patchSchedule(w, r)
@@ -62,6 +60,7 @@ func (e ScheduleSettings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e ScheduleSettings) patchSchedule(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesBroadcasterIDParam(r) {
 		mock_errors.WriteUnauthorized(w, "User token does not match broadcaster_id parameter")
@@ -42,8 +42,6 @@ func (e SearchCategories) ValidMethod(method string) bool {
 }
 
 func (e SearchCategories) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		searchCategories(w, r)
@@ -54,6 +52,7 @@ func (e SearchCategories) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func searchCategories(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	query := r.URL.Query().Get("query")
 
 	if query == "" {
@@ -42,8 +42,6 @@ func (e SearchChannels) ValidMethod(method string) bool {
 }
 
 func (e SearchChannels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		searchChannels(w, r)
@@ -53,6 +51,7 @@ func (e SearchChannels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func searchChannels(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	query := r.URL.Query().Get("query")
 	live_only := false
 
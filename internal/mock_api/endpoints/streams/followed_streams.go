@@ -41,8 +41,6 @@ func (e FollowedStreams) ValidMethod(method string) bool {
 }
 
 func (e FollowedStreams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getFollowedStreams(w, r)
@@ -53,6 +51,7 @@ func (e FollowedStreams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getFollowedStreams(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	if !userCtx.MatchesUserIDParam(r) {
@@ -55,8 +55,6 @@ func (e Markers) ValidMethod(method string) bool {
 }
 
 func (e Markers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getMarkers(w, r)
@@ -70,6 +68,7 @@ func (e Markers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getMarkers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	userID := r.URL.Query().Get("user_id")
 	videoID := r.URL.Query().Get("video_id")
@@ -98,6 +97,7 @@ func getMarkers(w http.ResponseWriter, r *http.Request) {
 }
 
 func postMarkers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	body := MarkerPostBody{}
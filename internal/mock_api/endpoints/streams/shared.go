@@ -4,8 +4,6 @@ package streams
 
 import "github.com/twitchdev/twitch-cli/internal/database"
 
-var db database.CLIDatabase
-
 type TagResponse struct {
 	TagID                    string         `json:"tag_id"`
 	IsAuto                   bool           `json:"is_auto"`
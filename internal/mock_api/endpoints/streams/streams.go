@@ -47,8 +47,6 @@ func (e Streams) ValidMethod(method string) bool {
 }
 
 func (e Streams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getStreams(w, r)
@@ -59,6 +57,7 @@ func (e Streams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getStreams(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	gameIDs := r.URL.Query()["game_id"]
 	languages := r.URL.Query()["language"]
 	userIDs := r.URL.Query()["user_id"]
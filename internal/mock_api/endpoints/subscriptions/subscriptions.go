@@ -41,8 +41,6 @@ func (e BroadcasterSubscriptions) ValidMethod(method string) bool {
 }
 
 func (e BroadcasterSubscriptions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getBroadcasterSubscriptions(w, r)
@@ -53,6 +51,7 @@ func (e BroadcasterSubscriptions) ServeHTTP(w http.ResponseWriter, r *http.Reque
 }
 
 func getBroadcasterSubscriptions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	if !userCtx.MatchesBroadcasterIDParam(r) {
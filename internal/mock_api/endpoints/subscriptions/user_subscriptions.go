@@ -41,8 +41,6 @@ func (e UserSubscriptions) ValidMethod(method string) bool {
 }
 
 func (e UserSubscriptions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getUserSubscriptions(w, r)
@@ -54,6 +52,7 @@ func (e UserSubscriptions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getUserSubscriptions(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	if userCtx.UserID != r.URL.Query().Get("user_id") {
@@ -54,8 +54,6 @@ func (e ChannelTeams) ValidMethod(method string) bool {
 }
 
 func (e ChannelTeams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getChannelTeams(w, r)
@@ -66,6 +64,7 @@ func (e ChannelTeams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getChannelTeams(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	if len(r.URL.Query().Get("broadcaster_id")) == 0 {
 		mock_errors.WriteBadRequest(w, "broadcaster_id is required")
 		return
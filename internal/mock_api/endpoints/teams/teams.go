@@ -40,8 +40,6 @@ func (e Teams) ValidMethod(method string) bool {
 }
 
 func (e Teams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getTeams(w, r)
@@ -52,6 +50,7 @@ func (e Teams) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getTeams(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	if r.URL.Query().Get("name") == "" && r.URL.Query().Get("id") == "" {
 		mock_errors.WriteBadRequest(w, "one of name or id is required")
 		return
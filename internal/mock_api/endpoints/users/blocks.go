@@ -44,8 +44,6 @@ func (e Blocks) ValidMethod(method string) bool {
 }
 
 func (e Blocks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getBlocks(w, r)
@@ -60,6 +58,7 @@ func (e Blocks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getBlocks(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	if !userCtx.MatchesBroadcasterIDParam(r) {
@@ -92,6 +91,7 @@ func getBlocks(w http.ResponseWriter, r *http.Request) {
 }
 
 func putBlocks(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	target := r.URL.Query().Get("target_user_id")
@@ -132,6 +132,7 @@ func putBlocks(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteBlocks(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	target := r.URL.Query().Get("target_user_id")
@@ -59,8 +59,6 @@ func (e UsersEndpoint) ValidMethod(method string) bool {
 }
 
 func (e UsersEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getUsers(w, r)
@@ -74,6 +72,7 @@ func (e UsersEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getUsers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	q := r.URL.Query()
 	users := []User{}
 	userIDs := q["id"]
@@ -145,6 +144,7 @@ func getUsers(w http.ResponseWriter, r *http.Request) {
 }
 
 func putUsers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	q := r.URL.Query()
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	description := q.Get("description")
@@ -1,7 +0,0 @@
-// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
-// SPDX-License-Identifier: Apache-2.0
-package videos
-
-import "github.com/twitchdev/twitch-cli/internal/database"
-
-var db database.CLIDatabase
@@ -51,8 +51,6 @@ func (e Videos) ValidMethod(method string) bool {
 }
 
 func (e Videos) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getVideos(w, r)
@@ -65,6 +63,7 @@ func (e Videos) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getVideos(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	videos := []database.Video{}
 	validPeriods := []string{"all", "day", "week", "month"}
 	validSorts := []string{"time", "trending", "views"}
@@ -161,6 +160,7 @@ func getVideos(w http.ResponseWriter, r *http.Request) {
 }
 
 func deleteVideos(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	ids := r.URL.Query()["id"]
 
 	if len(ids) == 0 || len(ids) > 5 {
@@ -7,8 +7,10 @@ import (
 	"net/http"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 )
 
 var whispersMethodsSupported = map[string]bool{
@@ -44,8 +46,6 @@ func (e Whispers) ValidMethod(method string) bool {
 }
 
 func (e Whispers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodPost:
 		postWhispers(w, r)
@@ -56,6 +56,7 @@ func (e Whispers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func postWhispers(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	if !userCtx.MatchesSpecifiedIDParam(r, "from_user_id") {
 		mock_errors.WriteUnauthorized(w, "from_user_id does not match token")
@@ -107,8 +108,21 @@ func postWhispers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Chat is not supported in Mock API, so we're pretending this worked.
-	// This implementation also has no support for suspended users, blocked users, or users with whispers disabled
+	sender, err := db.NewQuery(r, 100).GetUser(database.User{ID: fromUserID})
+	if err != nil {
+		mock_errors.WriteServerError(w, "error pulling from_user_id from database: "+err.Error())
+		return
+	}
+
+	// This implementation has no support for suspended users, blocked users, or users with whispers disabled
+	mock_events.EmitEventSub(r, trigger.TriggerParameters{
+		Event:        "user.whisper.message",
+		FromUser:     fromUserID,
+		FromUserName: sender.DisplayName,
+		ToUser:       toUserID,
+		ToUserName:   user.DisplayName,
+		MessageText:  body.Message,
+	})
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -5,9 +5,13 @@ package whispers
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	"github.com/twitchdev/twitch-cli/internal/models"
 	"github.com/twitchdev/twitch-cli/test_setup"
 	"github.com/twitchdev/twitch-cli/test_setup/test_server"
 )
@@ -42,3 +46,35 @@ func TestRaids(t *testing.T) {
 	a.Nil(err)
 	a.Equal(204, resp.StatusCode)
 }
+
+func TestWhisperEvent(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	var received []byte
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer forward.Close()
+
+	ts := test_server.SetupTestServerWithEmitter(Whispers{}, &mock_events.Emitter{ForwardAddress: forward.URL, Secret: "secretsecret"})
+
+	b, _ := json.Marshal(PostWhisperRequestBody{Message: "psst"})
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+Whispers{}.Path(), bytes.NewBuffer(b))
+	q := req.URL.Query()
+	q.Set("from_user_id", "1")
+	q.Set("to_user_id", "2")
+	req.URL.RawQuery = q.Encode()
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(204, resp.StatusCode)
+
+	// the recipient gets a whisper event
+	var body models.WhisperMessageEventSubResponse
+	err = json.Unmarshal(received, &body)
+	a.Nil(err)
+	a.Equal("user.whisper.message", body.Subscription.Type)
+	a.Equal("2", body.Subscription.Condition.UserID)
+	a.Equal("1", body.Event.FromUserID)
+	a.Equal("2", body.Event.ToUserID)
+	a.Equal("psst", body.Event.Whisper.Text)
+}
@@ -33,6 +33,11 @@ func Generate(userCount int) error {
 		return err
 	}
 
+	return GenerateInto(db, userCount)
+}
+
+// GenerateInto generates the mock data in the given database, rather than the CLI's own.
+func GenerateInto(db database.CLIDatabase, userCount int) error {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "db", db)
 
@@ -46,6 +51,12 @@ func Generate(userCount int) error {
 	}
 	generateAuthorization(ctx, c, "")
 
+	// and an Extension, whose secret signs Extension JWTs
+	_, err = generateExtensionClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	log.Print("Finished generation.")
 	return nil
 }
@@ -542,6 +553,20 @@ func generateClient(ctx context.Context) (database.AuthenticationClient, error)
 	return client, err
 }
 
+func generateExtensionClient(ctx context.Context) (database.AuthenticationClient, error) {
+	db := ctx.Value("db").(database.CLIDatabase)
+
+	client := database.AuthenticationClient{
+		ID:          util.RandomClientID(),
+		Name:        "Mock Extension",
+		IsExtension: true,
+	}
+
+	client, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(client, false)
+	log.Printf("Created Extension. Details:\nClient-ID: %v\nSecret: %v\nName: %v", client.ID, client.Secret, client.Name)
+	return client, err
+}
+
 func generateAuthorization(ctx context.Context, c database.AuthenticationClient, userID string) error {
 	db := ctx.Value("db").(database.CLIDatabase)
 
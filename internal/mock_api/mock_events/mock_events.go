@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_events
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	configure_event "github.com/twitchdev/twitch-cli/internal/events/configure"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	rpc_handler "github.com/twitchdev/twitch-cli/internal/rpc"
+)
+
+// Address of the mock EventSub WebSocket server's RPC handler
+const websocketRPCAddress = ":44747"
+
+// Address of the mock chat server's RPC handler
+const chatRPCAddress = ":44748"
+
+// Emitter delivers the EventSub events caused by actions taken through the mock API or the mock chat server, such as a
+// chat message being sent. Events are delivered in the background, so they don't add to the latency of the action.
+type Emitter struct {
+	// ForwardAddress is where events are sent as webhooks, signed with Secret. No webhooks are sent when it's empty.
+	ForwardAddress string
+	Secret         string
+	// Local forwards events to the mock EventSub WebSocket server and the mock chat server when they're running
+	Local bool
+
+	wg sync.WaitGroup
+}
+
+// NewEmitter returns an emitter that forwards events to the address set with "twitch event configure", and to the mock
+// EventSub WebSocket and chat servers, as `twitch mock-api start` does.
+func NewEmitter() *Emitter {
+	defaults := configure_event.GetEventConfiguration(false)
+	return &Emitter{
+		ForwardAddress: defaults.ForwardAddress,
+		Secret:         defaults.Secret,
+		Local:          true,
+	}
+}
+
+// Emit delivers an event, and also shows it in the mock chat server if it's running.
+func (e *Emitter) Emit(p trigger.TriggerParameters) {
+	e.start(func() {
+		e.emitEventSub(p)
+		if e.Local {
+			forwardToChatServer(p)
+		}
+	})
+}
+
+// EmitEventSub delivers only the EventSub event for an action.
+func (e *Emitter) EmitEventSub(p trigger.TriggerParameters) {
+	e.start(func() {
+		e.emitEventSub(p)
+	})
+}
+
+// Wait blocks until the events being delivered are done, such as before the database they're stored in is closed.
+func (e *Emitter) Wait() {
+	e.wg.Wait()
+}
+
+func (e *Emitter) start(f func()) {
+	if e.ForwardAddress == "" && !e.Local {
+		return
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		f()
+	}()
+}
+
+// Failures are only logged, as the action that caused the event has already succeeded.
+func (e *Emitter) emitEventSub(p trigger.TriggerParameters) {
+	p.SubscriptionStatus = "enabled"
+
+	if e.ForwardAddress != "" {
+		webhook := p
+		webhook.Transport = models.TransportWebhook
+		webhook.ForwardAddress = e.ForwardAddress
+		webhook.Secret = e.Secret
+
+		_, err := trigger.Fire(webhook)
+		if err != nil {
+			log.Printf("Failed to emit %v event via webhook: %v", p.Event, err)
+		}
+	}
+
+	if !e.Local {
+		return
+	}
+
+	conn, err := net.DialTimeout("tcp", websocketRPCAddress, time.Second)
+	if err != nil {
+		// WebSocket server isn't running; nothing to forward to
+		return
+	}
+	conn.Close()
+
+	websocket := p
+	websocket.Transport = models.TransportWebSocket
+
+	_, err = trigger.Fire(websocket)
+	if err != nil {
+		log.Printf("Failed to emit %v event via WebSocket: %v", p.Event, err)
+	}
+}
+
+// Emit fires the event for an action taken through the mock API request r, using the emitter of the server that
+// received it, and stores the event in the server's database. Servers without an emitter don't deliver events.
+func Emit(r *http.Request, p trigger.TriggerParameters) {
+	if e := fromRequest(r, &p); e != nil {
+		e.Emit(p)
+	}
+}
+
+// EmitEventSub is Emit for actions that shouldn't be shown in the mock chat server, such as whispers.
+func EmitEventSub(r *http.Request, p trigger.TriggerParameters) {
+	if e := fromRequest(r, &p); e != nil {
+		e.EmitEventSub(p)
+	}
+}
+
+func fromRequest(r *http.Request, p *trigger.TriggerParameters) *Emitter {
+	e, ok := r.Context().Value("emitter").(*Emitter)
+	if !ok || e == nil {
+		return nil
+	}
+	if db, ok := r.Context().Value("db").(database.CLIDatabase); ok {
+		p.Database = &db
+	}
+	return e
+}
+
+func forwardToChatServer(p trigger.TriggerParameters) {
+	conn, err := net.DialTimeout("tcp", chatRPCAddress, time.Second)
+	if err != nil {
+		// Chat server isn't running; nothing to forward to
+		return
+	}
+	conn.Close()
+
+	client, err := rpc.DialHTTP("tcp", chatRPCAddress)
+	if err != nil {
+		log.Printf("Failed to dial RPC handler for chat server: %v", err)
+		return
+	}
+	defer client.Close()
+
+	variables := make(map[string]string)
+	variables["Event"] = p.Event
+	variables["FromUser"] = p.FromUser
+	variables["ToUser"] = p.ToUser
+	variables["ItemID"] = p.ItemID
+	variables["MessageText"] = p.MessageText
+	variables["NoticeType"] = p.NoticeType
+	variables["Color"] = p.Color
+	variables["BanEndTimestamp"] = p.BanEndTimestamp
+
+	var reply rpc_handler.RPCResponse
+	err = client.Call("RPCHandler.ExecuteGenericRPC", &rpc_handler.RPCArgs{
+		RPCName:   "ChatServerForwardEvent",
+		Variables: variables,
+	}, &reply)
+	if err != nil {
+		log.Printf("Failed to forward %v event to chat server: %v", p.Event, err)
+	} else if reply.ResponseCode != 0 {
+		log.Printf("Chat server failed to process %v event: %v", p.Event, reply.DetailedInfo)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_events
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestEmit(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var received []byte
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer forward.Close()
+
+	db, err := database.NewConnectionAt(filepath.Join(t.TempDir(), "mock.db"), true)
+	a.Nil(err)
+	defer db.DB.Close()
+
+	p := trigger.TriggerParameters{Event: "user.whisper.message", FromUser: "1", ToUser: "2", MessageText: "psst"}
+
+	// without an emitter, nothing is delivered
+	r := httptest.NewRequest(http.MethodPost, "/whispers", nil)
+	r = r.WithContext(context.WithValue(r.Context(), "db", db))
+	EmitEventSub(r, p)
+	a.Nil(received)
+
+	e := &Emitter{ForwardAddress: forward.URL, Secret: "secretsecret"}
+	r = r.WithContext(context.WithValue(r.Context(), "emitter", e))
+	EmitEventSub(r, p)
+	e.Wait()
+	a.Contains(string(received), "psst")
+
+	// the event is stored in the database of the server the request was made to
+	events, err := db.NewQuery(nil, 100).GetEvents()
+	a.Nil(err)
+	a.Len(events, 1)
+	a.Equal("user.whisper.message", events[0].Event)
+}
@@ -11,17 +11,23 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/extensions"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 	"github.com/twitchdev/twitch-cli/internal/mock_auth"
 	"github.com/twitchdev/twitch-cli/internal/mock_units"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
 const MOCK_NAMESPACE = "/mock"
@@ -48,6 +54,9 @@ func StartServer(port int) error {
 	}
 
 	ctx = context.WithValue(ctx, "db", db)
+	// events caused by requests, such as a chat message being sent, are forwarded like those of `twitch event trigger`
+	emitter := mock_events.NewEmitter()
+	ctx = context.WithValue(ctx, "emitter", emitter)
 
 	RegisterHandlers(m)
 	s := http.Server{
@@ -57,15 +66,25 @@ func StartServer(port int) error {
 			return ctx
 		},
 	}
-	stop := make(chan os.Signal)
+	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 
 	var serverErr error = nil
 
+	l, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	// so `twitch api --target mock` can find the server without being given its port
+	if err := writePortFile(port); err != nil {
+		log.Printf("Unable to record the mock API port: %v", err)
+	}
+	defer removePortFile(port)
+
 	go func() {
 		log.Print("Mock server started")
 
-		if err := s.ListenAndServe(); err != nil {
+		if err := s.Serve(l); err != nil {
 			if err != http.ErrServerClosed {
 				serverErr = err
 				stop <- syscall.SIGINT // Simulate Ctrl+C
@@ -80,15 +99,13 @@ func StartServer(port int) error {
 	}
 
 	log.Print("shutting down ...\n")
-	db.DB.Close()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(time.Second*5))
 	defer cancel()
 
-	if err := s.Shutdown(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	err = s.Shutdown(ctx)
+	emitter.Wait()
+	db.DB.Close()
+	return err
 }
 
 func RegisterHandlers(m *http.ServeMux) {
@@ -99,8 +116,16 @@ func RegisterHandlers(m *http.ServeMux) {
 			m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(e))
 			continue
 		}
+		// Extension endpoints are authorized with an Extension JWT, rather than an OAuth token
+		if ee, ok := e.(mock_api.ExtensionEndpoint); ok && ee.RequiresExtensionJWT() {
+			m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(authentication.ExtensionJWTMiddleware(e)))
+			continue
+		}
 		m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(authentication.AuthenticationMiddleware(e)))
 	}
+
+	// Extension frontends receive PubSub messages sent through the mock API over a WebSocket, outside of the /mock/ namespace
+	m.Handle(extensions.PubSubSocket{}.Path(), loggerMiddleware(extensions.PubSubSocket{}))
 	for _, e := range mock_units.All() {
 		m.Handle(UNITS_NAMESPACE+e.Path(), loggerMiddleware(e))
 	}
@@ -115,6 +140,51 @@ func RegisterHandlers(m *http.ServeMux) {
 	}
 }
 
+// portFile holds the port of the running mock API server
+const portFile = "mock-api.port"
+
+func portFilePath() (string, error) {
+	home, err := util.GetApplicationDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, portFile), nil
+}
+
+func writePortFile(port int) error {
+	path, err := portFilePath()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(port)), 0600)
+}
+
+// removePortFile removes the port file, unless another server has since started on a different port
+func removePortFile(port int) {
+	if running, ok := RunningPort(); ok && running == port {
+		path, _ := portFilePath()
+		os.Remove(path)
+	}
+}
+
+// RunningPort returns the port of the mock API server started most recently with `twitch mock-api start`. The server
+// may have exited without removing the file, so it's only a hint of where to look.
+func RunningPort() (int, bool) {
+	path, err := portFilePath()
+	if err != nil {
+		return 0, false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, false
+	}
+	return port, true
+}
+
 func loggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("%v %v", r.Method, r.URL.Path)
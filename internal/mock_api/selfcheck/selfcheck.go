@@ -0,0 +1,553 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selfcheck checks the handlers of the mock API against the Helix API specification, so differences between the
+// mock and production, such as misnamed fields, null values and the shape of pagination, can be found and fixed.
+package selfcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/api/spec"
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/mock_auth"
+	"github.com/twitchdev/twitch-cli/internal/request"
+	"github.com/twitchdev/twitch-cli/pkg/mockapi"
+)
+
+const (
+	// StatusMatch is an endpoint whose responses match the specification
+	StatusMatch = "match"
+	// StatusDiverges is an endpoint with at least one response that doesn't match the specification
+	StatusDiverges = "diverges"
+	// StatusNoSpec is a mock endpoint that isn't in the specification. It's only checked for server errors and invalid JSON.
+	StatusNoSpec = "no spec"
+	// StatusNotMocked is an endpoint in the specification without a mock handler
+	StatusNotMocked = "not mocked"
+	// StatusSkipped is an endpoint that can't be called by the check, such as one requiring an Extension JWT
+	StatusSkipped = "skipped"
+)
+
+// Options configures Run.
+type Options struct {
+	// Spec is the specification to check against, defaulting to the one embedded in the CLI
+	Spec []spec.Endpoint
+	// Users is how many users to generate in the database of the check, defaulting to mockapi.DefaultUsers
+	Users int
+}
+
+// Result is the outcome of checking one method of an endpoint.
+type Result struct {
+	Method   string   `json:"method"`
+	Path     string   `json:"path"`
+	Status   string   `json:"status"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+type user struct {
+	ID              string `json:"id"`
+	Login           string `json:"login"`
+	BroadcasterType string `json:"broadcaster_type"`
+}
+
+// roles are the users the IDs of a request refer to
+type roles struct {
+	broadcaster string
+	user        string
+}
+
+type response struct {
+	status      int
+	body        []byte
+	contentType string
+}
+
+type checker struct {
+	server *mockapi.Server
+	client *http.Client
+	// broadcaster is who the requests are made as, and other is a second user, such as for raids and whispers
+	broadcaster user
+	other       user
+	spec        []spec.Endpoint
+	ids         map[string]string
+}
+
+// Run starts a mock API server with its own generated database, and calls each of its endpoints with valid and invalid
+// requests generated from the specification, checking the status and body of each response.
+func Run(opts Options) ([]Result, error) {
+	endpointSpecs := opts.Spec
+	if endpointSpecs == nil {
+		var err error
+		endpointSpecs, err = spec.Endpoints()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the mock logs each request, which would bury the results
+	w := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(w)
+
+	// without a forward address, the events caused by the requests, such as chat messages being sent, aren't delivered
+	s, err := mockapi.NewServer(mockapi.Options{Users: opts.Users})
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	c := &checker{
+		server: s,
+		client: request.NewClient(10 * time.Second),
+		spec:   endpointSpecs,
+		ids:    map[string]string{},
+	}
+	if err := c.chooseUsers(); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	mocked := map[string]bool{}
+	for _, e := range endpoints.All() {
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			if !e.ValidMethod(method) {
+				continue
+			}
+			mocked[method+" "+e.Path()] = true
+			results = append(results, c.check(e, method))
+		}
+	}
+
+	for _, e := range endpointSpecs {
+		if !mocked[e.Method+" "+e.Path] {
+			results = append(results, Result{Method: e.Method, Path: e.Path, Status: StatusNotMocked})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results, nil
+}
+
+// chooseUsers picks a live partner as the broadcaster when there is one, so endpoints limited to live channels, partners
+// and affiliates work
+func (c *checker) chooseUsers() error {
+	ids, err := c.server.UserIDs()
+	if err != nil {
+		return err
+	}
+	if len(ids) < 2 {
+		return fmt.Errorf("At least two users are needed to check the mock API")
+	}
+	if len(ids) > 100 {
+		ids = ids[:100]
+	}
+
+	token, err := c.server.AppToken()
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(http.MethodGet, "/users", url.Values{"id": ids}, nil, token)
+	if err != nil {
+		return err
+	}
+	var users struct {
+		Data []user `json:"data"`
+	}
+	if err := json.Unmarshal(resp.body, &users); err != nil || resp.status != http.StatusOK || len(users.Data) < 2 {
+		return fmt.Errorf("Unable to get the generated users: %v %v", resp.status, string(resp.body))
+	}
+
+	live := map[string]bool{}
+	resp, err = c.do(http.MethodGet, "/streams", url.Values{"user_id": ids, "first": {"100"}}, nil, token)
+	if err != nil {
+		return err
+	}
+	var streams struct {
+		Data []struct {
+			UserID string `json:"user_id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(resp.body, &streams) == nil {
+		for _, s := range streams.Data {
+			live[s.UserID] = true
+		}
+	}
+
+	rank := func(u user) int {
+		r := 0
+		if live[u.ID] {
+			r += 3
+		}
+		switch u.BroadcasterType {
+		case "partner":
+			r += 2
+		case "affiliate":
+			r++
+		}
+		return r
+	}
+	sort.SliceStable(users.Data, func(i, j int) bool {
+		return rank(users.Data[i]) > rank(users.Data[j])
+	})
+	c.broadcaster = users.Data[0]
+
+	// the other user is one the broadcaster hasn't banned, so they can be
+	banned := map[string]bool{}
+	userToken, err := c.server.UserToken(c.broadcaster.ID, "moderation:read")
+	if err != nil {
+		return err
+	}
+	resp, err = c.do(http.MethodGet, "/moderation/banned", url.Values{"broadcaster_id": {c.broadcaster.ID}, "first": {"100"}}, nil, userToken)
+	if err != nil {
+		return err
+	}
+	var bans struct {
+		Data []struct {
+			UserID string `json:"user_id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(resp.body, &bans) == nil {
+		for _, b := range bans.Data {
+			banned[b.UserID] = true
+		}
+	}
+	c.other = users.Data[1]
+	for _, u := range users.Data[1:] {
+		if !banned[u.ID] {
+			c.other = u
+			break
+		}
+	}
+	return nil
+}
+
+func (c *checker) check(e mock_api.MockEndpoint, method string) Result {
+	result := Result{Method: method, Path: e.Path(), Status: StatusMatch}
+
+	if ee, ok := e.(mock_api.ExtensionEndpoint); ok && ee.RequiresExtensionJWT() {
+		result.Status = StatusSkipped
+		result.Problems = []string{"Requires an Extension JWT"}
+		return result
+	}
+
+	es, hasSpec := c.find(method, e.Path())
+
+	scopes := append([]string{}, e.GetRequiredScopes(method)...)
+	if hasSpec {
+		scopes = append(scopes, es.Scopes...)
+	}
+	var userScopes []string
+	for _, s := range scopes {
+		if mock_auth.IsValidScope(s, mock_auth.USER_ACCESS_TOKEN) {
+			userScopes = append(userScopes, s)
+		}
+	}
+	token, err := c.server.UserToken(c.broadcaster.ID, userScopes...)
+	if err != nil {
+		result.Status = StatusSkipped
+		result.Problems = []string{err.Error()}
+		return result
+	}
+
+	if !hasSpec {
+		result.Status = StatusNoSpec
+		result.Problems = c.smoke(method, e.Path(), token)
+		if len(result.Problems) != 0 {
+			result.Status = StatusDiverges
+		}
+		return result
+	}
+
+	r := c.roles(es)
+	query, body := c.validRequest(es, r, token)
+
+	// the invalid requests go first, since the valid one can change the data, such as by deleting it
+	resp, err := c.do(method, e.Path(), query, body, "")
+	result.Problems = append(result.Problems, expectStatus("Without authorization", http.StatusUnauthorized, resp, err)...)
+
+	for _, p := range es.Parameters {
+		if !p.Required {
+			continue
+		}
+		q := url.Values{}
+		for k, v := range query {
+			if k != p.Name {
+				q[k] = v
+			}
+		}
+		resp, err := c.do(method, e.Path(), q, body, token)
+		result.Problems = append(result.Problems, expectStatus("Without "+p.Name, http.StatusBadRequest, resp, err)...)
+	}
+
+	if es.Body != nil {
+		resp, err := c.do(method, e.Path(), query, nil, token)
+		result.Problems = append(result.Problems, expectStatus("Without a body", http.StatusBadRequest, resp, err)...)
+	}
+
+	resp, err = c.do(method, e.Path(), query, body, token)
+	// endpoints such as GET /clips need one of several optional parameters, which the specification can't express, so
+	// each is tried in turn
+	for _, p := range optionalIDs(es) {
+		if err != nil || resp.status != http.StatusBadRequest {
+			break
+		}
+		q := url.Values{p.Name: {c.queryValue(es.Path, p, r, token)}}
+		for k, v := range query {
+			q[k] = v
+		}
+		resp, err = c.do(method, e.Path(), q, body, token)
+	}
+	if err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("Valid request: %v", err))
+	} else {
+		for _, p := range es.ValidateResponse(resp.status, resp.body) {
+			if strings.HasPrefix(p, "Expected") {
+				p += errorMessage(resp.body)
+			}
+			result.Problems = append(result.Problems, "Valid request: "+p)
+		}
+	}
+
+	if len(result.Problems) != 0 {
+		result.Status = StatusDiverges
+	}
+	return result
+}
+
+// smoke calls an endpoint that isn't in the specification, which should respond without a server error
+func (c *checker) smoke(method string, path string, token string) []string {
+	query := url.Values{"broadcaster_id": {c.broadcaster.ID}, "user_id": {c.broadcaster.ID}}
+	resp, err := c.do(method, path, query, nil, token)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if resp.status >= 500 {
+		return []string{fmt.Sprintf("Request: status %v%v", resp.status, errorMessage(resp.body))}
+	}
+	// some endpoints aren't JSON, such as the iCalendar of a schedule
+	if strings.Contains(resp.contentType, "json") && len(bytes.TrimSpace(resp.body)) != 0 && !json.Valid(resp.body) {
+		return []string{"Request: body is not valid JSON"}
+	}
+	return nil
+}
+
+func (c *checker) find(method string, path string) (spec.Endpoint, bool) {
+	for _, e := range c.spec {
+		if e.Method == method && e.Path == path {
+			return e, true
+		}
+	}
+	return spec.Endpoint{}, false
+}
+
+// roles works out who the IDs of a request are. The user is the broadcaster, who the requests are authorized as, unless
+// a moderator acts on them, such as to ban them. When a request takes both a user and a broadcaster, such as to check the
+// user's subscription, the broadcaster is the other user.
+func (c *checker) roles(e spec.Endpoint) roles {
+	required := map[string]bool{}
+	for _, p := range e.Parameters {
+		required[p.Name] = p.Required
+	}
+	if e.Body != nil {
+		for _, r := range e.Body.Required {
+			required[r] = true
+		}
+	}
+
+	r := roles{broadcaster: c.broadcaster.ID, user: c.broadcaster.ID}
+	switch {
+	case required["moderator_id"]:
+		r.user = c.other.ID
+	case required["user_id"] && required["broadcaster_id"]:
+		r.broadcaster = c.other.ID
+	}
+	return r
+}
+
+// validRequest fills in the required query parameters and body fields of an endpoint
+func (c *checker) validRequest(e spec.Endpoint, r roles, token string) (url.Values, []byte) {
+	query := url.Values{}
+	for _, p := range e.Parameters {
+		if p.Required {
+			query.Set(p.Name, c.queryValue(e.Path, p, r, token))
+		}
+	}
+
+	if e.Body == nil {
+		return query, nil
+	}
+	body, _ := json.Marshal(c.value(e.Path, "", *e.Body, r, token))
+	return query, body
+}
+
+// optionalIDs returns the optional parameters of an endpoint that an ID of the generated data can be given for
+func optionalIDs(e spec.Endpoint) []spec.Parameter {
+	var params []spec.Parameter
+	for _, p := range e.Parameters {
+		if !p.Required && (p.Name == "broadcaster_id" || p.Name == "user_id" || p.Name == "id") {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+func (c *checker) queryValue(path string, p spec.Parameter, r roles, token string) string {
+	s := p.Schema
+	if s.Type == "array" && s.Items != nil {
+		s = *s.Items
+	}
+	v := c.value(path, p.Name, s, r, token)
+	if str, ok := v.(string); ok {
+		return str
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// value generates a valid value of a schema, using the field's name to pick IDs of the generated data
+func (c *checker) value(path string, name string, s spec.Schema, r roles, token string) any {
+	if s.Example != nil {
+		return s.Example
+	}
+	if len(s.Enum) != 0 {
+		return s.Enum[0]
+	}
+
+	switch s.Type {
+	case "object":
+		obj := map[string]any{}
+		for _, field := range s.Required {
+			if child, ok := s.Properties[field]; ok {
+				obj[field] = c.value(path, field, *child, r, token)
+			}
+		}
+		return obj
+	case "array":
+		if s.Items == nil {
+			return []any{}
+		}
+		// two items, since lists such as the choices of a poll need at least two
+		return []any{c.value(path, name, *s.Items, r, token), c.value(path, name, *s.Items, r, token)}
+	case "integer", "number":
+		return 30
+	case "boolean":
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(name, "to_"):
+		return c.other.ID
+	case name == "id":
+		return c.existingID(path, token)
+	case name == "user_id":
+		return r.user
+	case name == "broadcaster_id":
+		return r.broadcaster
+	case strings.HasSuffix(name, "_id") && (strings.Contains(name, "broadcaster") || strings.Contains(name, "user") ||
+		strings.Contains(name, "moderator") || strings.Contains(name, "sender")):
+		return c.broadcaster.ID
+	case strings.HasSuffix(name, "login"):
+		return c.broadcaster.Login
+	case strings.HasSuffix(name, "_at"):
+		return time.Now().UTC().Add(-24 * time.Hour).Format(time.RFC3339)
+	}
+	return "test"
+}
+
+// existingID returns the ID of the first item of the GET method of path, such as a poll to end or a video to delete
+func (c *checker) existingID(path string, token string) string {
+	if id, ok := c.ids[path]; ok {
+		return id
+	}
+
+	query := url.Values{}
+	if e, ok := c.find(http.MethodGet, path); ok {
+		for _, p := range e.Parameters {
+			switch p.Name {
+			case "broadcaster_id", "user_id":
+				query.Set(p.Name, c.broadcaster.ID)
+			}
+		}
+	} else {
+		query.Set("broadcaster_id", c.broadcaster.ID)
+	}
+
+	id := "1"
+	resp, err := c.do(http.MethodGet, path, query, nil, token)
+	if err == nil {
+		var items struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if json.Unmarshal(resp.body, &items) == nil && len(items.Data) != 0 && items.Data[0].ID != "" {
+			id = items.Data[0].ID
+		}
+	}
+	c.ids[path] = id
+	return id
+}
+
+func (c *checker) do(method string, path string, query url.Values, body []byte, token string) (response, error) {
+	u := c.server.HelixURL() + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := request.NewRequest(method, u, r)
+	if err != nil {
+		return response{}, err
+	}
+	req.Header.Set("Client-ID", c.server.ClientID)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	return response{status: resp.StatusCode, body: respBody, contentType: resp.Header.Get("Content-Type")}, err
+}
+
+func expectStatus(check string, expected int, resp response, err error) []string {
+	if err != nil {
+		return []string{fmt.Sprintf("%v: %v", check, err)}
+	}
+	if resp.status != expected {
+		return []string{fmt.Sprintf("%v: expected status %v, got %v%v", check, expected, resp.status, errorMessage(resp.body))}
+	}
+	return nil
+}
+
+// errorMessage returns the message of an error response, to explain an unexpected status
+func errorMessage(body []byte) string {
+	var resp struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &resp) != nil || resp.Message == "" {
+		return ""
+	}
+	return " (" + resp.Message + ")"
+}
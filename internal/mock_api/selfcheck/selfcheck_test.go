@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package selfcheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/api/spec"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestRun(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	results, err := Run(Options{Users: 5})
+	a.Nil(err)
+
+	byEndpoint := map[string]Result{}
+	for _, r := range results {
+		byEndpoint[r.Method+" "+r.Path] = r
+	}
+	for _, e := range endpoints.All() {
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			if e.ValidMethod(method) {
+				a.Contains(byEndpoint, method+" "+e.Path())
+			}
+		}
+	}
+
+	a.Equal(StatusMatch, byEndpoint["GET /users"].Status, byEndpoint["GET /users"].Problems)
+	a.Equal(StatusMatch, byEndpoint["GET /chat/settings"].Status, byEndpoint["GET /chat/settings"].Problems)
+	a.Equal(StatusSkipped, byEndpoint["PUT /extensions/configurations"].Status)
+	a.Equal(StatusNoSpec, byEndpoint["GET /bits/cheermotes"].Status)
+}
+
+func TestRunSpec(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	s, err := spec.Parse([]byte(`{"paths":{
+		"/users":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{
+			"type":"object","required":["data"],"properties":{"data":{"type":"array","items":{
+				"type":"object","required":["id","potato"],"properties":{"id":{"type":"string"},"potato":{"type":"string"}}}}}}}}}}}},
+		"/potatoes":{"get":{}}}}`))
+	a.Nil(err)
+
+	results, err := Run(Options{Spec: s, Users: 3})
+	a.Nil(err)
+
+	var users, potatoes Result
+	for _, r := range results {
+		switch r.Method + " " + r.Path {
+		case "GET /users":
+			users = r
+		case "GET /potatoes":
+			potatoes = r
+		}
+	}
+	a.Equal(StatusDiverges, users.Status)
+	a.Contains(users.Problems, "Valid request: body.data[0] is missing required field potato")
+	a.Equal(StatusNotMocked, potatoes.Status)
+}
+
+func TestRunDeliversNoEvents(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var forwarded []string
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Header.Get("Twitch-Eventsub-Subscription-Type"))
+	}))
+	defer forward.Close()
+	viper.Set("forwardAddress", forward.URL)
+	defer viper.Set("forwardAddress", "")
+
+	viper.Set("DB_FILENAME", "test-selfcheck.db")
+	defer viper.Set("DB_FILENAME", "test-eventCache.db")
+	home, err := util.GetApplicationDir()
+	a.Nil(err)
+	cliDB := filepath.Join(home, "test-selfcheck.db")
+	os.Remove(cliDB)
+	defer os.Remove(cliDB)
+
+	_, err = Run(Options{Users: 5})
+	a.Nil(err)
+
+	// requests such as POST /chat/messages and POST /whispers don't reach the CLI's forward address or database
+	a.Empty(forwarded)
+	_, err = os.Stat(cliDB)
+	a.True(os.IsNotExist(err))
+}
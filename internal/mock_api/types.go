@@ -13,3 +13,9 @@ type MockEndpoint interface {
 	ValidMethod(string) bool
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
 }
+
+// ExtensionEndpoint is implemented by endpoints that are authorized with an Extension JWT instead of an OAuth token
+type ExtensionEndpoint interface {
+	MockEndpoint
+	RequiresExtensionJWT() bool
+}
@@ -33,7 +33,7 @@ type AppAccessTokenEndpointResponse struct {
 func (e AppAccessTokenEndpoint) Path() string { return "/token" }
 
 func (e AppAccessTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
+	db := r.Context().Value("db").(database.CLIDatabase)
 
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -4,8 +4,6 @@ package mock_auth
 
 import (
 	"net/http"
-
-	"github.com/twitchdev/twitch-cli/internal/database"
 )
 
 type AuthEndpoint interface {
@@ -13,8 +11,6 @@ type AuthEndpoint interface {
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
 }
 
-var db database.CLIDatabase
-
 const APP_ACCES_TOKEN = "app_access"
 const USER_ACCESS_TOKEN = "user_access"
 
@@ -47,6 +43,8 @@ var validScopesByTokenType = map[string]map[string]bool{
 		"channel:read:stream_key":           true,
 		"channel:read:subscriptions":        true,
 		"channel:read:vips":                 true,
+		"chat:edit":                         true,
+		"chat:read":                         true,
 		"clips:edit":                        true,
 		"moderation:read":                   true,
 		"moderator:manage:announcements":    true,
@@ -70,9 +68,11 @@ var validScopesByTokenType = map[string]map[string]bool{
 		"user:manage:whispers":              true,
 		"user:read:blocked_users":           true,
 		"user:read:broadcast":               true,
+		"user:read:chat":                    true,
 		"user:read:email":                   true,
 		"user:read:follows":                 true,
 		"user:read:subscriptions":           true,
+		"user:write:chat":                   true,
 	},
 }
 
@@ -84,6 +84,11 @@ func All() []AuthEndpoint {
 	}
 }
 
+// IsValidScope returns whether a token of tokenType can be authorized with scope.
+func IsValidScope(scope string, tokenType string) bool {
+	return validScopesByTokenType[tokenType][scope]
+}
+
 func areValidScopes(scopes []string, tokenType string) bool {
 	if tokenType != APP_ACCES_TOKEN && tokenType != USER_ACCESS_TOKEN {
 		return false
@@ -16,7 +16,7 @@ import (
 type UserTokenEndpoint struct{}
 
 func (e UserTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
+	db := r.Context().Value("db").(database.CLIDatabase)
 
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -24,7 +24,7 @@ type ValidateTokenEndpointResponse struct {
 func (e ValidateTokenEndpoint) Path() string { return "/validate" }
 
 func (e ValidateTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
+	db := r.Context().Value("db").(database.CLIDatabase)
 
 	if r.Method != http.MethodGet {
 		w.WriteHeader(http.StatusMethodNotAllowed)
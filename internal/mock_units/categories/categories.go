@@ -11,13 +11,9 @@ import (
 
 type Endpoint struct{}
 
-var db database.CLIDatabase
-
 func (e Endpoint) Path() string { return "/categories" }
 
 func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getCategories(w, r)
@@ -28,6 +24,7 @@ func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getCategories(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	c, err := db.NewQuery(r, 100).GetCategories(database.Category{})
 	if err != nil {
 		w.Write([]byte(err.Error()))
@@ -7,27 +7,28 @@ import (
 	"net/http"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
 type Endpoint struct{}
 
-var db database.CLIDatabase
-
 func (e Endpoint) Path() string { return "/clients" }
 
 func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getClients(w, r)
 		break
+	case http.MethodPost:
+		postClients(w, r)
+		break
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
 
 func getClients(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	s, err := db.NewQuery(r, 100).GetAuthenticationClient(database.AuthenticationClient{})
 	if err != nil {
 		w.Write([]byte(err.Error()))
@@ -40,3 +41,30 @@ func getClients(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Write(j)
 }
+
+// postClients creates a client, such as for `twitch api --target mock` when the database has none
+func postClients(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = "Mock API Client"
+	}
+
+	c, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{
+		ID:   util.RandomClientID(),
+		Name: name,
+	}, false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	j, err := json.Marshal(database.DBResponse{Data: []database.AuthenticationClient{c}, Total: 1})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write(j)
+}
@@ -11,13 +11,9 @@ import (
 
 type Endpoint struct{}
 
-var db database.CLIDatabase
-
 func (e Endpoint) Path() string { return "/tags" }
 
 func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getTags(w, r)
@@ -28,6 +24,7 @@ func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getTags(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
 	s, err := db.NewQuery(r, 100).GetTags(database.Tag{})
 	if err != nil {
 		w.Write([]byte(err.Error()))
@@ -11,13 +11,9 @@ import (
 
 type Endpoint struct{}
 
-var db database.CLIDatabase
-
 func (e Endpoint) Path() string { return "/users" }
 
 func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	db = r.Context().Value("db").(database.CLIDatabase)
-
 	switch r.Method {
 	case http.MethodGet:
 		getUsers(w, r)
@@ -27,8 +23,10 @@ func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getUsers lists the first 100 users, or the user with the login query parameter, such as for `twitch api --as-user`
 func getUsers(w http.ResponseWriter, r *http.Request) {
-	u, err := db.NewQuery(nil, 100).GetUsers(database.User{})
+	db := r.Context().Value("db").(database.CLIDatabase)
+	u, err := db.NewQuery(nil, 100).GetUsers(database.User{UserLogin: r.URL.Query().Get("login")})
 	if err != nil {
 		w.Write([]byte(err.Error()))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type AutomaticRewardEmote struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AutomaticRewardRedemptionEventSubEvent struct {
+	BroadcasterUserID    string                 `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                 `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                 `json:"broadcaster_user_name"`
+	UserID               string                 `json:"user_id"`
+	UserLogin            string                 `json:"user_login"`
+	UserName             string                 `json:"user_name"`
+	ID                   string                 `json:"id"`
+	Reward               AutomaticReward        `json:"reward"`
+	Message              AutomaticRewardMessage `json:"message"`
+	UserInput            string                 `json:"user_input"`
+	RedeemedAt           string                 `json:"redeemed_at"`
+}
+
+type AutomaticReward struct {
+	Type          string                `json:"type"`
+	Cost          int64                 `json:"cost"`
+	UnlockedEmote *AutomaticRewardEmote `json:"unlocked_emote"`
+}
+
+type AutomaticRewardMessage struct {
+	Text   string                        `json:"text"`
+	Emotes []AutomaticRewardMessageEmote `json:"emotes"`
+}
+
+type AutomaticRewardMessageEmote struct {
+	ID    string `json:"id"`
+	Begin int    `json:"begin"`
+	End   int    `json:"end"`
+}
+
+type AutomaticRewardRedemptionEventSubResponse struct {
+	Subscription EventsubSubscription                   `json:"subscription"`
+	Event        AutomaticRewardRedemptionEventSubEvent `json:"event"`
+}
+
+type AutomaticRewardRedemptionV2EventSubEvent struct {
+	BroadcasterUserID    string                    `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                    `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                    `json:"broadcaster_user_name"`
+	UserID               string                    `json:"user_id"`
+	UserLogin            string                    `json:"user_login"`
+	UserName             string                    `json:"user_name"`
+	ID                   string                    `json:"id"`
+	Reward               AutomaticRewardV2         `json:"reward"`
+	Message              *AutomaticRewardV2Message `json:"message"`
+	RedeemedAt           string                    `json:"redeemed_at"`
+}
+
+type AutomaticRewardV2 struct {
+	Type          string                `json:"type"`
+	ChannelPoints int64                 `json:"channel_points"`
+	Emote         *AutomaticRewardEmote `json:"emote"`
+}
+
+type AutomaticRewardV2Message struct {
+	Text      string                           `json:"text"`
+	Fragments []AutomaticRewardMessageFragment `json:"fragments"`
+}
+
+type AutomaticRewardMessageFragment struct {
+	Type  string                               `json:"type"`
+	Text  string                               `json:"text"`
+	Emote *AutomaticRewardMessageFragmentEmote `json:"emote"`
+}
+
+type AutomaticRewardMessageFragmentEmote struct {
+	ID string `json:"id"`
+}
+
+type AutomaticRewardRedemptionV2EventSubResponse struct {
+	Subscription EventsubSubscription                     `json:"subscription"`
+	Event        AutomaticRewardRedemptionV2EventSubEvent `json:"event"`
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type AutomodMessageHoldEventSubResponse struct {
+	Subscription EventsubSubscription            `json:"subscription"`
+	Event        AutomodMessageHoldEventSubEvent `json:"event"`
+}
+
+type AutomodMessageHoldEventSubEvent struct {
+	BroadcasterUserID    string          `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string          `json:"broadcaster_user_login"`
+	BroadcasterUserName  string          `json:"broadcaster_user_name"`
+	UserID               string          `json:"user_id"`
+	UserLogin            string          `json:"user_login"`
+	UserName             string          `json:"user_name"`
+	MessageID            string          `json:"message_id"`
+	Message              ChatMessageBody `json:"message"`
+	Category             string          `json:"category"`
+	Level                int64           `json:"level"`
+	HeldAt               string          `json:"held_at"`
+}
+
+type AutomodMessageUpdateEventSubResponse struct {
+	Subscription EventsubSubscription              `json:"subscription"`
+	Event        AutomodMessageUpdateEventSubEvent `json:"event"`
+}
+
+type AutomodMessageUpdateEventSubEvent struct {
+	AutomodMessageHoldEventSubEvent
+	ModeratorUserID    string `json:"moderator_user_id"`
+	ModeratorUserLogin string `json:"moderator_user_login"`
+	ModeratorUserName  string `json:"moderator_user_name"`
+	Status             string `json:"status"`
+}
+
+type AutomodSettingsUpdateEventSubResponse struct {
+	Subscription EventsubSubscription               `json:"subscription"`
+	Event        AutomodSettingsUpdateEventSubEvent `json:"event"`
+}
+
+type AutomodSettingsUpdateEventSubEvent struct {
+	BroadcasterUserID       string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin    string `json:"broadcaster_user_login"`
+	BroadcasterUserName     string `json:"broadcaster_user_name"`
+	ModeratorUserID         string `json:"moderator_user_id"`
+	ModeratorUserLogin      string `json:"moderator_user_login"`
+	ModeratorUserName       string `json:"moderator_user_name"`
+	Bits                    int64  `json:"bits"`
+	Chat                    int64  `json:"chat"`
+	Disability              int64  `json:"disability"`
+	Misogyny                int64  `json:"misogyny"`
+	RaceEthnicityOrReligion int64  `json:"race_ethnicity_or_religion"`
+	SexBasedTerms           int64  `json:"sex_based_terms"`
+	SexualitySexOrGender    int64  `json:"sexuality_sex_or_gender"`
+	Swearing                int64  `json:"swearing"`
+	OverallLevel            *int64 `json:"overall_level"`
+}
+
+type AutomodTermsUpdateEventSubResponse struct {
+	Subscription EventsubSubscription            `json:"subscription"`
+	Event        AutomodTermsUpdateEventSubEvent `json:"event"`
+}
+
+type AutomodTermsUpdateEventSubEvent struct {
+	BroadcasterUserID    string   `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string   `json:"broadcaster_user_login"`
+	BroadcasterUserName  string   `json:"broadcaster_user_name"`
+	ModeratorUserID      string   `json:"moderator_user_id"`
+	ModeratorUserLogin   string   `json:"moderator_user_login"`
+	ModeratorUserName    string   `json:"moderator_user_name"`
+	Action               string   `json:"action"`
+	FromAutomod          bool     `json:"from_automod"`
+	Terms                []string `json:"terms"`
+}
+
+type SuspiciousUserMessageEventSubResponse struct {
+	Subscription EventsubSubscription               `json:"subscription"`
+	Event        SuspiciousUserMessageEventSubEvent `json:"event"`
+}
+
+type SuspiciousUserMessageEventSubEvent struct {
+	BroadcasterUserID    string                `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                `json:"broadcaster_user_name"`
+	UserID               string                `json:"user_id"`
+	UserLogin            string                `json:"user_login"`
+	UserName             string                `json:"user_name"`
+	LowTrustStatus       string                `json:"low_trust_status"`
+	SharedBanChannelIDs  []string              `json:"shared_ban_channel_ids"`
+	Types                []string              `json:"types"`
+	BanEvasionEvaluation string                `json:"ban_evasion_evaluation"`
+	Message              SuspiciousUserMessage `json:"message"`
+}
+
+type SuspiciousUserMessage struct {
+	MessageID string                `json:"message_id"`
+	Text      string                `json:"text"`
+	Fragments []ChatMessageFragment `json:"fragments"`
+}
+
+type SuspiciousUserUpdateEventSubResponse struct {
+	Subscription EventsubSubscription              `json:"subscription"`
+	Event        SuspiciousUserUpdateEventSubEvent `json:"event"`
+}
+
+type SuspiciousUserUpdateEventSubEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	ModeratorUserID      string `json:"moderator_user_id"`
+	ModeratorUserLogin   string `json:"moderator_user_login"`
+	ModeratorUserName    string `json:"moderator_user_name"`
+	UserID               string `json:"user_id"`
+	UserLogin            string `json:"user_login"`
+	UserName             string `json:"user_name"`
+	LowTrustStatus       string `json:"low_trust_status"`
+}
@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type BitsUseEventSubEvent struct {
+	BroadcasterUserID    string          `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string          `json:"broadcaster_user_login"`
+	BroadcasterUserName  string          `json:"broadcaster_user_name"`
+	UserID               string          `json:"user_id"`
+	UserLogin            string          `json:"user_login"`
+	UserName             string          `json:"user_name"`
+	Bits                 int64           `json:"bits"`
+	Type                 string          `json:"type"`
+	Message              *BitsUseMessage `json:"message"`
+	PowerUp              *BitsUsePowerUp `json:"power_up"`
+}
+
+type BitsUseMessage struct {
+	Text      string                   `json:"text"`
+	Fragments []BitsUseMessageFragment `json:"fragments"`
+}
+
+type BitsUseMessageFragment struct {
+	Type      string                `json:"type"`
+	Text      string                `json:"text"`
+	Cheermote *ChatMessageCheermote `json:"cheermote"`
+	Emote     *ChatMessageEmote     `json:"emote"`
+}
+
+type BitsUsePowerUp struct {
+	Type            string                `json:"type"`
+	Emote           *AutomaticRewardEmote `json:"emote"`
+	MessageEffectID *string               `json:"message_effect_id"`
+}
+
+type BitsUseEventSubResponse struct {
+	Subscription EventsubSubscription `json:"subscription"`
+	Event        BitsUseEventSubEvent `json:"event"`
+}
@@ -0,0 +1,221 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type ChatMessageFragment struct {
+	Type      string                `json:"type"`
+	Text      string                `json:"text"`
+	Cheermote *ChatMessageCheermote `json:"cheermote"`
+	Emote     *ChatMessageEmote     `json:"emote"`
+	Mention   *ChatMessageMention   `json:"mention"`
+}
+
+type ChatMessageCheermote struct {
+	Prefix string `json:"prefix"`
+	Bits   int64  `json:"bits"`
+	Tier   int64  `json:"tier"`
+}
+
+type ChatMessageEmote struct {
+	ID         string   `json:"id"`
+	EmoteSetID string   `json:"emote_set_id"`
+	OwnerID    string   `json:"owner_id"`
+	Format     []string `json:"format"`
+}
+
+type ChatMessageMention struct {
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	UserLogin string `json:"user_login"`
+}
+
+type ChatMessageBadge struct {
+	SetID string `json:"set_id"`
+	ID    string `json:"id"`
+	Info  string `json:"info"`
+}
+
+type ChatMessageBody struct {
+	Text      string                `json:"text"`
+	Fragments []ChatMessageFragment `json:"fragments"`
+}
+
+type ChatMessageCheer struct {
+	Bits int64 `json:"bits"`
+}
+
+type ChatMessageReply struct {
+	ParentMessageID   string `json:"parent_message_id"`
+	ParentMessageBody string `json:"parent_message_body"`
+	ParentUserID      string `json:"parent_user_id"`
+	ParentUserName    string `json:"parent_user_name"`
+	ParentUserLogin   string `json:"parent_user_login"`
+	ThreadMessageID   string `json:"thread_message_id"`
+	ThreadUserID      string `json:"thread_user_id"`
+	ThreadUserName    string `json:"thread_user_name"`
+	ThreadUserLogin   string `json:"thread_user_login"`
+}
+
+// channel.chat.message
+
+type ChatMessageEventSubResponse struct {
+	Subscription EventsubSubscription     `json:"subscription"`
+	Event        ChatMessageEventSubEvent `json:"event"`
+}
+
+type ChatMessageEventSubEvent struct {
+	BroadcasterUserID           string             `json:"broadcaster_user_id"`
+	BroadcasterUserLogin        string             `json:"broadcaster_user_login"`
+	BroadcasterUserName         string             `json:"broadcaster_user_name"`
+	ChatterUserID               string             `json:"chatter_user_id"`
+	ChatterUserLogin            string             `json:"chatter_user_login"`
+	ChatterUserName             string             `json:"chatter_user_name"`
+	MessageID                   string             `json:"message_id"`
+	Message                     ChatMessageBody    `json:"message"`
+	Color                       string             `json:"color"`
+	Badges                      []ChatMessageBadge `json:"badges"`
+	MessageType                 string             `json:"message_type"`
+	Cheer                       *ChatMessageCheer  `json:"cheer"`
+	Reply                       *ChatMessageReply  `json:"reply"`
+	ChannelPointsCustomRewardID *string            `json:"channel_points_custom_reward_id"`
+}
+
+// channel.chat.notification
+
+type ChatNotificationEventSubResponse struct {
+	Subscription EventsubSubscription          `json:"subscription"`
+	Event        ChatNotificationEventSubEvent `json:"event"`
+}
+
+type ChatNotificationEventSubEvent struct {
+	BroadcasterUserID    string                            `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                            `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                            `json:"broadcaster_user_name"`
+	ChatterUserID        string                            `json:"chatter_user_id"`
+	ChatterUserLogin     string                            `json:"chatter_user_login"`
+	ChatterUserName      string                            `json:"chatter_user_name"`
+	ChatterIsAnonymous   bool                              `json:"chatter_is_anonymous"`
+	Color                string                            `json:"color"`
+	Badges               []ChatMessageBadge                `json:"badges"`
+	SystemMessage        string                            `json:"system_message"`
+	MessageID            string                            `json:"message_id"`
+	Message              ChatMessageBody                   `json:"message"`
+	NoticeType           string                            `json:"notice_type"`
+	Sub                  *ChatNotificationSub              `json:"sub"`
+	Resub                *ChatNotificationResub            `json:"resub"`
+	SubGift              *ChatNotificationSubGift          `json:"sub_gift"`
+	CommunitySubGift     *ChatNotificationCommunitySubGift `json:"community_sub_gift"`
+	GiftPaidUpgrade      *ChatNotificationGiftPaidUpgrade  `json:"gift_paid_upgrade"`
+	PrimePaidUpgrade     *ChatNotificationPrimePaidUpgrade `json:"prime_paid_upgrade"`
+	PayItForward         *ChatNotificationPayItForward     `json:"pay_it_forward"`
+	Raid                 *ChatNotificationRaid             `json:"raid"`
+	Unraid               *struct{}                         `json:"unraid"`
+	Announcement         *ChatNotificationAnnouncement     `json:"announcement"`
+	BitsBadgeTier        *ChatNotificationBitsBadgeTier    `json:"bits_badge_tier"`
+	CharityDonation      *ChatNotificationCharityDonation  `json:"charity_donation"`
+}
+
+type ChatNotificationSub struct {
+	SubTier        string `json:"sub_tier"`
+	IsPrime        bool   `json:"is_prime"`
+	DurationMonths int    `json:"duration_months"`
+}
+
+type ChatNotificationResub struct {
+	CumulativeMonths  int     `json:"cumulative_months"`
+	DurationMonths    int     `json:"duration_months"`
+	StreakMonths      *int    `json:"streak_months"`
+	SubTier           string  `json:"sub_tier"`
+	IsPrime           bool    `json:"is_prime"`
+	IsGift            bool    `json:"is_gift"`
+	GifterIsAnonymous *bool   `json:"gifter_is_anonymous"`
+	GifterUserID      *string `json:"gifter_user_id"`
+	GifterUserName    *string `json:"gifter_user_name"`
+	GifterUserLogin   *string `json:"gifter_user_login"`
+}
+
+type ChatNotificationSubGift struct {
+	DurationMonths     int     `json:"duration_months"`
+	CumulativeTotal    *int    `json:"cumulative_total"`
+	RecipientUserID    string  `json:"recipient_user_id"`
+	RecipientUserName  string  `json:"recipient_user_name"`
+	RecipientUserLogin string  `json:"recipient_user_login"`
+	SubTier            string  `json:"sub_tier"`
+	CommunityGiftID    *string `json:"community_gift_id"`
+}
+
+type ChatNotificationCommunitySubGift struct {
+	ID              string `json:"id"`
+	Total           int    `json:"total"`
+	SubTier         string `json:"sub_tier"`
+	CumulativeTotal *int   `json:"cumulative_total"`
+}
+
+type ChatNotificationGiftPaidUpgrade struct {
+	GifterIsAnonymous bool    `json:"gifter_is_anonymous"`
+	GifterUserID      *string `json:"gifter_user_id"`
+	GifterUserName    *string `json:"gifter_user_name"`
+	GifterUserLogin   *string `json:"gifter_user_login"`
+}
+
+type ChatNotificationPrimePaidUpgrade struct {
+	SubTier string `json:"sub_tier"`
+}
+
+type ChatNotificationPayItForward struct {
+	GifterIsAnonymous bool    `json:"gifter_is_anonymous"`
+	GifterUserID      *string `json:"gifter_user_id"`
+	GifterUserName    *string `json:"gifter_user_name"`
+	GifterUserLogin   *string `json:"gifter_user_login"`
+}
+
+type ChatNotificationRaid struct {
+	UserID          string `json:"user_id"`
+	UserName        string `json:"user_name"`
+	UserLogin       string `json:"user_login"`
+	ViewerCount     int64  `json:"viewer_count"`
+	ProfileImageURL string `json:"profile_image_url"`
+}
+
+type ChatNotificationAnnouncement struct {
+	Color string `json:"color"`
+}
+
+type ChatNotificationBitsBadgeTier struct {
+	Tier int64 `json:"tier"`
+}
+
+type ChatNotificationCharityDonation struct {
+	CharityName string                     `json:"charity_name"`
+	Amount      CharityEventSubEventAmount `json:"amount"`
+}
+
+// channel.chat.clear
+
+type ChatClearEventSubResponse struct {
+	Subscription EventsubSubscription   `json:"subscription"`
+	Event        ChatClearEventSubEvent `json:"event"`
+}
+
+type ChatClearEventSubEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+}
+
+// channel.chat.message_delete
+
+type ChatMessageDeleteEventSubResponse struct {
+	Subscription EventsubSubscription           `json:"subscription"`
+	Event        ChatMessageDeleteEventSubEvent `json:"event"`
+}
+
+type ChatMessageDeleteEventSubEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	TargetUserID         string `json:"target_user_id"`
+	TargetUserLogin      string `json:"target_user_login"`
+	TargetUserName       string `json:"target_user_name"`
+	MessageID            string `json:"message_id"`
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type GuestStarSessionEventSubEvent struct {
+	BroadcasterUserID    string  `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string  `json:"broadcaster_user_login"`
+	BroadcasterUserName  string  `json:"broadcaster_user_name"`
+	SessionID            string  `json:"session_id"`
+	StartedAt            string  `json:"started_at"`
+	EndedAt              *string `json:"ended_at,omitempty"`
+	HostUserID           string  `json:"host_user_id"`
+	HostUserLogin        string  `json:"host_user_login"`
+	HostUserName         string  `json:"host_user_name"`
+}
+
+type GuestStarSessionEventSubResponse struct {
+	Subscription EventsubSubscription          `json:"subscription"`
+	Event        GuestStarSessionEventSubEvent `json:"event"`
+}
+
+type GuestStarGuestUpdateEventSubEvent struct {
+	BroadcasterUserID    string  `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string  `json:"broadcaster_user_login"`
+	BroadcasterUserName  string  `json:"broadcaster_user_name"`
+	SessionID            string  `json:"session_id"`
+	ModeratorUserID      *string `json:"moderator_user_id"`
+	ModeratorUserLogin   *string `json:"moderator_user_login"`
+	ModeratorUserName    *string `json:"moderator_user_name"`
+	GuestUserID          *string `json:"guest_user_id"`
+	GuestUserLogin       *string `json:"guest_user_login"`
+	GuestUserName        *string `json:"guest_user_name"`
+	SlotID               *string `json:"slot_id"`
+	State                string  `json:"state"`
+	HostUserID           string  `json:"host_user_id"`
+	HostUserLogin        string  `json:"host_user_login"`
+	HostUserName         string  `json:"host_user_name"`
+	HostVideoEnabled     *bool   `json:"host_video_enabled"`
+	HostAudioEnabled     *bool   `json:"host_audio_enabled"`
+	HostVolume           *int    `json:"host_volume"`
+}
+
+type GuestStarGuestUpdateEventSubResponse struct {
+	Subscription EventsubSubscription              `json:"subscription"`
+	Event        GuestStarGuestUpdateEventSubEvent `json:"event"`
+}
+
+type GuestStarSettingsUpdateEventSubEvent struct {
+	BroadcasterUserID           string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin        string `json:"broadcaster_user_login"`
+	BroadcasterUserName         string `json:"broadcaster_user_name"`
+	IsModeratorSendLiveEnabled  bool   `json:"is_moderator_send_live_enabled"`
+	SlotCount                   int    `json:"slot_count"`
+	IsBrowserSourceAudioEnabled bool   `json:"is_browser_source_audio_enabled"`
+	GroupLayout                 string `json:"group_layout"`
+}
+
+type GuestStarSettingsUpdateEventSubResponse struct {
+	Subscription EventsubSubscription                 `json:"subscription"`
+	Event        GuestStarSettingsUpdateEventSubEvent `json:"event"`
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type ModerateEventSubResponse struct {
+	Subscription EventsubSubscription  `json:"subscription"`
+	Event        ModerateEventSubEvent `json:"event"`
+}
+
+type ModerateV2EventSubResponse struct {
+	Subscription EventsubSubscription    `json:"subscription"`
+	Event        ModerateV2EventSubEvent `json:"event"`
+}
+
+// ModerateEventSubEvent is the channel.moderate event. Only the object matching Action is set; the rest are null.
+type ModerateEventSubEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	ModeratorUserID      string `json:"moderator_user_id"`
+	ModeratorUserLogin   string `json:"moderator_user_login"`
+	ModeratorUserName    string `json:"moderator_user_name"`
+	Action               string `json:"action"`
+
+	Followers    *ModerateFollowers    `json:"followers"`
+	Slow         *ModerateSlow         `json:"slow"`
+	Vip          *ModerateUser         `json:"vip"`
+	Unvip        *ModerateUser         `json:"unvip"`
+	Mod          *ModerateUser         `json:"mod"`
+	Unmod        *ModerateUser         `json:"unmod"`
+	Ban          *ModerateBan          `json:"ban"`
+	Unban        *ModerateUser         `json:"unban"`
+	Timeout      *ModerateTimeout      `json:"timeout"`
+	Untimeout    *ModerateUser         `json:"untimeout"`
+	Raid         *ModerateRaid         `json:"raid"`
+	Unraid       *ModerateUser         `json:"unraid"`
+	Delete       *ModerateDelete       `json:"delete"`
+	AutomodTerms *ModerateAutomodTerms `json:"automod_terms"`
+	UnbanRequest *ModerateUnbanRequest `json:"unban_request"`
+}
+
+// ModerateV2EventSubEvent is version 2 of the channel.moderate event, which adds warnings.
+type ModerateV2EventSubEvent struct {
+	ModerateEventSubEvent
+	Warn *ModerateWarn `json:"warn"`
+}
+
+type ModerateUser struct {
+	UserID    string `json:"user_id"`
+	UserLogin string `json:"user_login"`
+	UserName  string `json:"user_name"`
+}
+
+type ModerateFollowers struct {
+	FollowDurationMinutes int64 `json:"follow_duration_minutes"`
+}
+
+type ModerateSlow struct {
+	WaitTimeSeconds int64 `json:"wait_time_seconds"`
+}
+
+type ModerateBan struct {
+	UserID    string `json:"user_id"`
+	UserLogin string `json:"user_login"`
+	UserName  string `json:"user_name"`
+	Reason    string `json:"reason"`
+}
+
+type ModerateTimeout struct {
+	UserID    string `json:"user_id"`
+	UserLogin string `json:"user_login"`
+	UserName  string `json:"user_name"`
+	Reason    string `json:"reason"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type ModerateRaid struct {
+	UserID      string `json:"user_id"`
+	UserLogin   string `json:"user_login"`
+	UserName    string `json:"user_name"`
+	ViewerCount int64  `json:"viewer_count"`
+}
+
+type ModerateDelete struct {
+	UserID      string `json:"user_id"`
+	UserLogin   string `json:"user_login"`
+	UserName    string `json:"user_name"`
+	MessageID   string `json:"message_id"`
+	MessageBody string `json:"message_body"`
+}
+
+type ModerateAutomodTerms struct {
+	Action      string   `json:"action"`
+	List        string   `json:"list"`
+	Terms       []string `json:"terms"`
+	FromAutomod bool     `json:"from_automod"`
+}
+
+type ModerateUnbanRequest struct {
+	IsApproved       bool   `json:"is_approved"`
+	UserID           string `json:"user_id"`
+	UserLogin        string `json:"user_login"`
+	UserName         string `json:"user_name"`
+	ModeratorMessage string `json:"moderator_message"`
+}
+
+type ModerateWarn struct {
+	UserID         string   `json:"user_id"`
+	UserLogin      string   `json:"user_login"`
+	UserName       string   `json:"user_name"`
+	Reason         string   `json:"reason"`
+	ChatRulesCited []string `json:"chat_rules_cited"`
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type SharedChatEventSubEvent struct {
+	SessionID                string                  `json:"session_id"`
+	BroadcasterUserID        string                  `json:"broadcaster_user_id"`
+	BroadcasterUserLogin     string                  `json:"broadcaster_user_login"`
+	BroadcasterUserName      string                  `json:"broadcaster_user_name"`
+	HostBroadcasterUserID    string                  `json:"host_broadcaster_user_id"`
+	HostBroadcasterUserLogin string                  `json:"host_broadcaster_user_login"`
+	HostBroadcasterUserName  string                  `json:"host_broadcaster_user_name"`
+	Participants             []SharedChatParticipant `json:"participants,omitempty"` // Not included in end events
+}
+
+type SharedChatParticipant struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+}
+
+type SharedChatEventSubResponse struct {
+	Subscription EventsubSubscription    `json:"subscription"`
+	Event        SharedChatEventSubEvent `json:"event"`
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type WarningSendEventSubEvent struct {
+	BroadcasterUserID    string   `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string   `json:"broadcaster_user_login"`
+	BroadcasterUserName  string   `json:"broadcaster_user_name"`
+	ModeratorUserID      string   `json:"moderator_user_id"`
+	ModeratorUserLogin   string   `json:"moderator_user_login"`
+	ModeratorUserName    string   `json:"moderator_user_name"`
+	UserID               string   `json:"user_id"`
+	UserLogin            string   `json:"user_login"`
+	UserName             string   `json:"user_name"`
+	Reason               *string  `json:"reason"`
+	ChatRulesCited       []string `json:"chat_rules_cited"`
+}
+
+type WarningAcknowledgeEventSubEvent struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	UserID               string `json:"user_id"`
+	UserLogin            string `json:"user_login"`
+	UserName             string `json:"user_name"`
+}
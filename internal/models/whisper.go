@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package models
+
+type WhisperMessageEventSubEvent struct {
+	FromUserID    string         `json:"from_user_id"`
+	FromUserLogin string         `json:"from_user_login"`
+	FromUserName  string         `json:"from_user_name"`
+	ToUserID      string         `json:"to_user_id"`
+	ToUserLogin   string         `json:"to_user_login"`
+	ToUserName    string         `json:"to_user_name"`
+	WhisperID     string         `json:"whisper_id"`
+	Whisper       WhisperMessage `json:"whisper"`
+}
+
+type WhisperMessage struct {
+	Text string `json:"text"`
+}
+
+type WhisperMessageEventSubResponse struct {
+	Subscription EventsubSubscription        `json:"subscription"`
+	Event        WhisperMessageEventSubEvent `json:"event"`
+}
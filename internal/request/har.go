@@ -0,0 +1,267 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// redactedQueryParameters are removed from the URLs of captured requests, along with the Authorization header
+var redactedQueryParameters = []string{"client_secret", "refresh_token"}
+
+const redacted = "REDACTED"
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// harTrailer closes the entries array and the document. It's written after each entry and then overwritten by the next.
+const harTrailer = "\n    ]\n  }\n}\n"
+
+// harRecorder appends each request to its file as it's made, keeping the file a complete HAR document however the
+// command exits, without rewriting the entries before it
+type harRecorder struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	entries int
+	failed  bool
+}
+
+func newHARRecorder(path string) *harRecorder {
+	return &harRecorder{path: path}
+}
+
+func (h *harRecorder) add(e harEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failed {
+		return
+	}
+	if err := h.write(e); err != nil {
+		// later requests aren't captured, as the file may no longer be valid
+		h.failed = true
+		log.Printf("Error writing HAR file: %v", err)
+	}
+}
+
+func (h *harRecorder) write(e harEntry) error {
+	if h.file == nil {
+		f, err := os.OpenFile(h.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		h.file = f
+
+		// the document is written up to the opening of its entries array
+		header, err := json.MarshalIndent(harFile{Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "twitch-cli", Version: util.GetVersion()},
+			Entries: []harEntry{},
+		}}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := h.file.Write(header[:bytes.LastIndexByte(header, '[')+1]); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.MarshalIndent(e, "      ", "  ")
+	if err != nil {
+		return err
+	}
+	separator := ",\n      "
+	if h.entries == 0 {
+		separator = "\n      "
+	}
+	if _, err := h.file.WriteString(separator + string(b) + harTrailer); err != nil {
+		return err
+	}
+	if _, err := h.file.Seek(-int64(len(harTrailer)), io.SeekCurrent); err != nil {
+		return err
+	}
+	h.entries++
+	return nil
+}
+
+func (h *harRecorder) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+	h.failed = true
+}
+
+type harTransport struct {
+	next     http.RoundTripper
+	recorder *harRecorder
+}
+
+func (t *harTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	wait := time.Since(start)
+
+	// the body is read here so it can be captured, then handed back to the caller unchanged
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	receive := time.Since(start) - wait
+
+	u := *r.URL
+	q := u.Query()
+	for _, p := range redactedQueryParameters {
+		if q.Has(p) {
+			q.Set(p, redacted)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	entry := harEntry{
+		StartedDateTime: start.UTC().Format(time.RFC3339Nano),
+		Time:            milliseconds(wait + receive),
+		Request: harRequest{
+			Method:      r.Method,
+			URL:         u.String(),
+			HTTPVersion: r.Proto,
+			Headers:     harHeaders(r.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harHeaders(resp.Header),
+			Content: harContent{
+				Size:     len(respBody),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     string(respBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Wait: milliseconds(wait), Receive: milliseconds(receive)},
+	}
+	for k, values := range q {
+		for _, v := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: k, Value: v})
+		}
+	}
+	if reqBody != nil {
+		entry.Request.PostData = &harPostData{MimeType: r.Header.Get("Content-Type"), Text: string(reqBody)}
+	}
+
+	t.recorder.add(entry)
+	return resp, nil
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for k, values := range h {
+		for _, v := range values {
+			if http.CanonicalHeaderKey(k) == "Authorization" {
+				v = redacted
+			}
+			headers = append(headers, harNameValue{Name: k, Value: v})
+		}
+	}
+	return headers
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,136 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package request
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestTransportCAFile(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	defer SetTransportOptions(TransportOptions{})
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(time.Second)
+	_, err := client.Get(ts.URL)
+	a.NotNil(err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	a.Nil(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0644))
+	a.Nil(SetTransportOptions(TransportOptions{CAFile: caFile}))
+
+	// clients created before the transport was configured use it too
+	resp, err := client.Get(ts.URL)
+	a.Nil(err)
+	resp.Body.Close()
+	a.Equal(http.StatusOK, resp.StatusCode)
+
+	a.NotNil(SetTransportOptions(TransportOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}))
+	a.NotNil(SetTransportOptions(TransportOptions{CertFile: caFile}))
+}
+
+func TestTransportProxy(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	defer SetTransportOptions(TransportOptions{})
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		a.Equal("Basic dXNlcjpwYXNz", r.Header.Get("Proxy-Authorization"))
+		w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("local"))
+	}))
+	defer local.Close()
+
+	a.Nil(SetTransportOptions(TransportOptions{Proxy: strings.Replace(proxy.URL, "http://", "http://user:pass@", 1)}))
+	client := NewClient(time.Second)
+
+	resp, err := client.Get("http://api.twitch.example/helix/users")
+	a.Nil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	a.Equal("proxied", string(body))
+	a.Equal([]string{"http://api.twitch.example/helix/users"}, proxied)
+
+	// requests to localhost, such as the mock API, aren't proxied
+	resp, err = client.Get(local.URL)
+	a.Nil(err)
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	a.Equal("local", string(body))
+	a.Len(proxied, 1)
+
+	a.NotNil(SetTransportOptions(TransportOptions{Proxy: "not a url"}))
+}
+
+func TestTransportHAR(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	defer SetTransportOptions(TransportOptions{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "capture.har")
+	a.Nil(SetTransportOptions(TransportOptions{HARFile: path, Timeout: 5 * time.Second}))
+	client := NewClient(time.Second)
+	a.Equal(5*time.Second, client.Timeout)
+	// the update check keeps its own timeout
+	a.Equal(2*time.Second, NewClientWithTimeout(2*time.Second).Timeout)
+
+	req, err := NewRequest(http.MethodPost, ts.URL+"/token?client_id=1&client_secret=s3cr3t", strings.NewReader(`{"a":1}`))
+	a.Nil(err)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.Do(req)
+	a.Nil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// the captured body is still returned to the caller
+	a.Equal(`{"data":[]}`, string(body))
+
+	b, err := os.ReadFile(path)
+	a.Nil(err)
+	a.NotContains(string(b), "s3cr3t")
+	a.NotContains(string(b), "Bearer token")
+
+	var har harFile
+	a.Nil(json.Unmarshal(b, &har))
+	a.Equal("1.2", har.Log.Version)
+	a.Len(har.Log.Entries, 1)
+	e := har.Log.Entries[0]
+	a.Equal(http.MethodPost, e.Request.Method)
+	a.Equal(`{"a":1}`, e.Request.PostData.Text)
+	a.Equal(http.StatusOK, e.Response.Status)
+	a.Equal(`{"data":[]}`, e.Response.Content.Text)
+	a.Equal("application/json", e.Response.Content.MimeType)
+
+	// clients that change the transport, such as event forwarding, are captured too
+	forward := &http.Client{Transport: WrapTransport(NewTransport())}
+	resp, err = forward.Get(ts.URL)
+	a.Nil(err)
+	resp.Body.Close()
+	b, _ = os.ReadFile(path)
+	a.Nil(json.Unmarshal(b, &har))
+	a.Len(har.Log.Entries, 2)
+}
@@ -23,8 +23,8 @@ type updateCheckerReleasesResponse struct {
 }
 
 // Check Github's Releases API to see if we're running the latest version.
-// If there's any errors, quietly allow it to fail.
-func CheckForUpdatesAndPrintNotice() {
+// If there's any errors, quietly allow it to fail. The client is passed in so it can use the CLI's shared transport.
+func CheckForUpdatesAndPrintNotice(client *http.Client) {
 	// Don't bother running this if current application is built from source
 	if strings.EqualFold(GetVersion(), "source") {
 		return
@@ -42,7 +42,7 @@ func CheckForUpdatesAndPrintNotice() {
 		return
 	}
 
-	runningLatestVersion, latestVersionTag, err := areWeRunningLatestVersion()
+	runningLatestVersion, latestVersionTag, err := areWeRunningLatestVersion(client)
 	if err != nil {
 		return // Drop errors without notifying
 	}
@@ -87,11 +87,7 @@ func CheckForUpdatesAndPrintNotice() {
 }
 
 // Makes the call to Github's Releases API to check for the latest release version, and compares it to the current version.
-func areWeRunningLatestVersion() (bool, string, error) {
-	client := &http.Client{
-		Timeout: time.Second * 2,
-	}
-
+func areWeRunningLatestVersion(client *http.Client) (bool, string, error) {
 	req, err := http.NewRequest("GET", "https://api.github.com/repos/twitchdev/twitch-cli/releases/latest", nil)
 	if err != nil {
 		return false, "", err
@@ -0,0 +1,190 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package eventsub
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/events/payload"
+	"github.com/twitchdev/twitch-cli/internal/events/trigger"
+	"github.com/twitchdev/twitch-cli/internal/events/types"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+// Params are the parameters of a generated event, matching the flags of `twitch event trigger`. Anything left empty is
+// filled in the same way, such as random user IDs and the current time.
+type Params struct {
+	// Event is a trigger such as "channel.follow" or "subscribe"
+	Event string
+	// Transport is "webhook" or "websocket", defaulting to webhook
+	Transport string
+	// Version is the subscription version, defaulting to the latest
+	Version string
+
+	FromUserID          string
+	FromUserName        string
+	ToUserID            string
+	ToUserName          string
+	GiftUserID          string
+	ModeratorUserID     string
+	ModeratorUserName   string
+	IsAnonymous         bool
+	EventStatus         string
+	SubscriptionStatus  string
+	SubscriptionID      string
+	MessageID           string
+	ItemID              string
+	ItemName            string
+	Cost                int64
+	Description         string
+	GameID              string
+	Tier                string
+	Timestamp           string
+	ClientID            string
+	CharityCurrentValue int
+	CharityTargetValue  int
+	BanStartTimestamp   string
+	BanEndTimestamp     string
+	MessageText         string
+	NoticeType          string
+	Color               string
+	ModerateAction      string
+	RewardType          string
+	ParticipantIDs      []string
+
+	// Set overrides fields of the payload, as path=value assignments like `--set`, such as event.reward.title=Hydrate
+	Set []string
+}
+
+// Event is a generated event.
+type Event struct {
+	// Type is the subscription type, such as channel.follow
+	Type      string
+	Version   string
+	Transport string
+	// MessageType is notification, or revocation when the subscription status isn't enabled
+	MessageType string
+	MessageID   string
+	Timestamp   string
+	// Payload is the body of the notification, with the subscription and event
+	Payload []byte
+}
+
+// Events returns every trigger that can be generated, such as channel.follow, sorted.
+func Events() []string {
+	seen := map[string]bool{}
+	var all []string
+	for _, e := range types.AllEvents() {
+		for _, transport := range []string{models.TransportWebhook, models.TransportWebSocket} {
+			for _, topic := range e.GetAllTopicsByTransport(transport) {
+				if !seen[topic] {
+					seen[topic] = true
+					all = append(all, topic)
+				}
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// Generate builds an event the same way `twitch event trigger` does, without storing or sending it.
+func Generate(p Params) (Event, error) {
+	if p.Transport == "" {
+		p.Transport = models.TransportWebhook
+	}
+	if p.SubscriptionStatus == "" {
+		p.SubscriptionStatus = "enabled"
+	}
+
+	g, err := trigger.Generate(trigger.TriggerParameters{
+		Event:               p.Event,
+		Transport:           p.Transport,
+		Version:             p.Version,
+		FromUser:            p.FromUserID,
+		FromUserName:        p.FromUserName,
+		ToUser:              p.ToUserID,
+		ToUserName:          p.ToUserName,
+		GiftUser:            p.GiftUserID,
+		ModeratorUser:       p.ModeratorUserID,
+		ModeratorUserName:   p.ModeratorUserName,
+		IsAnonymous:         p.IsAnonymous,
+		EventStatus:         p.EventStatus,
+		SubscriptionStatus:  p.SubscriptionStatus,
+		SubscriptionID:      p.SubscriptionID,
+		EventMessageID:      p.MessageID,
+		ItemID:              p.ItemID,
+		ItemName:            p.ItemName,
+		Cost:                p.Cost,
+		Description:         p.Description,
+		GameID:              p.GameID,
+		Tier:                p.Tier,
+		Timestamp:           p.Timestamp,
+		ClientID:            p.ClientID,
+		CharityCurrentValue: p.CharityCurrentValue,
+		CharityTargetValue:  p.CharityTargetValue,
+		BanStartTimestamp:   p.BanStartTimestamp,
+		BanEndTimestamp:     p.BanEndTimestamp,
+		MessageText:         p.MessageText,
+		NoticeType:          p.NoticeType,
+		Color:               p.Color,
+		ModerateAction:      p.ModerateAction,
+		RewardType:          p.RewardType,
+		ParticipantIDs:      p.ParticipantIDs,
+	})
+	if err != nil {
+		return Event{}, err
+	}
+
+	body := g.Response.JSON
+	if len(p.Set) != 0 {
+		o, err := payload.NewOverrides(p.Set, nil, nil)
+		if err != nil {
+			return Event{}, err
+		}
+		body, err = o.Apply(body)
+		if err != nil {
+			return Event{}, err
+		}
+	}
+
+	messageType := MessageTypeNotification
+	if !strings.EqualFold(g.Parameters.SubscriptionStatus, "enabled") {
+		messageType = MessageTypeRevocation
+	}
+
+	return Event{
+		Type:        g.Topic,
+		Version:     g.Version,
+		Transport:   g.Parameters.Transport,
+		MessageType: messageType,
+		// the same message ID `twitch event trigger` sends in the header
+		MessageID: g.Response.ID,
+		Timestamp: g.Parameters.Timestamp,
+		Payload:   body,
+	}, nil
+}
+
+// Decode unmarshals the payload into v, such as a *FollowEventSubResponse.
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// WebhookRequest builds the POST request Twitch would send to a webhook callback, with the EventSub headers and a
+// signature from secret.
+func (e Event) WebhookRequest(callback string, secret string) (*http.Request, error) {
+	return trigger.NewForwardRequest(trigger.ForwardParamters{
+		ID:                  e.MessageID,
+		ForwardAddress:      callback,
+		JSON:                e.Payload,
+		Transport:           models.TransportWebhook,
+		Timestamp:           e.Timestamp,
+		Secret:              secret,
+		Event:               e.Type,
+		Type:                e.MessageType,
+		SubscriptionVersion: e.Version,
+	})
+}
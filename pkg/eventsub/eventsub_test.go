@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package eventsub
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestGenerate(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Contains(Events(), "channel.follow")
+
+	e, err := Generate(Params{Event: "channel.follow", ToUserID: "1234", Set: []string{"event.user_name=Override"}})
+	a.Nil(err)
+	a.Equal("channel.follow", e.Type)
+	a.Equal(MessageTypeNotification, e.MessageType)
+
+	var follow FollowEventSubResponse
+	a.Nil(e.Decode(&follow))
+	a.Equal("1234", follow.Event.BroadcasterUserID)
+	a.Equal("Override", follow.Event.UserName)
+	a.Equal("channel.follow", follow.Subscription.Type)
+
+	e, err = Generate(Params{Event: "channel.follow", SubscriptionStatus: "user_removed"})
+	a.Nil(err)
+	a.Equal(MessageTypeRevocation, e.MessageType)
+
+	_, err = Generate(Params{Event: "not.an.event"})
+	a.NotNil(err)
+}
+
+func TestVerifyRequest(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e, err := Generate(Params{Event: "channel.follow"})
+	a.Nil(err)
+
+	req, err := e.WebhookRequest("http://localhost/eventsub", "secretsecret")
+	a.Nil(err)
+	a.Equal(e.MessageID, req.Header.Get(HeaderMessageID))
+	a.Equal("channel.follow", req.Header.Get(HeaderSubscriptionType))
+	a.Equal(MessageTypeNotification, req.Header.Get(HeaderMessageType))
+
+	body, err := VerifyRequest("secretsecret", req)
+	a.Nil(err)
+	a.Equal(e.Payload, body)
+	// the body can still be read
+	b, _ := io.ReadAll(req.Body)
+	a.Equal(e.Payload, b)
+
+	a.ErrorIs(VerifySignature("wrongsecret", req.Header, body), ErrInvalidSignature)
+	a.ErrorIs(VerifySignature("secretsecret", req.Header, append(body, ' ')), ErrInvalidSignature)
+	a.ErrorIs(VerifySignature("secretsecret", http.Header{}, body), ErrInvalidSignature)
+
+	// the timestamp is signed as sent, including trailing zeros Go wouldn't format
+	timestamp := time.Now().UTC().Truncate(time.Second).Format("2006-01-02T15:04:05.000Z07:00")
+	header := http.Header{}
+	header.Set(HeaderMessageID, "abc")
+	header.Set(HeaderMessageTimestamp, timestamp)
+	header.Set(HeaderMessageSignature, Signature("secretsecret", "abc", timestamp, body))
+	a.True(ValidSignature("secretsecret", header, body))
+	a.Nil(VerifySignature("secretsecret", header, body))
+
+	stale, err := Generate(Params{Event: "channel.follow", Timestamp: time.Now().Add(-11 * time.Minute).UTC().Format(time.RFC3339Nano)})
+	a.Nil(err)
+	req, err = stale.WebhookRequest("http://localhost/eventsub", "secretsecret")
+	a.Nil(err)
+	_, err = VerifyRequest("secretsecret", req)
+	a.ErrorIs(err, ErrStaleMessage)
+}
@@ -0,0 +1,123 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package eventsub
+
+import "github.com/twitchdev/twitch-cli/internal/models"
+
+// The payload types are the same models the CLI generates events from, so payloads unmarshal the same way in both.
+type (
+	Subscription             = models.EventsubSubscription
+	Transport                = models.EventsubTransport
+	Condition                = models.EventsubCondition
+	SubscriptionVerification = models.EventsubSubscriptionVerification
+	// Notification is any notification payload, with the event left as a map. Use the event's own response type, such as
+	// FollowEventSubResponse, to unmarshal it into a struct.
+	Notification = models.EventsubResponse
+)
+
+// Event and response types, by event
+type (
+	AdBreakBeginEventSubEvent                 = models.AdBreakBeginEventSubEvent
+	AdBreakBeginEventSubResponse              = models.AdBreakBeginEventSubResponse
+	AuthorizationRevokeEventSubResponse       = models.AuthorizationRevokeEventSubResponse
+	AutomaticRewardRedemptionEventSubEvent    = models.AutomaticRewardRedemptionEventSubEvent
+	AutomaticRewardRedemptionEventSubResponse = models.AutomaticRewardRedemptionEventSubResponse
+	AutomodMessageHoldEventSubEvent           = models.AutomodMessageHoldEventSubEvent
+	AutomodMessageHoldEventSubResponse        = models.AutomodMessageHoldEventSubResponse
+	AutomodMessageUpdateEventSubEvent         = models.AutomodMessageUpdateEventSubEvent
+	AutomodMessageUpdateEventSubResponse      = models.AutomodMessageUpdateEventSubResponse
+	AutomodSettingsUpdateEventSubEvent        = models.AutomodSettingsUpdateEventSubEvent
+	AutomodSettingsUpdateEventSubResponse     = models.AutomodSettingsUpdateEventSubResponse
+	AutomodTermsUpdateEventSubEvent           = models.AutomodTermsUpdateEventSubEvent
+	AutomodTermsUpdateEventSubResponse        = models.AutomodTermsUpdateEventSubResponse
+	BanEventSubEvent                          = models.BanEventSubEvent
+	BanEventSubResponse                       = models.BanEventSubResponse
+	BitsUseEventSubEvent                      = models.BitsUseEventSubEvent
+	BitsUseEventSubResponse                   = models.BitsUseEventSubResponse
+	ChannelUpdateEventSubEvent                = models.ChannelUpdateEventSubEvent
+	ChannelUpdateEventSubResponse             = models.ChannelUpdateEventSubResponse
+	CharityEventSubEvent                      = models.CharityEventSubEvent
+	CharityEventSubEventAmount                = models.CharityEventSubEventAmount
+	CharityEventSubResponse                   = models.CharityEventSubResponse
+	ChatClearEventSubEvent                    = models.ChatClearEventSubEvent
+	ChatClearEventSubResponse                 = models.ChatClearEventSubResponse
+	ChatMessageDeleteEventSubEvent            = models.ChatMessageDeleteEventSubEvent
+	ChatMessageDeleteEventSubResponse         = models.ChatMessageDeleteEventSubResponse
+	ChatMessageEventSubEvent                  = models.ChatMessageEventSubEvent
+	ChatMessageEventSubResponse               = models.ChatMessageEventSubResponse
+	ChatNotificationEventSubEvent             = models.ChatNotificationEventSubEvent
+	ChatNotificationEventSubResponse          = models.ChatNotificationEventSubResponse
+	CheerEventSubEvent                        = models.CheerEventSubEvent
+	CheerEventSubResponse                     = models.CheerEventSubResponse
+	DropsEntitlementEventSubEvent             = models.DropsEntitlementEventSubEvent
+	DropsEntitlementEventSubEventData         = models.DropsEntitlementEventSubEventData
+	DropsEntitlementEventSubResponse          = models.DropsEntitlementEventSubResponse
+	FollowEventSubEvent                       = models.FollowEventSubEvent
+	FollowEventSubResponse                    = models.FollowEventSubResponse
+	GiftEventSubEvent                         = models.GiftEventSubEvent
+	GiftEventSubResponse                      = models.GiftEventSubResponse
+	GoalEventSubEvent                         = models.GoalEventSubEvent
+	GoalEventSubResponse                      = models.GoalEventSubResponse
+	GuestStarGuestUpdateEventSubEvent         = models.GuestStarGuestUpdateEventSubEvent
+	GuestStarGuestUpdateEventSubResponse      = models.GuestStarGuestUpdateEventSubResponse
+	GuestStarSessionEventSubEvent             = models.GuestStarSessionEventSubEvent
+	GuestStarSessionEventSubResponse          = models.GuestStarSessionEventSubResponse
+	GuestStarSettingsUpdateEventSubEvent      = models.GuestStarSettingsUpdateEventSubEvent
+	GuestStarSettingsUpdateEventSubResponse   = models.GuestStarSettingsUpdateEventSubResponse
+	HypeTrainEventSubEvent                    = models.HypeTrainEventSubEvent
+	HypeTrainEventSubResponse                 = models.HypeTrainEventSubResponse
+	ModerateEventSubEvent                     = models.ModerateEventSubEvent
+	ModerateEventSubResponse                  = models.ModerateEventSubResponse
+	ModeratorChangeEventSubEvent              = models.ModeratorChangeEventSubEvent
+	ModeratorChangeEventSubResponse           = models.ModeratorChangeEventSubResponse
+	PollEventSubEvent                         = models.PollEventSubEvent
+	PollEventSubEventChoice                   = models.PollEventSubEventChoice
+	PollEventSubEventGoodVoting               = models.PollEventSubEventGoodVoting
+	PollEventSubResponse                      = models.PollEventSubResponse
+	PredictionEventSubEvent                   = models.PredictionEventSubEvent
+	PredictionEventSubEventOutcomes           = models.PredictionEventSubEventOutcomes
+	PredictionEventSubEventTopPredictors      = models.PredictionEventSubEventTopPredictors
+	PredictionEventSubResponse                = models.PredictionEventSubResponse
+	RaidEventSubResponse                      = models.RaidEventSubResponse
+	RedemptionEventSubEvent                   = models.RedemptionEventSubEvent
+	RedemptionEventSubResponse                = models.RedemptionEventSubResponse
+	RewardEventSubEvent                       = models.RewardEventSubEvent
+	RewardEventSubResponse                    = models.RewardEventSubResponse
+	SharedChatEventSubEvent                   = models.SharedChatEventSubEvent
+	SharedChatEventSubResponse                = models.SharedChatEventSubResponse
+	ShieldModeEventSubEvent                   = models.ShieldModeEventSubEvent
+	ShieldModeEventSubResponse                = models.ShieldModeEventSubResponse
+	ShoutoutCreateEventSubEvent               = models.ShoutoutCreateEventSubEvent
+	ShoutoutCreateEventSubResponse            = models.ShoutoutCreateEventSubResponse
+	ShoutoutReceivedEventSubEvent             = models.ShoutoutReceivedEventSubEvent
+	ShoutoutReceivedEventSubResponse          = models.ShoutoutReceivedEventSubResponse
+	StreamDownEventSubEvent                   = models.StreamDownEventSubEvent
+	StreamDownEventSubResponse                = models.StreamDownEventSubResponse
+	StreamUpEventSubEvent                     = models.StreamUpEventSubEvent
+	StreamUpEventSubResponse                  = models.StreamUpEventSubResponse
+	SubEventSubEvent                          = models.SubEventSubEvent
+	SubEventSubResponse                       = models.SubEventSubResponse
+	SubscribeMessageEventSubEvent             = models.SubscribeMessageEventSubEvent
+	SubscribeMessageEventSubMessage           = models.SubscribeMessageEventSubMessage
+	SubscribeMessageEventSubMessageEmote      = models.SubscribeMessageEventSubMessageEmote
+	SubscribeMessageEventSubResponse          = models.SubscribeMessageEventSubResponse
+	SuspiciousUserMessageEventSubEvent        = models.SuspiciousUserMessageEventSubEvent
+	SuspiciousUserMessageEventSubResponse     = models.SuspiciousUserMessageEventSubResponse
+	SuspiciousUserUpdateEventSubEvent         = models.SuspiciousUserUpdateEventSubEvent
+	SuspiciousUserUpdateEventSubResponse      = models.SuspiciousUserUpdateEventSubResponse
+	TransactionEventSubEvent                  = models.TransactionEventSubEvent
+	TransactionEventSubProduct                = models.TransactionEventSubProduct
+	TransactionEventSubResponse               = models.TransactionEventSubResponse
+	UnbanEventSubEvent                        = models.UnbanEventSubEvent
+	UnbanRequestCreateEventSubEvent           = models.UnbanRequestCreateEventSubEvent
+	UnbanRequestCreateEventSubResponse        = models.UnbanRequestCreateEventSubResponse
+	UnbanRequestResolveEventSubEvent          = models.UnbanRequestResolveEventSubEvent
+	UserUpdateEventSubEvent                   = models.UserUpdateEventSubEvent
+	UserUpdateEventSubResponse                = models.UserUpdateEventSubResponse
+	VIPEventSubEvent                          = models.VIPEventSubEvent
+	VIPEventSubResponse                       = models.VIPEventSubResponse
+	WarningAcknowledgeEventSubEvent           = models.WarningAcknowledgeEventSubEvent
+	WarningSendEventSubEvent                  = models.WarningSendEventSubEvent
+	WhisperMessageEventSubEvent               = models.WhisperMessageEventSubEvent
+	WhisperMessageEventSubResponse            = models.WhisperMessageEventSubResponse
+)
@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/request"
+)
+
+// DefaultTokenURL is the production OAuth token endpoint. A mockapi.Server has its own at AuthURL() + "/token".
+const DefaultTokenURL = "https://id.twitch.tv/oauth2/token"
+
+// Tokens are refreshed this long before they expire, so they don't expire in flight
+const refreshMargin = time.Minute
+
+// AuthProvider provides the token each request is sent with.
+type AuthProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) { return string(t), nil }
+
+// StaticToken authenticates with a token that's never refreshed, such as one from `twitch token`.
+func StaticToken(token string) AuthProvider {
+	return staticToken(token)
+}
+
+// Token is an access token returned by the token endpoint.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Scopes       []string
+	// ExpiresAt is zero when the token doesn't expire
+	ExpiresAt time.Time
+}
+
+// ClientCredentials authenticates with app access tokens from the client credentials grant, fetching a new one when it expires.
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// TokenURL defaults to DefaultTokenURL
+	TokenURL string
+
+	mu    sync.Mutex
+	token Token
+}
+
+func (c *ClientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.AccessToken != "" && !expiring(c.token) {
+		return c.token.AccessToken, nil
+	}
+
+	t, err := requestToken(ctx, c.TokenURL, url.Values{
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"grant_type":    {"client_credentials"},
+		"scope":         {strings.Join(c.Scopes, " ")},
+	})
+	if err != nil {
+		return "", err
+	}
+	c.token = t
+	return t.AccessToken, nil
+}
+
+// RefreshingToken authenticates with a user access token, using its refresh token to get a new one when it expires.
+type RefreshingToken struct {
+	ClientID     string
+	ClientSecret string
+	// TokenURL defaults to DefaultTokenURL
+	TokenURL string
+	// OnRefresh is called with each new token, such as to store it
+	OnRefresh func(Token)
+
+	mu    sync.Mutex
+	token Token
+}
+
+// NewRefreshingToken returns a provider starting with the given token.
+func NewRefreshingToken(clientID string, clientSecret string, token Token) *RefreshingToken {
+	return &RefreshingToken{ClientID: clientID, ClientSecret: clientSecret, token: token}
+}
+
+func (r *RefreshingToken) Token(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.token.AccessToken != "" && !expiring(r.token) {
+		return r.token.AccessToken, nil
+	}
+	if r.token.RefreshToken == "" {
+		return "", fmt.Errorf("Token has expired and there's no refresh token")
+	}
+
+	t, err := requestToken(ctx, r.TokenURL, url.Values{
+		"client_id":     {r.ClientID},
+		"client_secret": {r.ClientSecret},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {r.token.RefreshToken},
+	})
+	if err != nil {
+		return "", err
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = r.token.RefreshToken
+	}
+	r.token = t
+	if r.OnRefresh != nil {
+		r.OnRefresh(t)
+	}
+	return t.AccessToken, nil
+}
+
+func expiring(t Token) bool {
+	return !t.ExpiresAt.IsZero() && time.Now().Add(refreshMargin).After(t.ExpiresAt)
+}
+
+func requestToken(ctx context.Context, tokenURL string, params url.Values) (Token, error) {
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return Token{}, fmt.Errorf("Error getting url: %v", err)
+	}
+	q := u.Query()
+	for k, values := range params {
+		if len(values) != 0 && values[0] != "" {
+			q[k] = values
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := request.NewRequest(http.MethodPost, u.String(), nil)
+	if err != nil {
+		return Token{}, err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return Token{}, fmt.Errorf("Error requesting token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, fmt.Errorf("Error reading body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("Error requesting token: [%v - `%v`]", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var r struct {
+		AccessToken  string   `json:"access_token"`
+		RefreshToken string   `json:"refresh_token"`
+		ExpiresIn    int64    `json:"expires_in"`
+		Scope        []string `json:"scope"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Token{}, fmt.Errorf("Error unmarshalling token: %v", err)
+	}
+
+	t := Token{AccessToken: r.AccessToken, RefreshToken: r.RefreshToken, Scopes: r.Scope}
+	if r.ExpiresIn > 0 {
+		t.ExpiresAt = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return t, nil
+}
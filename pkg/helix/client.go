@@ -0,0 +1,177 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package helix is a client for the Twitch Helix API, built on the same rate limited client the CLI's api commands use.
+// Requests are paced to stay within the rate limits, 429s are retried once the bucket resets, and idempotent requests
+// failing with a 5xx are retried with backoff.
+//
+//	c := helix.NewClient(clientID, helix.StaticToken(token))
+//	resp, err := c.Get(ctx, "/users", url.Values{"login": {"twitchdev"}})
+package helix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/request"
+	"golang.org/x/time/rate"
+)
+
+// DefaultBaseURL is the production Helix API. Point a Client at a mock API server with its BaseURL.
+const DefaultBaseURL = "https://api.twitch.tv/helix"
+
+// Client makes authenticated requests to the Helix API. It's safe for concurrent use, and requests share its rate limit.
+type Client struct {
+	ClientID string
+	Auth     AuthProvider
+	// BaseURL defaults to DefaultBaseURL, such as the HelixURL of a mockapi.Server
+	BaseURL string
+
+	http *api.RLClient
+}
+
+// NewClient returns a client for the given Client ID, authenticating each request with a token from auth.
+// The rate limit matches the default Helix bucket of 800 points per minute.
+func NewClient(clientID string, auth AuthProvider) *Client {
+	return &Client{
+		ClientID: clientID,
+		Auth:     auth,
+		BaseURL:  DefaultBaseURL,
+		http:     api.NewClient(rate.NewLimiter(rate.Every(time.Minute/800), 800)),
+	}
+}
+
+// Response is a Helix API response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Data is the data field of the response, which is usually an array
+	Data json.RawMessage
+	// Cursor is the cursor of the next page, or empty on the last page
+	Cursor string
+	// Total is set by the endpoints that return the total number of results
+	Total *int
+	// Body is the whole response body
+	Body []byte
+}
+
+// Decode unmarshals the data of the response into v.
+func (r *Response) Decode(v any) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+// APIError is returned for responses with a status code outside of 2xx.
+type APIError struct {
+	StatusCode int
+	Status     string `json:"error"`
+	Message    string `json:"message"`
+	Body       []byte `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%v %v", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%v %v: %v", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+type envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Total      *int            `json:"total"`
+	Pagination *struct {
+		Cursor string `json:"cursor"`
+	} `json:"pagination"`
+}
+
+// Get sends a GET request to path, such as /users.
+func (c *Client) Get(ctx context.Context, path string, query url.Values) (*Response, error) {
+	return c.Do(ctx, http.MethodGet, path, query, nil)
+}
+
+// Do sends a request to path, such as /users. A non-nil body is sent as JSON, either as given when it's a []byte or
+// json.RawMessage, or otherwise marshalled. Responses outside of 2xx are returned as an *APIError.
+func (c *Client) Do(ctx context.Context, method string, path string, query url.Values, body any) (*Response, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.BaseURL, "/") + "/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("Error getting url: %v", err)
+	}
+	if len(query) != 0 {
+		q := u.Query()
+		for k, values := range query {
+			for _, v := range values {
+				q.Add(k, v)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	var payload []byte
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		payload = b
+	case json.RawMessage:
+		payload = b
+	default:
+		payload, err = json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("Error marshalling body: %v", err)
+		}
+	}
+
+	req, err := request.NewRequest(strings.ToUpper(method), u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Client-ID", c.ClientID)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Auth != nil {
+		token, err := c.Auth.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading body: %v", err)
+	}
+
+	if resp.StatusCode > 299 || resp.StatusCode < 200 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: b}
+		json.Unmarshal(b, apiErr)
+		return nil, apiErr
+	}
+
+	r := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: b}
+	if len(b) != 0 {
+		var e envelope
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("Error unmarshalling body: %v", err)
+		}
+		r.Data = e.Data
+		r.Total = e.Total
+		if e.Pagination != nil {
+			r.Cursor = e.Pagination.Cursor
+		}
+	}
+	return r, nil
+}
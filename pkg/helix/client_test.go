@@ -0,0 +1,145 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestClientDo(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("client", r.Header.Get("Client-ID"))
+		a.Equal("Bearer token", r.Header.Get("Authorization"))
+		a.Contains(r.Header.Get("User-Agent"), "twitch-cli/")
+
+		switch r.URL.Path {
+		case "/helix/users":
+			a.Equal("twitchdev", r.URL.Query().Get("login"))
+			w.Write([]byte(`{"data":[{"id":"1","login":"twitchdev"}],"total":1}`))
+		case "/helix/chat/announcements":
+			a.Equal("application/json", r.Header.Get("Content-Type"))
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			a.Equal("hi", body["message"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","status":404,"message":"no such endpoint"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient("client", StaticToken("token"))
+	c.BaseURL = ts.URL + "/helix"
+
+	resp, err := c.Get(ctx, "/users", url.Values{"login": {"twitchdev"}})
+	a.Nil(err)
+	var users []struct {
+		ID    string `json:"id"`
+		Login string `json:"login"`
+	}
+	a.Nil(resp.Decode(&users))
+	a.Equal("twitchdev", users[0].Login)
+	a.Equal(1, *resp.Total)
+	a.Equal("", resp.Cursor)
+
+	resp, err = c.Do(ctx, http.MethodPost, "chat/announcements", nil, map[string]string{"message": "hi"})
+	a.Nil(err)
+	a.Equal(http.StatusNoContent, resp.StatusCode)
+
+	_, err = c.Get(ctx, "/nope", nil)
+	apiErr, ok := err.(*APIError)
+	a.True(ok)
+	a.Equal(http.StatusNotFound, apiErr.StatusCode)
+	a.Equal("no such endpoint", apiErr.Message)
+}
+
+func TestPager(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		a.Equal("10", r.URL.Query().Get("broadcaster_id"))
+		cursor := ""
+		if page < 2 {
+			cursor = strconv.Itoa(page + 1)
+		}
+		fmt.Fprintf(w, `{"data":[{"page":%v},{"page":%v}],"pagination":{"cursor":"%v"}}`, page, page, cursor)
+	}))
+	defer ts.Close()
+
+	c := NewClient("client", StaticToken("token"))
+	c.BaseURL = ts.URL
+
+	p := c.Paginate("/channels/followers", url.Values{"broadcaster_id": {"10"}})
+	pages := 0
+	for p.Next(ctx) {
+		a.Contains(string(p.Page().Data), fmt.Sprintf(`"page":%v`, pages))
+		pages++
+	}
+	a.Nil(p.Err())
+	a.Equal(3, pages)
+
+	items, err := c.Paginate("/channels/followers", url.Values{"broadcaster_id": {"10"}}).All(ctx)
+	a.Nil(err)
+	a.Len(items, 6)
+}
+
+func TestAuthProviders(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		a.Equal("client", q.Get("client_id"))
+		a.Equal("secret", q.Get("client_secret"))
+		switch q.Get("grant_type") {
+		case "client_credentials":
+			fmt.Fprintf(w, `{"access_token":"app%v","expires_in":3600}`, requests)
+		case "refresh_token":
+			a.Equal("refresh", q.Get("refresh_token"))
+			w.Write([]byte(`{"access_token":"user2","refresh_token":"refresh2","expires_in":3600}`))
+		}
+	}))
+	defer ts.Close()
+
+	cc := &ClientCredentials{ClientID: "client", ClientSecret: "secret", TokenURL: ts.URL}
+	token, err := cc.Token(ctx)
+	a.Nil(err)
+	a.Equal("app1", token)
+	// cached until it expires
+	token, err = cc.Token(ctx)
+	a.Nil(err)
+	a.Equal("app1", token)
+	a.Equal(1, requests)
+
+	var refreshed Token
+	rt := NewRefreshingToken("client", "secret", Token{AccessToken: "user1", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)})
+	rt.TokenURL = ts.URL
+	rt.OnRefresh = func(t Token) { refreshed = t }
+	token, err = rt.Token(ctx)
+	a.Nil(err)
+	a.Equal("user1", token)
+
+	rt.token.ExpiresAt = time.Now()
+	token, err = rt.Token(ctx)
+	a.Nil(err)
+	a.Equal("user2", token)
+	a.Equal("refresh2", refreshed.RefreshToken)
+}
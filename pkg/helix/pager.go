@@ -0,0 +1,90 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Pager iterates over the pages of a GET endpoint, following the cursor of each page.
+//
+//	p := c.Paginate("/channels/followers", url.Values{"broadcaster_id": {id}, "first": {"100"}})
+//	for p.Next(ctx) {
+//		var followers []Follower
+//		p.Page().Decode(&followers)
+//	}
+//	if err := p.Err(); err != nil { ... }
+type Pager struct {
+	c     *Client
+	path  string
+	query url.Values
+
+	page *Response
+	err  error
+	done bool
+}
+
+// Paginate returns a Pager over path. No request is made until Next is called.
+func (c *Client) Paginate(path string, query url.Values) *Pager {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string{}, v...)
+	}
+	return &Pager{c: c, path: path, query: q}
+}
+
+// Next fetches the next page, returning false once there are no more pages or a request fails.
+func (p *Pager) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	first := p.page == nil
+	if !first {
+		p.query.Set("after", p.page.Cursor)
+	}
+	page, err := p.c.Do(ctx, http.MethodGet, p.path, p.query, nil)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.page = page
+	// an empty page is also the last, as some endpoints return a cursor regardless
+	p.done = page.Cursor == "" || isEmpty(page.Data)
+	// the first page is returned even when it's empty, so its response can still be inspected
+	return first || !isEmpty(page.Data)
+}
+
+// Page returns the page fetched by the last call to Next.
+func (p *Pager) Page() *Response {
+	return p.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// All fetches every remaining page, returning the items of each page's data in order.
+func (p *Pager) All(ctx context.Context) ([]json.RawMessage, error) {
+	all := []json.RawMessage{}
+	for p.Next(ctx) {
+		var items []json.RawMessage
+		if err := p.page.Decode(&items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+	}
+	return all, p.Err()
+}
+
+func isEmpty(data json.RawMessage) bool {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return len(data) == 0 || string(data) == "null"
+	}
+	return len(items) == 0
+}
@@ -0,0 +1,179 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mockapi runs the mock API of `twitch mock-api start` in process, in the style of httptest.Server, so Go tests
+// can make requests against it without the CLI. Each server has its own database of generated data.
+//
+//	s, err := mockapi.NewServer(mockapi.Options{})
+//	defer s.Close()
+//	token, _ := s.AppToken()
+//	c := helix.NewClient(s.ClientID, helix.StaticToken(token))
+//	c.BaseURL = s.HelixURL()
+package mockapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_server"
+)
+
+// DefaultUsers is how many users are generated when Options.Users isn't set.
+const DefaultUsers = 10
+
+// Options configures a Server.
+type Options struct {
+	// DatabasePath is the database file to use. It's created and filled with generated data if it doesn't exist.
+	// When empty, a temporary database is generated and removed by Close.
+	DatabasePath string
+	// Users is how many users to generate in a new database, defaulting to DefaultUsers
+	Users int
+	// ForwardAddress is where the EventSub events caused by requests, such as a chat message being sent, are sent as
+	// webhooks signed with EventSecret. When empty, events aren't delivered anywhere. Either way, they're stored only in
+	// the server's own database, and never forwarded to the servers of `twitch event websocket` or `twitch chat`.
+	ForwardAddress string
+	EventSecret    string
+}
+
+// Server is a mock API server listening on a local port.
+type Server struct {
+	// URL is the base URL of the server, such as http://127.0.0.1:54321
+	URL string
+	// ClientID and ClientSecret are of the generated client, for use with the token endpoints
+	ClientID     string
+	ClientSecret string
+
+	server  *httptest.Server
+	db      database.CLIDatabase
+	emitter *mock_events.Emitter
+	tmpDir  string
+}
+
+// NewServer starts a server, generating its data first when the database is new.
+func NewServer(opts Options) (*Server, error) {
+	s := &Server{}
+
+	path := opts.DatabasePath
+	if path == "" {
+		dir, err := os.MkdirTemp("", "twitch-mock-api")
+		if err != nil {
+			return nil, err
+		}
+		s.tmpDir = dir
+		path = filepath.Join(dir, "mock.db")
+	}
+
+	db, err := database.NewConnectionAt(path, false)
+	if err != nil {
+		s.removeTmpDir()
+		return nil, fmt.Errorf("Error connecting to database: %v", err)
+	}
+	s.db = db
+
+	if db.IsFirstRun() {
+		users := opts.Users
+		if users == 0 {
+			users = DefaultUsers
+		}
+		if err := generate.GenerateInto(db, users); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	clients, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{})
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	for _, c := range clients.Data.([]database.AuthenticationClient) {
+		if !c.IsExtension {
+			s.ClientID = c.ID
+			s.ClientSecret = c.Secret
+			break
+		}
+	}
+
+	s.emitter = &mock_events.Emitter{ForwardAddress: opts.ForwardAddress, Secret: opts.EventSecret}
+
+	m := http.NewServeMux()
+	mock_server.RegisterHandlers(m)
+	s.server = httptest.NewUnstartedServer(m)
+	s.server.Config.BaseContext = func(net.Listener) context.Context {
+		ctx := context.WithValue(context.Background(), "db", db)
+		return context.WithValue(ctx, "emitter", s.emitter)
+	}
+	s.server.Start()
+	s.URL = s.server.URL
+
+	return s, nil
+}
+
+// HelixURL is the base URL of the mock Helix API, for the BaseURL of a helix.Client.
+func (s *Server) HelixURL() string {
+	return s.URL + mock_server.MOCK_NAMESPACE
+}
+
+// AuthURL is the base URL of the mock OAuth endpoints: /token, /authorize and /validate.
+func (s *Server) AuthURL() string {
+	return s.URL + mock_server.AUTH_NAMESPACE
+}
+
+// AppToken returns a new app access token for the generated client, with the given scopes.
+func (s *Server) AppToken(scopes ...string) (string, error) {
+	return s.token("", scopes)
+}
+
+// UserToken returns a new user access token for userID, with the given scopes.
+func (s *Server) UserToken(userID string, scopes ...string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("A user ID is required")
+	}
+	return s.token(userID, scopes)
+}
+
+func (s *Server) token(userID string, scopes []string) (string, error) {
+	a, err := s.db.NewQuery(nil, 100).CreateAuthorization(database.Authorization{
+		ClientID: s.ClientID,
+		UserID:   userID,
+		Scopes:   strings.Join(scopes, " "),
+	})
+	if err != nil {
+		return "", err
+	}
+	return a.Token, nil
+}
+
+// UserIDs returns the IDs of the generated users.
+func (s *Server) UserIDs() ([]string, error) {
+	var ids []string
+	err := s.db.DB.Select(&ids, "select id from users order by id")
+	return ids, err
+}
+
+// Close shuts down the server once the events it's delivering are done, and removes the database if it was temporary.
+func (s *Server) Close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+	if s.emitter != nil {
+		s.emitter.Wait()
+	}
+	s.db.DB.Close()
+	s.removeTmpDir()
+}
+
+func (s *Server) removeTmpDir() {
+	if s.tmpDir != "" {
+		os.RemoveAll(s.tmpDir)
+	}
+}
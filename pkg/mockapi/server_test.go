@@ -0,0 +1,187 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mockapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/pkg/helix"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestServer(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	s, err := NewServer(Options{Users: 3})
+	a.Nil(err)
+	dir := s.tmpDir
+
+	a.NotEmpty(s.ClientID)
+	a.NotEmpty(s.ClientSecret)
+
+	ids, err := s.UserIDs()
+	a.Nil(err)
+	a.Len(ids, 3)
+
+	token, err := s.AppToken()
+	a.Nil(err)
+	c := helix.NewClient(s.ClientID, helix.StaticToken(token))
+	c.BaseURL = s.HelixURL()
+
+	resp, err := c.Get(ctx, "/users", url.Values{"id": ids})
+	a.Nil(err)
+	var users []struct {
+		ID string `json:"id"`
+	}
+	a.Nil(resp.Decode(&users))
+	a.Len(users, 3)
+
+	// scopes are checked the same as in the CLI's mock API
+	userToken, err := s.UserToken(ids[0])
+	a.Nil(err)
+	c.Auth = helix.StaticToken(userToken)
+	query := url.Values{"broadcaster_id": {ids[1]}, "user_id": {ids[0]}}
+	_, err = c.Get(ctx, "/subscriptions/user", query)
+	a.Equal(http.StatusUnauthorized, err.(*helix.APIError).StatusCode)
+	userToken, err = s.UserToken(ids[0], "user:read:subscriptions")
+	a.Nil(err)
+	c.Auth = helix.StaticToken(userToken)
+	_, err = c.Get(ctx, "/subscriptions/user", query)
+	if err != nil {
+		a.NotEqual(http.StatusUnauthorized, err.(*helix.APIError).StatusCode)
+	}
+
+	// tokens can also be fetched through the mock token endpoint
+	c.Auth = &helix.ClientCredentials{ClientID: s.ClientID, ClientSecret: s.ClientSecret, TokenURL: s.AuthURL() + "/token"}
+	items, err := c.Paginate("/users", url.Values{"id": ids}).All(ctx)
+	a.Nil(err)
+	a.Len(items, 3)
+
+	s.Close()
+	_, err = os.Stat(dir)
+	a.True(os.IsNotExist(err))
+}
+
+// Servers in the same process, such as in parallel tests, each only see their own database
+func TestServersInParallel(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		s, err := NewServer(Options{Users: 2})
+		a.Nil(err)
+		defer s.Close()
+
+		ids, err := s.UserIDs()
+		a.Nil(err)
+		token, err := s.AppToken()
+		a.Nil(err)
+		c := helix.NewClient(s.ClientID, helix.StaticToken(token))
+		c.BaseURL = s.HelixURL()
+
+		for j := 0; j < 10; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := c.Get(ctx, "/users", url.Values{"id": ids})
+				if !a.Nil(err) {
+					return
+				}
+				var users []struct {
+					ID string `json:"id"`
+				}
+				a.Nil(resp.Decode(&users))
+				a.Len(users, 2)
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestServerDatabasePath(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	path := filepath.Join(t.TempDir(), "mock.db")
+	s, err := NewServer(Options{DatabasePath: path, Users: 2})
+	a.Nil(err)
+	first := s.ClientID
+	s.Close()
+
+	// an existing database is reused as is
+	s, err = NewServer(Options{DatabasePath: path})
+	a.Nil(err)
+	defer s.Close()
+	a.Equal(first, s.ClientID)
+	ids, err := s.UserIDs()
+	a.Nil(err)
+	a.Len(ids, 2)
+}
+
+func TestServerEvents(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ctx := context.Background()
+
+	// the CLI's own forward address and database are never used
+	var cliForwarded int
+	cliForward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cliForwarded++
+	}))
+	defer cliForward.Close()
+	viper.Set("forwardAddress", cliForward.URL)
+	defer viper.Set("forwardAddress", "")
+	viper.Set("DB_FILENAME", "test-mockapi-events.db")
+	defer viper.Set("DB_FILENAME", "test-eventCache.db")
+	home, err := util.GetApplicationDir()
+	a.Nil(err)
+	cliDB := filepath.Join(home, "test-mockapi-events.db")
+	os.Remove(cliDB)
+	defer os.Remove(cliDB)
+
+	var received []byte
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer forward.Close()
+
+	whisper := func(s *Server) {
+		ids, err := s.UserIDs()
+		a.Nil(err)
+		token, err := s.UserToken(ids[0], "user:manage:whispers")
+		a.Nil(err)
+		c := helix.NewClient(s.ClientID, helix.StaticToken(token))
+		c.BaseURL = s.HelixURL()
+		_, err = c.Do(ctx, http.MethodPost, "/whispers", url.Values{"from_user_id": {ids[0]}, "to_user_id": {ids[1]}}, map[string]string{"message": "psst"})
+		a.Nil(err)
+	}
+
+	// events aren't delivered by default
+	s, err := NewServer(Options{Users: 2})
+	a.Nil(err)
+	whisper(s)
+	s.Close()
+	a.Nil(received)
+
+	s, err = NewServer(Options{Users: 2, ForwardAddress: forward.URL, EventSecret: "secretsecret"})
+	a.Nil(err)
+	whisper(s)
+	// Close waits for the events being delivered
+	s.Close()
+	a.Contains(string(received), "psst")
+
+	a.Equal(0, cliForwarded)
+	_, err = os.Stat(cliDB)
+	a.True(os.IsNotExist(err))
+}
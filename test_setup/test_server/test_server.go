@@ -11,9 +11,16 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_events"
 )
 
 func SetupTestServer(next mock_api.MockEndpoint) *httptest.Server {
+	return SetupTestServerWithEmitter(next, nil)
+}
+
+// SetupTestServerWithEmitter is SetupTestServer with an emitter for the events caused by requests. Each response waits
+// for its events to be delivered, so tests can check them once the request returns.
+func SetupTestServerWithEmitter(next mock_api.MockEndpoint, emitter *mock_events.Emitter) *httptest.Server {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.Background()
 
@@ -58,6 +65,10 @@ func SetupTestServer(next mock_api.MockEndpoint) *httptest.Server {
 			"user:read:follows",
 			"user:read:subscriptions",
 		}, UserID: "1", ClientID: "1"})
+		if emitter != nil {
+			ctx = context.WithValue(ctx, "emitter", emitter)
+			defer emitter.Wait()
+		}
 		r = r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)